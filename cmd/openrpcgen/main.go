@@ -0,0 +1,54 @@
+// Package main: Command-line generator of typed Sui RPC client code from OpenRPC document
+// Reads checked-in OpenRPC JSON and writes formatted Go source
+// Runs through go generate in suiapi package
+//
+// main: 根据 OpenRPC 文档生成类型化 Sui RPC 客户端代码的命令行工具
+// 读取已提交的 OpenRPC JSON 并写入格式化的 Go 源码
+// 通过 suiapi 包中的 go generate 运行
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-xlan/sui-go-guide/internal/openrpcgen"
+	"github.com/yyle88/must"
+	"github.com/yyle88/rese"
+)
+
+// main parses flags, generates code and writes output file
+//
+// main 解析参数、生成代码并写入输出文件
+func main() {
+	specPath := flag.String("spec", "openrpc.json", "OpenRPC document path")
+	outPath := flag.String("out", "client_gen.go", "generated Go file path")
+	packageName := flag.String("package", "", "Go package name, defaults to output directory name")
+	prefixes := flag.String("prefixes", "sui_,suix_,unsafe_", "comma separated method prefixes to generate")
+	configPath := flag.String("config", "", "JSON config of hand-written types and methods to leave alone")
+	flag.Parse()
+
+	if *packageName == "" {
+		*packageName = filepath.Base(filepath.Dir(rese.V1(filepath.Abs(*outPath))))
+	}
+
+	// 读取并解析规范
+	document := rese.P1(openrpcgen.ParseDocument(rese.V1(os.ReadFile(*specPath))))
+
+	// 读取手写部分的配置
+	config := &openrpcgen.Config{}
+	if *configPath != "" {
+		config = rese.P1(openrpcgen.ParseConfig(rese.V1(os.ReadFile(*configPath))))
+	}
+
+	// 生成并写入代码
+	source := rese.V1(openrpcgen.Generate(document, openrpcgen.Options{
+		PackageName: *packageName,
+		Source:      filepath.Base(*specPath),
+		Prefixes:    strings.Split(*prefixes, ","),
+		Types:       config.Types,
+		Skip:        config.Skip,
+	}))
+	must.Done(os.WriteFile(*outPath, source, 0o644))
+}
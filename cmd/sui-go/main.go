@@ -1,19 +1,24 @@
 // Package main: Command-line interface to create and manage SUI blockchain wallets
 // Provides wallet generation with random private key creation
 // Displays wallet information including public key and address
+// Checks chain identifier of network chosen with --network
 // Built on cobra CLI framework with colorized terminal output
 //
 // main: 用于创建和管理 SUI 区块链钱包的命令行界面
 // 提供随机私钥创建的钱包生成功能
 // 显示包括公钥和地址在内的钱包信息
+// 校验通过 --network 所选网络的链标识符
 // 基于 cobra CLI 框架构建，带有彩色终端输出
 package main
 
 import (
+	"context"
 	"crypto/rand"
 	"encoding/hex"
 	"fmt"
 
+	"github.com/go-xlan/sui-go-guide/suiapi"
+	"github.com/go-xlan/sui-go-guide/suirpc"
 	"github.com/go-xlan/sui-go-guide/suiwallet"
 	"github.com/spf13/cobra"
 	"github.com/yyle88/eroticgo"
@@ -33,11 +38,19 @@ func main() {
 	var rootCmd = &cobra.Command{
 		Use:   "sui-go",
 		Short: "Sui wallet CLI",
-		Long:  `Command line application to manage Sui wallet operations including [create wallet]`,
+		Long:  `Command line application to manage Sui wallet operations including [create wallet] [check chain]`,
+	}
+
+	// 选择网络 // Choose network by name or RPC URL
+	var networkName string
+	rootCmd.PersistentFlags().StringVar(&networkName, "network", "testnet", "network: mainnet, testnet, devnet, localnet or RPC URL")
+	network := func() suirpc.Network {
+		return rese.V1(suirpc.LookupNetwork(networkName))
 	}
 
 	// 添加子命令
 	rootCmd.AddCommand(createWalletCommand())
+	rootCmd.AddCommand(chainCommand(network))
 
 	// 执行命令 // Execute command
 	must.Done(rootCmd.Execute())
@@ -86,3 +99,30 @@ func newWallet(privateKeyHex string) {
 	fmt.Println(eroticgo.PINK.Sprint("WALLET-ADDRESS:"), wallet.Address())
 	fmt.Println(eroticgo.BLUE.Sprint("----"))
 }
+
+// chainCommand creates subcommand checking chain identifier of chosen network
+// Client built with Network.NewClient verifies chain identifier before the query goes out
+// Fails when node reports chain identifier other than the network expects
+//
+// chainCommand 创建校验所选网络链标识符的子命令
+// 通过 Network.NewClient 创建的客户端在查询发出前校验链标识符
+// 节点报告的链标识符与网络预期不符时失败
+func chainCommand(network func() suirpc.Network) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "chain",
+		Short: "Check chain identifier of the network",
+		Long:  `Query sui_getChainIdentifier and latest checkpoint on the network RPC URL, failing when chain identifier differs from the expected one`,
+		Run: func(cmd *cobra.Command, args []string) {
+			network := network()
+			client := suiapi.NewClient(network.NewClient())
+			ctx := context.Background()
+			chainId := rese.V1(client.GetChainIdentifier(ctx))
+			checkpoint := rese.V1(client.GetLatestCheckpointSequenceNumber(ctx))
+			fmt.Println(eroticgo.CYAN.Sprint("NETWORK:"), network.Name)
+			fmt.Println(eroticgo.BLUE.Sprint("RPC-URL:"), network.RpcUrl)
+			fmt.Println(eroticgo.PINK.Sprint("CHAIN-ID:"), chainId)
+			fmt.Println(eroticgo.BLUE.Sprint("CHECKPOINT:"), checkpoint)
+		},
+	}
+	return cmd
+}
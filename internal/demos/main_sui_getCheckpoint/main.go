@@ -14,49 +14,21 @@ import (
 	"fmt"
 	"strconv"
 
+	"github.com/go-xlan/sui-go-guide/suiapi"
 	"github.com/go-xlan/sui-go-guide/suirpc"
-	"github.com/yyle88/must"
 	"github.com/yyle88/neatjson/neatjsons"
 	"github.com/yyle88/rese"
 )
 
 func main() {
-	const serverUrl = "https://fullnode.devnet.sui.io/"
+	const serverUrl = suirpc.DevnetRpcUrl
 
-	suirpc.SetDebugMode(false)
+	// 使用默认客户端，以便测试时接入录制的磁带
+	client := suiapi.NewClient(suirpc.DefaultClient().WithServerUrl(serverUrl))
 
-	checkpointNum := mustGetCheckpointNum(context.Background(), serverUrl)
+	checkpointNum := rese.C1(client.GetLatestCheckpointSequenceNumber(context.Background()))
 	fmt.Println("Checkpoint-num:", checkpointNum)
 
-	// 构造 JSON-RPC 请求
-	request := &suirpc.RpcRequest{
-		Jsonrpc: "2.0",
-		Method:  "sui_getCheckpoint",
-		Params: []any{
-			strconv.FormatInt(checkpointNum, 10),
-		},
-		ID: 1,
-	}
-
-	rpcResponse, err := suirpc.SendRpc[map[string]interface{}](context.Background(), serverUrl, request)
-	must.Done(err)
-	fmt.Println(neatjsons.S(rpcResponse))
-}
-
-func mustGetCheckpointNum(ctx context.Context, serverUrl string) int64 {
-	// 构造 JSON-RPC 请求
-	request := &suirpc.RpcRequest{
-		Jsonrpc: "2.0",
-		Method:  "sui_getLatestCheckpointSequenceNumber",
-		Params:  []any{},
-		ID:      1,
-	}
-
-	rpcResponse, err := suirpc.SendRpc[string](ctx, serverUrl, request)
-	must.Done(err)
-	fmt.Println("Checkpoint-num:", rpcResponse.Result)
-
-	checkpointNum := rese.C1(strconv.ParseInt(rpcResponse.Result, 10, 64))
-	fmt.Println("Checkpoint-num:", checkpointNum)
-	return checkpointNum
+	checkpoint := rese.P1(client.GetCheckpoint(context.Background(), strconv.FormatUint(checkpointNum, 10)))
+	fmt.Println(neatjsons.S(checkpoint))
 }
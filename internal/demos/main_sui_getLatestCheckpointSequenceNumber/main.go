@@ -13,25 +13,17 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/go-xlan/sui-go-guide/suiapi"
 	"github.com/go-xlan/sui-go-guide/suirpc"
-	"github.com/yyle88/must"
-	"github.com/yyle88/neatjson/neatjsons"
+	"github.com/yyle88/rese"
 )
 
 func main() {
-	const serverUrl = "https://fullnode.devnet.sui.io/"
+	const serverUrl = suirpc.DevnetRpcUrl
 
-	suirpc.SetDebugMode(false)
+	// 使用默认客户端，以便测试时接入录制的磁带
+	client := suiapi.NewClient(suirpc.DefaultClient().WithServerUrl(serverUrl))
 
-	// 构造 JSON-RPC 请求
-	request := &suirpc.RpcRequest{
-		Jsonrpc: "2.0",
-		Method:  "sui_getLatestCheckpointSequenceNumber",
-		Params:  []any{},
-		ID:      1,
-	}
-
-	rpcResponse, err := suirpc.SendRpc[string](context.Background(), serverUrl, request)
-	must.Done(err)
-	fmt.Println(neatjsons.S(rpcResponse))
+	checkpointNum := rese.C1(client.GetLatestCheckpointSequenceNumber(context.Background()))
+	fmt.Println("Checkpoint-num:", checkpointNum)
 }
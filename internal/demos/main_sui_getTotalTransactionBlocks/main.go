@@ -12,24 +12,19 @@ package main
 import (
 	"context"
 
+	"github.com/go-xlan/sui-go-guide/suiapi"
 	"github.com/go-xlan/sui-go-guide/suirpc"
-	"github.com/yyle88/must"
+	"github.com/yyle88/rese"
 	"github.com/yyle88/zaplog"
 )
 
 func main() {
 	// SUI JSON-RPC API URL
-	serverUrl := "https://fullnode.mainnet.sui.io"
+	serverUrl := suirpc.MainnetRpcUrl
 
-	// 构建 JSON-RPC 请求体
-	request := &suirpc.RpcRequest{
-		Jsonrpc: "2.0",
-		Method:  "sui_getTotalTransactionBlocks",
-		Params:  []interface{}{},
-		ID:      1,
-	}
+	// 使用默认客户端，以便测试时接入录制的磁带
+	client := suiapi.NewClient(suirpc.DefaultClient().WithServerUrl(serverUrl))
 
-	rpcResponse, err := suirpc.SendRpc[string](context.Background(), serverUrl, request)
-	must.Done(err)
-	zaplog.SUG.Debugln(rpcResponse.Result)
+	total := rese.C1(client.GetTotalTransactionBlocks(context.Background()))
+	zaplog.SUG.Debugln(total)
 }
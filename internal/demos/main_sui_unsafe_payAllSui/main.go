@@ -22,14 +22,15 @@ import (
 )
 
 func main() {
-	// 测试网络
-	const serverUrl = "https://fullnode.testnet.sui.io/"
 	// 发起交易的签名者地址
 	const address = "0x353a47f8fedca2d8cd1352222300f06b1f36789a55fffdecc6fe414ee1998969"
 	// 使用的 SUI 对象 ID
 	const suiObjectID = "0xfc46685ae8893aa647c151f581e60a8549ccb240685b585cdbcf343c4bfd36c9"
 
-	secondCoin := must.Nice(fetchSecondCoin(context.Background(), serverUrl, address, suiObjectID))
+	// 测试网络，首次调用时校验链标识符
+	client := suirpc.Testnet.NewClient()
+
+	secondCoin := must.Nice(fetchSecondCoin(context.Background(), client, address, suiObjectID))
 
 	// 接收方地址
 	const recipient = "0x207ed5c0ad36b96c730ed0f71e3c26a0ffb59bc20ab21d08067ca4c035d4d062"
@@ -41,7 +42,7 @@ func main() {
 	// Gas 预算
 	gasBudget := "10000000" // Gas 预算，单位与实际交易成本有关
 
-	suirpc.SetDebugMode(true)
+	client.SetDebug(true)
 
 	// 构造 JSON-RPC 请求
 	request := &suirpc.RpcRequest{
@@ -53,15 +54,14 @@ func main() {
 			recipient,  // 接收方地址列表
 			gasBudget,  // Gas 预算
 		},
-		ID: 1,
 	}
 
-	rpcResponse, err := suirpc.SendRpc[suiapi.TxBytesMessage](context.Background(), serverUrl, request)
+	rpcResponse, err := suirpc.Call[suiapi.TxBytesMessage](context.Background(), client, request)
 	must.Done(err)
 	txBytes := rpcResponse.Result.TxBytes
 
 	{
-		res, err := suiapi.DryRunTransactionBlock[suiapi.EffectsStatusStatusMessage](context.Background(), serverUrl, txBytes)
+		res, err := suiapi.DryRunTransactionBlock[suiapi.EffectsStatusStatusMessage](context.Background(), client, txBytes)
 		must.Done(err)
 		fmt.Println(neatjsons.S(res))
 		must.Same(res.Effects.Status.Status, "success")
@@ -71,7 +71,7 @@ func main() {
 	// next step: send
 }
 
-func fetchSecondCoin(ctx context.Context, serverUrl string, address string, suiObjectID string) *suiapi.CoinType {
+func fetchSecondCoin(ctx context.Context, client *suirpc.Client, address string, suiObjectID string) *suiapi.CoinType {
 	// 构造 JSON-RPC 请求
 	request := &suirpc.RpcRequest{
 		Jsonrpc: "2.0",
@@ -80,7 +80,6 @@ func fetchSecondCoin(ctx context.Context, serverUrl string, address string, suiO
 			address,
 			"0x2::sui::SUI", //default to 0x2::sui::SUI //因此这里不设置也是可以的
 		},
-		ID: 1,
 	}
 
 	type GetCoinsResponse struct {
@@ -89,7 +88,7 @@ func fetchSecondCoin(ctx context.Context, serverUrl string, address string, suiO
 		NextCursor  string             `json:"nextCursor"`
 	}
 
-	rpcResponse := rese.P1(suirpc.SendRpc[GetCoinsResponse](ctx, serverUrl, request))
+	rpcResponse := rese.P1(suirpc.Call[GetCoinsResponse](ctx, client, request))
 	for _, coin := range rpcResponse.Result.Data {
 		if coin.CoinObjectId == suiObjectID {
 			continue
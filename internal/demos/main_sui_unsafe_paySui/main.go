@@ -23,8 +23,6 @@ import (
 )
 
 func main() {
-	// 测试网络
-	const serverUrl = "https://fullnode.testnet.sui.io/"
 	// 发起交易的签名者地址
 	const address = "0x353a47f8fedca2d8cd1352222300f06b1f36789a55fffdecc6fe414ee1998969"
 	// 私钥信息
@@ -32,7 +30,10 @@ func main() {
 	// 使用的 SUI 对象 ID
 	const suiObjectID = "0xfc46685ae8893aa647c151f581e60a8549ccb240685b585cdbcf343c4bfd36c9"
 
-	secondCoin := must.Nice(fetchSecondCoin(context.Background(), serverUrl, address, suiObjectID))
+	// 测试网络，首次调用时校验链标识符
+	client := suirpc.Testnet.NewClient()
+
+	secondCoin := must.Nice(fetchSecondCoin(context.Background(), client, address, suiObjectID))
 
 	// 接收方地址
 	var recipients = []string{
@@ -63,16 +64,15 @@ func main() {
 			amounts,    // 转账金额列表
 			gasBudget,  // Gas 预算
 		},
-		ID: 1,
 	}
 
-	rpcResponse, err := suirpc.SendRpc[suiapi.TxBytesMessage](context.Background(), serverUrl, request)
+	rpcResponse, err := suirpc.Call[suiapi.TxBytesMessage](context.Background(), client, request)
 	must.Done(err)
 	txBytes := rpcResponse.Result.TxBytes
 	fmt.Println("raw-transaction:", txBytes)
 
 	{
-		res, err := suiapi.DryRunTransactionBlock[suiapi.EffectsStatusStatusMessage](context.Background(), serverUrl, txBytes)
+		res, err := suiapi.DryRunTransactionBlock[suiapi.EffectsStatusStatusMessage](context.Background(), client, txBytes)
 		must.Done(err)
 		fmt.Println(neatjsons.S(res))
 		must.Same(res.Effects.Status.Status, "success")
@@ -82,12 +82,12 @@ func main() {
 	must.Done(err)
 	fmt.Println("signatures:", signatures)
 
-	res, err := suiapi.ExecuteTransactionBlock[suiapi.DigestMessage](context.Background(), serverUrl, txBytes, signatures)
+	res, err := suiapi.ExecuteTransactionBlock[suiapi.DigestMessage](context.Background(), client, txBytes, signatures)
 	must.Done(err)
 	fmt.Println(neatjsons.S(res))
 }
 
-func fetchSecondCoin(ctx context.Context, serverUrl string, address string, suiObjectID string) *suiapi.CoinType {
+func fetchSecondCoin(ctx context.Context, client *suirpc.Client, address string, suiObjectID string) *suiapi.CoinType {
 	// 构造 JSON-RPC 请求
 	request := &suirpc.RpcRequest{
 		Jsonrpc: "2.0",
@@ -96,7 +96,6 @@ func fetchSecondCoin(ctx context.Context, serverUrl string, address string, suiO
 			address,
 			"0x2::sui::SUI", //default to 0x2::sui::SUI //因此这里不设置也是可以的
 		},
-		ID: 1,
 	}
 
 	type GetCoinsResponse struct {
@@ -105,7 +104,7 @@ func fetchSecondCoin(ctx context.Context, serverUrl string, address string, suiO
 		NextCursor  string             `json:"nextCursor"`
 	}
 
-	rpcResponse := rese.P1(suirpc.SendRpc[GetCoinsResponse](ctx, serverUrl, request))
+	rpcResponse := rese.P1(suirpc.Call[GetCoinsResponse](ctx, client, request))
 	for _, coin := range rpcResponse.Result.Data {
 		if coin.CoinObjectId == suiObjectID {
 			continue
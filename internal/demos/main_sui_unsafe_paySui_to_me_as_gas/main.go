@@ -21,8 +21,6 @@ import (
 )
 
 func main() {
-	// 测试网络
-	const serverUrl = "https://fullnode.testnet.sui.io/"
 	// 发起交易的签名者地址
 	const address = "0x353a47f8fedca2d8cd1352222300f06b1f36789a55fffdecc6fe414ee1998969"
 	// 私钥信息
@@ -30,6 +28,9 @@ func main() {
 	// 使用的 SUI 对象 ID
 	const suiObjectID = "0xfc46685ae8893aa647c151f581e60a8549ccb240685b585cdbcf343c4bfd36c9"
 
+	// 测试网络，首次调用时校验链标识符
+	client := suirpc.Testnet.NewClient()
+
 	// 接收方地址
 	var recipients = []string{
 		address,
@@ -56,15 +57,14 @@ func main() {
 			amounts,    // 转账金额列表
 			gasBudget,  // Gas 预算
 		},
-		ID: 1,
 	}
 
-	rpcResponse, err := suirpc.SendRpc[suiapi.TxBytesMessage](context.Background(), serverUrl, request)
+	rpcResponse, err := suirpc.Call[suiapi.TxBytesMessage](context.Background(), client, request)
 	must.Done(err)
 	txBytes := rpcResponse.Result.TxBytes
 
 	{
-		res, err := suiapi.DryRunTransactionBlock[suiapi.EffectsStatusStatusMessage](context.Background(), serverUrl, txBytes)
+		res, err := suiapi.DryRunTransactionBlock[suiapi.EffectsStatusStatusMessage](context.Background(), client, txBytes)
 		must.Done(err)
 		fmt.Println(neatjsons.S(res))
 		must.Same(res.Effects.Status.Status, "success")
@@ -74,7 +74,7 @@ func main() {
 	must.Done(err)
 	fmt.Println("signatures", signatures)
 
-	res, err := suiapi.ExecuteTransactionBlock[suiapi.DigestMessage](context.Background(), serverUrl, txBytes, signatures)
+	res, err := suiapi.ExecuteTransactionBlock[suiapi.DigestMessage](context.Background(), client, txBytes, signatures)
 	must.Done(err)
 	fmt.Println(neatjsons.S(res))
 }
@@ -25,14 +25,15 @@ import (
 )
 
 func main() {
-	// 测试网络
-	const serverUrl = "https://fullnode.testnet.sui.io/"
 	// 发起交易的签名者地址
 	const address = "0x353a47f8fedca2d8cd1352222300f06b1f36789a55fffdecc6fe414ee1998969"
 	// 私钥信息
 	const privateKeyHex = "0e51bb6e96264505b7c36c71d6a7f8053ed73b20f6f4476fb4f7877b8934ae6b"
 
-	suiCoin := chooseSuiCoin(context.Background(), serverUrl, address)
+	// 测试网络，首次调用时校验链标识符
+	client := suirpc.Testnet.NewClient()
+
+	suiCoin := chooseSuiCoin(context.Background(), client, address)
 	zaplog.SUG.Debugln(neatjsons.S(suiCoin))
 
 	// 使用的 SUI 对象 ID
@@ -66,15 +67,14 @@ func main() {
 			nil,          // Gas 对象，如果没有指定，则为 nil
 			gasBudget,    // Gas 预算
 		},
-		ID: 1,
 	}
 
-	rpcResponse, err := suirpc.SendRpc[suiapi.TxBytesMessage](context.Background(), serverUrl, request)
+	rpcResponse, err := suirpc.Call[suiapi.TxBytesMessage](context.Background(), client, request)
 	must.Done(err)
 	txBytes := rpcResponse.Result.TxBytes
 
 	{
-		res, err := suiapi.DryRunTransactionBlock[suiapi.EffectsStatusStatusMessage](context.Background(), serverUrl, txBytes)
+		res, err := suiapi.DryRunTransactionBlock[suiapi.EffectsStatusStatusMessage](context.Background(), client, txBytes)
 		must.Done(err)
 		fmt.Println(neatjsons.S(res))
 		must.Same(res.Effects.Status.Status, "success")
@@ -84,13 +84,13 @@ func main() {
 	must.Done(err)
 	fmt.Println("signatures", signatures)
 
-	res, err := suiapi.ExecuteTransactionBlock[suiapi.DigestMessage](context.Background(), serverUrl, txBytes, signatures)
+	res, err := suiapi.ExecuteTransactionBlock[suiapi.DigestMessage](context.Background(), client, txBytes, signatures)
 	must.Done(err)
 	fmt.Println(neatjsons.S(res))
 }
 
-func chooseSuiCoin(ctx context.Context, serverUrl string, address string) *suiapi.CoinType {
-	suiCoins, err := suiapi.GetSuiCoinsInTopPage(ctx, serverUrl, address)
+func chooseSuiCoin(ctx context.Context, client *suirpc.Client, address string) *suiapi.CoinType {
+	suiCoins, err := suiapi.GetSuiCoinsInTopPage(ctx, client, address)
 	must.Done(err)
 
 	rand.Shuffle(len(suiCoins), func(i, j int) {
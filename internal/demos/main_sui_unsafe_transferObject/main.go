@@ -20,18 +20,20 @@ import (
 )
 
 func main() {
-	// 测试网络
-	const serverUrl = "https://fullnode.testnet.sui.io/"
 	// 发起交易的签名者地址
 	const address = "0x353a47f8fedca2d8cd1352222300f06b1f36789a55fffdecc6fe414ee1998969"
 	// 接收方地址
 	const recipient = "0x207ed5c0ad36b96c730ed0f71e3c26a0ffb59bc20ab21d08067ca4c035d4d062"
+
+	// 测试网络，首次调用时校验链标识符
+	client := suirpc.Testnet.NewClient()
+
 	// 使用的 SUI 对象 ID
 	suiObjectID := "0xfc46685ae8893aa647c151f581e60a8549ccb240685b585cdbcf343c4bfd36c9"
 	// Gas 预算
 	gasBudget := "10000000" // Gas 预算，单位与实际交易成本有关
 
-	suirpc.SetDebugMode(true)
+	client.SetDebug(true)
 
 	// 构造 JSON-RPC 请求
 	request := &suirpc.RpcRequest{
@@ -44,15 +46,14 @@ func main() {
 			gasBudget, // Gas 预算
 			recipient, // 接收方地址
 		},
-		ID: 1,
 	}
 
-	rpcResponse, err := suirpc.SendRpc[suiapi.TxBytesMessage](context.Background(), serverUrl, request)
+	rpcResponse, err := suirpc.Call[suiapi.TxBytesMessage](context.Background(), client, request)
 	must.Done(err)
 	txBytes := rpcResponse.Result.TxBytes
 
 	{
-		res, err := suiapi.DryRunTransactionBlock[suiapi.EffectsStatusStatusMessage](context.Background(), serverUrl, txBytes)
+		res, err := suiapi.DryRunTransactionBlock[suiapi.EffectsStatusStatusMessage](context.Background(), client, txBytes)
 		must.Done(err)
 		fmt.Println(neatjsons.S(res))
 		must.Same(res.Effects.Status.Status, "success")
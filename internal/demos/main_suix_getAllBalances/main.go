@@ -12,6 +12,7 @@ package main
 import (
 	"context"
 
+	"github.com/go-xlan/sui-go-guide/suiapi"
 	"github.com/go-xlan/sui-go-guide/suirpc"
 	"github.com/yyle88/rese"
 	"github.com/yyle88/zaplog"
@@ -19,27 +20,15 @@ import (
 )
 
 func main() {
-	const serverUrl = "https://fullnode.mainnet.sui.io/"
+	const serverUrl = suirpc.MainnetRpcUrl
 	// 要查询余额的地址
 	address := "0x2f76f93951df4d4b165a33f41978dfe6040db97ea2dc220602d5c163e9cd3d89"
 
-	// 构造 JSON-RPC 请求
-	request := &suirpc.RpcRequest{
-		Jsonrpc: "2.0",
-		Method:  "suix_getAllBalances",
-		Params:  []any{address},
-		ID:      1,
-	}
-
-	type BalanceItem struct {
-		CoinType        string   `json:"coinType"`
-		CoinObjectCount int      `json:"coinObjectCount"`
-		TotalBalance    string   `json:"totalBalance"`
-		LockedBalance   struct{} `json:"lockedBalance"`
-	}
+	// 使用默认客户端，以便测试时接入录制的磁带
+	client := suiapi.NewClient(suirpc.DefaultClient().WithServerUrl(serverUrl))
 
-	response := rese.P1(suirpc.SendRpc[[]*BalanceItem](context.Background(), serverUrl, request))
-	for _, coin := range response.Result {
+	balances := rese.V1(client.GetAllBalances(context.Background(), address))
+	for _, coin := range balances {
 		zaplog.LOG.Debug("coin", zap.String("balance", coin.TotalBalance), zap.String("coin_type", coin.CoinType))
 	}
 }
@@ -19,31 +19,18 @@ import (
 	"go.uber.org/zap"
 )
 
-type GetCoinsResponse struct {
-	Data        []*suiapi.CoinType `json:"data"`
-	HasNextPage bool               `json:"hasNextPage"`
-	NextCursor  string             `json:"nextCursor"`
-}
-
 func main() {
 	// 测试网络
-	const serverUrl = "https://fullnode.testnet.sui.io/"
+	const serverUrl = suirpc.TestnetRpcUrl
 	// 钱包地址
 	const address = "0x207ed5c0ad36b96c730ed0f71e3c26a0ffb59bc20ab21d08067ca4c035d4d062"
 
-	suirpc.SetDebugMode(false)
-
-	request := &suirpc.RpcRequest{
-		Jsonrpc: "2.0",
-		Method:  "suix_getCoins",
-		Params: []any{
-			address,
-		},
-		ID: 1,
-	}
+	// 使用默认客户端，以便测试时接入录制的磁带
+	client := suiapi.NewClient(suirpc.DefaultClient().WithServerUrl(serverUrl))
 
-	response := rese.P1(suirpc.SendRpc[GetCoinsResponse](context.Background(), serverUrl, request))
-	for _, coin := range response.Result.Data {
+	// 读取全部分页，代币数量超过一页时余额依然正确
+	coins := rese.V1(client.PaginateCoins(address, "", suiapi.PageOptions[string]{}).All(context.Background()))
+	for _, coin := range coins {
 		zaplog.LOG.Debug("coin", zap.String("balance", coin.Balance), zap.String("coin_type", coin.CoinType))
 	}
 }
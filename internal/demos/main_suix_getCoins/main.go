@@ -15,36 +15,23 @@ import (
 
 	"github.com/go-xlan/sui-go-guide/suiapi"
 	"github.com/go-xlan/sui-go-guide/suirpc"
+	"github.com/yyle88/must"
 	"github.com/yyle88/neatjson/neatjsons"
-	"github.com/yyle88/rese"
 )
 
 func main() {
-	const serverUrl = "https://fullnode.mainnet.sui.io/"
-
-	suirpc.SetDebugMode(false)
+	const serverUrl = suirpc.MainnetRpcUrl
 
 	// 要查询余额的地址
 	address := "0x2f76f93951df4d4b165a33f41978dfe6040db97ea2dc220602d5c163e9cd3d89"
 
-	// 构造 JSON-RPC 请求
-	request := &suirpc.RpcRequest{
-		Jsonrpc: "2.0",
-		Method:  "suix_getCoins",
-		Params: []any{
-			address,
-		},
-		ID: 1,
-	}
-
-	type GetCoinsResponse struct {
-		Data        []*suiapi.CoinType `json:"data"`
-		HasNextPage bool               `json:"hasNextPage"`
-		NextCursor  string             `json:"nextCursor"`
-	}
+	// 使用默认客户端，以便测试时接入录制的磁带
+	client := suiapi.NewClient(suirpc.DefaultClient().WithServerUrl(serverUrl))
 
-	rpcResponse := rese.P1(suirpc.SendRpc[GetCoinsResponse](context.Background(), serverUrl, request))
-	for _, coin := range rpcResponse.Result.Data {
-		fmt.Println(neatjsons.S(coin))
+	// 逐页读取全部 SUI 代币，而不是只读第一页
+	paginator := client.PaginateCoins(address, "", suiapi.PageOptions[string]{PageSize: suiapi.MaxPageSize})
+	for paginator.Next(context.Background()) {
+		fmt.Println(neatjsons.S(paginator.Item()))
 	}
+	must.Done(paginator.Err())
 }
@@ -20,32 +20,17 @@ import (
 )
 
 func main() {
-	const serverUrl = "https://fullnode.testnet.sui.io/"
-
-	suirpc.SetDebugMode(false)
+	const serverUrl = suirpc.TestnetRpcUrl
 
 	// 要查询余额的地址
 	address := "0x207ed5c0ad36b96c730ed0f71e3c26a0ffb59bc20ab21d08067ca4c035d4d062"
 
-	// 构造 JSON-RPC 请求
-	request := &suirpc.RpcRequest{
-		Jsonrpc: "2.0",
-		Method:  "suix_getCoins",
-		Params: []any{
-			address,
-			"0x2::sui::SUI", //default to 0x2::sui::SUI //因此这里不设置也是可以的
-		},
-		ID: 1,
-	}
-
-	type GetCoinsResponse struct {
-		Data        []*suiapi.CoinType `json:"data"`
-		HasNextPage bool               `json:"hasNextPage"`
-		NextCursor  string             `json:"nextCursor"`
-	}
+	// 使用默认客户端，以便测试时接入录制的磁带
+	client := suiapi.NewClient(suirpc.DefaultClient().WithServerUrl(serverUrl))
 
-	rpcResponse := rese.P1(suirpc.SendRpc[GetCoinsResponse](context.Background(), serverUrl, request))
-	for _, coin := range rpcResponse.Result.Data {
+	// 代币类型默认为 0x2::sui::SUI，因此这里不设置也是可以的
+	coins := rese.V1(client.PaginateCoins(address, "0x2::sui::SUI", suiapi.PageOptions[string]{}).All(context.Background()))
+	for _, coin := range coins {
 		fmt.Println(neatjsons.S(coin))
 	}
 }
@@ -0,0 +1,19 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/go-xlan/sui-go-guide/suirpc"
+	"github.com/stretchr/testify/require"
+)
+
+// TestReplay runs the demo against recorded cassette without network
+//
+// TestReplay 使用已录制磁带在无网络时运行演示
+func TestReplay(t *testing.T) {
+	cassette, err := suirpc.NewCassette("testdata/cassette.json", suirpc.CassetteReplay)
+	require.NoError(t, err)
+	suirpc.DefaultClient().Use(cassette.Middleware())
+
+	require.NotPanics(t, main)
+}
@@ -20,7 +20,7 @@ import (
 
 func main() {
 	// 测试网络
-	const serverUrl = "https://fullnode.testnet.sui.io/"
+	const serverUrl = suirpc.TestnetRpcUrl
 
 	suirpc.SetDebugMode(true)
 
@@ -33,7 +33,6 @@ func main() {
 			"math", // 模块名称
 			"add",  // 方法名称
 		},
-		ID: 1,
 	}
 
 	rpcResponse := rese.P1(suirpc.SendRpc[map[string]interface{}](context.Background(), serverUrl, request))
@@ -23,13 +23,14 @@ import (
 )
 
 func main() {
-	// 测试网络
-	const serverUrl = "https://fullnode.testnet.sui.io/"
 	// 发起交易的签名者地址
 	const address = "0x353a47f8fedca2d8cd1352222300f06b1f36789a55fffdecc6fe414ee1998969"
 	// 私钥信息
 	const privateKeyHex = "0e51bb6e96264505b7c36c71d6a7f8053ed73b20f6f4476fb4f7877b8934ae6b"
 
+	// 测试网络，首次调用时校验链标识符
+	client := suirpc.Testnet.NewClient()
+
 	// 构造 JSON-RPC 请求
 	request := &suirpc.RpcRequest{
 		Jsonrpc: "2.0",
@@ -44,17 +45,16 @@ func main() {
 			nil,                // Gas 对象（可选）
 			"75000000",         // Gas 预算，这里注意假如给的太少就会出问题，但给的太多也不利于使用
 		},
-		ID: 1,
 	}
 
-	rpcResponse := rese.P1(suirpc.SendRpc[suiapi.TxBytesMessage](context.Background(), serverUrl, request))
+	rpcResponse := rese.P1(suirpc.Call[suiapi.TxBytesMessage](context.Background(), client, request))
 
 	txBytes := rpcResponse.Result.TxBytes
 
 	fmt.Println(txBytes)
 
 	{
-		res, err := suiapi.DryRunTransactionBlock[suiapi.EffectsStatusStatusMessage](context.Background(), serverUrl, txBytes)
+		res, err := suiapi.DryRunTransactionBlock[suiapi.EffectsStatusStatusMessage](context.Background(), client, txBytes)
 		must.Done(err)
 		fmt.Println(neatjsons.S(res))
 		must.Same(res.Effects.Status.Status, "success")
@@ -62,7 +62,7 @@ func main() {
 
 	signatures := rese.C1(suisigntx.Sign(privateKeyHex, txBytes))
 
-	res, err := suiapi.ExecuteTransactionBlock[suiapi.DigestMessage](context.Background(), serverUrl, txBytes, signatures)
+	res, err := suiapi.ExecuteTransactionBlock[suiapi.DigestMessage](context.Background(), client, txBytes, signatures)
 	must.Done(err)
 	zaplog.SUG.Debugln(neatjsons.S(res))
 }
@@ -21,9 +21,11 @@ import (
 )
 
 func main() {
-	const serverUrl = "https://fullnode.mainnet.sui.io/"
 
-	suirpc.SetDebugMode(true)
+	// 主网，首次调用时校验链标识符
+	client := suirpc.Mainnet.NewClient()
+
+	client.SetDebug(true)
 
 	request := &suirpc.RpcRequest{
 		Jsonrpc: "2.0",
@@ -38,15 +40,14 @@ func main() {
 			nil,               // Gas 对象（可选）
 			"75000000",        // Gas 预算，这里注意假如给的太少就会出问题，但给的太多也不利于使用
 		},
-		ID: 1,
 	}
 
-	rpcResponse := rese.P1(suirpc.SendRpc[suiapi.TxBytesMessage](context.Background(), serverUrl, request))
+	rpcResponse := rese.P1(suirpc.Call[suiapi.TxBytesMessage](context.Background(), client, request))
 
 	txBytes := rpcResponse.Result.TxBytes
 
 	{
-		res, err := suiapi.DryRunTransactionBlock[suiapi.EffectsStatusStatusMessage](context.Background(), serverUrl, txBytes)
+		res, err := suiapi.DryRunTransactionBlock[suiapi.EffectsStatusStatusMessage](context.Background(), client, txBytes)
 		must.Done(err)
 		fmt.Println(neatjsons.S(res))
 		must.Same(res.Effects.Status.Status, "success")
@@ -0,0 +1,487 @@
+package openrpcgen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/yyle88/erero"
+)
+
+// Options represents settings of one generation run
+//
+// Options 表示一次生成运行的配置
+type Options struct {
+	PackageName string            // Go package of generated file // 生成文件所属的 Go 包
+	Source      string            // Spec file name noted in header // 头部注明的规范文件名
+	Prefixes    []string          // Method prefixes to generate, such as sui_ // 要生成的方法前缀，例如 sui_
+	Types       map[string]string // Schemas the target package models by hand, mapped to its Go struct types // 目标包手写建模的模式，映射到其 Go 结构体类型
+	Skip        []string          // Methods the target package wraps by hand // 目标包手写包装的方法
+}
+
+// Generate renders Go source of enums, structs and client methods from OpenRPC document
+// Methods without one of the prefixes are skipped, methods come out sorted by name
+// Generated methods call helpers call and callValue, which the target package provides
+// Mapped schemas and skipped methods are left to hand-written code of the target package
+//
+// Generate 根据 OpenRPC 文档渲染枚举、结构体和客户端方法的 Go 源码
+// 跳过不带任一前缀的方法，方法按名称排序输出
+// 生成的方法调用目标包提供的 call 和 callValue 辅助函数
+// 映射的模式和跳过的方法留给目标包的手写代码
+func Generate(document *Document, options Options) ([]byte, error) {
+	g := &generator{
+		schemas:  document.Components.Schemas,
+		types:    options.Types,
+		declared: map[string]bool{},
+		enums:    map[string]bool{},
+		mapped:   map[string]bool{},
+	}
+	for name, goType := range options.Types {
+		if g.schemas.Schemas[name] == nil {
+			return nil, erero.Errorf("mapped schema %s is not in document", name)
+		}
+		g.mapped[goType] = true
+	}
+	for _, name := range options.Skip {
+		if !slices.ContainsFunc(document.Methods, func(method *Method) bool {
+			return method.Name == name
+		}) {
+			return nil, erero.Errorf("skipped method %s is not in document", name)
+		}
+	}
+	for _, name := range document.Components.Schemas.Names {
+		if err := g.declareNamed(name); err != nil {
+			return nil, erero.Wro(err)
+		}
+	}
+
+	methods := slices.Clone(document.Methods)
+	slices.SortFunc(methods, func(a, b *Method) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	for _, method := range methods {
+		if !slices.ContainsFunc(options.Prefixes, func(prefix string) bool {
+			return strings.HasPrefix(method.Name, prefix)
+		}) || slices.Contains(options.Skip, method.Name) {
+			continue
+		}
+		if err := g.writeMethod(method); err != nil {
+			return nil, erero.WithMessagef(err, "method %s", method.Name)
+		}
+	}
+
+	var output bytes.Buffer
+	fmt.Fprintf(&output, "// Code generated by openrpcgen from %s (%s %s). DO NOT EDIT.\n\n", options.Source, document.Info.Title, document.Info.Version)
+	fmt.Fprintf(&output, "package %s\n\n", options.PackageName)
+	output.WriteString("import (\n\t\"context\"\n")
+	if g.usesJSON {
+		output.WriteString("\t\"encoding/json\"\n")
+	}
+	output.WriteString(")\n")
+	output.Write(g.decls.Bytes())
+	output.Write(g.methods.Bytes())
+
+	source, err := format.Source(output.Bytes())
+	if err != nil {
+		return nil, erero.WithMessagef(err, "format generated source")
+	}
+	return source, nil
+}
+
+// generator represents state of one generation run
+//
+// generator 表示一次生成运行的状态
+type generator struct {
+	schemas  Properties        // Named schemas of the document // 文档中的命名模式
+	types    map[string]string // Hand-written Go types by schema name // 按模式名索引的手写 Go 类型
+	declared map[string]bool   // Go type names already declared // 已声明的 Go 类型名
+	enums    map[string]bool   // Declared enum type names // 已声明的枚举类型名
+	mapped   map[string]bool   // Hand-written Go struct types // 手写的 Go 结构体类型
+	decls    bytes.Buffer      // Type declarations // 类型声明
+	methods  bytes.Buffer      // Param structs and client methods // 参数结构体和客户端方法
+	usesJSON bool              // Output refers to encoding/json // 输出引用了 encoding/json
+}
+
+// declareNamed declares Go type of named schema when it needs one
+// Primitive, union and mapped schemas resolve inline, so they get no declaration
+//
+// declareNamed 在需要时为命名模式声明 Go 类型
+// 原始类型、联合类型和映射的模式内联解析，因此不声明
+func (g *generator) declareNamed(name string) error {
+	schema := g.schemas.Schemas[name]
+	if schema == nil {
+		return erero.Errorf("unknown schema %s", name)
+	}
+	if _, ok := g.types[name]; ok || !declarable(schema) {
+		return nil
+	}
+	_, err := g.declare(schema, goName(name))
+	return err
+}
+
+// declarable checks if schema becomes named Go type: string enum or object with properties
+//
+// declarable 检查模式是否成为命名 Go 类型：字符串枚举或带属性的对象
+func declarable(schema *Schema) bool {
+	return (len(schema.Enum) > 0 && baseType(schema) == "string") || len(schema.Properties.Names) > 0
+}
+
+// declare writes enum or struct declaration of schema once and returns its type name
+//
+// declare 为模式写入一次枚举或结构体声明并返回其类型名
+func (g *generator) declare(schema *Schema, typeName string) (string, error) {
+	if g.declared[typeName] {
+		return typeName, nil
+	}
+	g.declared[typeName] = true
+	if len(schema.Properties.Names) == 0 {
+		g.writeEnum(schema, typeName)
+		return typeName, nil
+	}
+
+	// Render fields first, nested declarations land ahead of this struct
+	// 先渲染字段，嵌套声明位于此结构体之前
+	var fields bytes.Buffer
+	for _, name := range schema.Properties.Names {
+		property := schema.Properties.Schemas[name]
+		fieldType, err := g.goType(property, typeName+goName(name))
+		if err != nil {
+			return "", erero.WithMessagef(err, "%s.%s", typeName, name)
+		}
+		tag := name
+		if quoted, ok := g.quotedInteger(property); ok {
+			fieldType = quoted
+			tag += ",string"
+		}
+		if !slices.Contains(schema.Required, name) {
+			fieldType = g.optionalField(fieldType)
+			tag += ",omitempty"
+		}
+		fmt.Fprintf(&fields, "\t%s %s `json:%q`%s\n", goName(name), fieldType, tag, lineComment(property.Description))
+	}
+	g.writeDoc(&g.decls, typeName, schema.Description)
+	fmt.Fprintf(&g.decls, "type %s struct {\n%s}\n", typeName, fields.String())
+	return typeName, nil
+}
+
+// writeEnum writes string type with one const per allowed value
+//
+// writeEnum 写入字符串类型，并为每个允许值写入一个常量
+func (g *generator) writeEnum(schema *Schema, typeName string) {
+	g.enums[typeName] = true
+	g.writeDoc(&g.decls, typeName, schema.Description)
+	fmt.Fprintf(&g.decls, "type %s string\n\nconst (\n", typeName)
+	for _, value := range schema.Enum {
+		fmt.Fprintf(&g.decls, "\t%s%s %s = %q\n", typeName, goName(value), typeName, value)
+	}
+	g.decls.WriteString(")\n")
+}
+
+// goType resolves Go type of schema, declaring inline enums and objects under hint name
+//
+// goType 解析模式的 Go 类型，以 hint 名称声明内联枚举和对象
+func (g *generator) goType(schema *Schema, hint string) (string, error) {
+	if schema.Ref != "" {
+		name := strings.TrimPrefix(schema.Ref, "#/components/schemas/")
+		if goType, ok := g.types[name]; ok {
+			return goType, nil
+		}
+		target := g.schemas.Schemas[name]
+		if target == nil {
+			return "", erero.Errorf("unknown schema %s", schema.Ref)
+		}
+		if declarable(target) {
+			return g.declare(target, goName(name))
+		}
+		return g.goType(target, goName(name))
+	}
+	if len(schema.AllOf) == 1 {
+		return g.goType(schema.AllOf[0], hint)
+	}
+	if variants := append(slices.Clone(schema.OneOf), schema.AnyOf...); len(variants) > 0 {
+		others := slices.DeleteFunc(variants, func(variant *Schema) bool {
+			return slices.Equal(variant.Type, TypeList{"null"})
+		})
+		if len(others) == 1 && len(others) < len(schema.OneOf)+len(schema.AnyOf) {
+			goType, err := g.goType(others[0], hint)
+			if err != nil {
+				return "", erero.Wro(err)
+			}
+			return nullable(goType), nil
+		}
+		return g.rawMessage(), nil
+	}
+
+	goType, err := g.baseGoType(schema, hint)
+	if err != nil {
+		return "", erero.Wro(err)
+	}
+	if slices.Contains(schema.Type, "null") {
+		return nullable(goType), nil
+	}
+	return goType, nil
+}
+
+// baseGoType resolves Go type of schema by its non-null JSON type
+//
+// baseGoType 根据模式的非 null JSON 类型解析 Go 类型
+func (g *generator) baseGoType(schema *Schema, hint string) (string, error) {
+	switch baseType(schema) {
+	case "string":
+		if len(schema.Enum) > 0 {
+			return g.declare(schema, hint)
+		}
+		return "string", nil
+	case "integer":
+		if slices.Contains(integerFormats, schema.Format) {
+			return schema.Format, nil
+		}
+		return "int64", nil
+	case "number":
+		return "float64", nil
+	case "boolean":
+		return "bool", nil
+	case "array":
+		if schema.Items == nil {
+			return "[]" + g.rawMessage(), nil
+		}
+		item, err := g.goType(schema.Items, hint+"Item")
+		if err != nil {
+			return "", erero.Wro(err)
+		}
+		return "[]" + g.elemType(item), nil
+	case "object":
+		if len(schema.Properties.Names) > 0 {
+			return g.declare(schema, hint)
+		}
+		if schema.AdditionalProperties != nil {
+			value, err := g.goType(schema.AdditionalProperties, hint+"Value")
+			if err != nil {
+				return "", erero.Wro(err)
+			}
+			return "map[string]" + g.elemType(value), nil
+		}
+		return g.rawMessage(), nil
+	default:
+		return g.rawMessage(), nil
+	}
+}
+
+// integerFormats lists integer formats that name Go integer types
+//
+// integerFormats 列出与 Go 整数类型同名的整数格式
+var integerFormats = []string{"uint", "uint8", "uint16", "uint32", "uint64", "int8", "int16", "int32", "int64"}
+
+// quotedInteger resolves Go integer type of integer encoded as decimal string
+// Sui names such schemas BigInt_for_<width>, fields of them decode with the ,string tag
+// Param and element types stay string since the tag does not reach them
+//
+// quotedInteger 解析以十进制字符串编码的整数的 Go 整数类型
+// Sui 将此类模式命名为 BigInt_for_<宽度>，这类字段使用 ,string 标签解码
+// 参数和元素类型保持 string，因为标签作用不到它们
+func (g *generator) quotedInteger(schema *Schema) (string, bool) {
+	if schema.Ref != "" {
+		name := strings.TrimPrefix(schema.Ref, "#/components/schemas/")
+		if _, ok := g.types[name]; ok {
+			return "", false
+		}
+		target := g.schemas.Schemas[name]
+		width, ok := strings.CutPrefix(name, "BigInt_for_")
+		if target == nil || !ok || baseType(target) != "string" || !slices.Contains(integerFormats, width) {
+			return "", false
+		}
+		if slices.Contains(target.Type, "null") {
+			return nullable(width), true
+		}
+		return width, true
+	}
+	if len(schema.AllOf) == 1 {
+		return g.quotedInteger(schema.AllOf[0])
+	}
+	variants := append(slices.Clone(schema.OneOf), schema.AnyOf...)
+	others := slices.DeleteFunc(slices.Clone(variants), func(variant *Schema) bool {
+		return slices.Equal(variant.Type, TypeList{"null"})
+	})
+	if len(others) == 1 && len(others) < len(variants) {
+		if width, ok := g.quotedInteger(others[0]); ok {
+			return nullable(width), true
+		}
+	}
+	return "", false
+}
+
+// rawMessage returns json.RawMessage and notes the import
+//
+// rawMessage 返回 json.RawMessage 并记录导入
+func (g *generator) rawMessage() string {
+	g.usesJSON = true
+	return "json.RawMessage"
+}
+
+// writeMethod writes param struct and client method of RPC method
+//
+// writeMethod 写入 RPC 方法的参数结构体和客户端方法
+func (g *generator) writeMethod(method *Method) error {
+	name := methodName(method.Name)
+	resultType, err := g.goType(method.Result.Schema, name+"Result")
+	if err != nil {
+		return erero.Wro(err)
+	}
+
+	var args []string
+	signature := "ctx context.Context"
+	if len(method.Params) > 0 {
+		paramsName := name + "Params"
+		var fields bytes.Buffer
+		for _, param := range method.Params {
+			paramType, err := g.goType(param.Schema, paramsName+goName(param.Name))
+			if err != nil {
+				return erero.WithMessagef(err, "param %s", param.Name)
+			}
+			if !param.Required {
+				paramType = nullable(paramType)
+			}
+			fmt.Fprintf(&fields, "\t%s %s%s\n", goName(param.Name), paramType, lineComment(param.Description))
+			args = append(args, "params."+goName(param.Name))
+		}
+		g.writeDoc(&g.methods, paramsName, "positional params of "+method.Name)
+		fmt.Fprintf(&g.methods, "type %s struct {\n%s}\n\n", paramsName, fields.String())
+		signature += ", params " + paramsName
+	}
+
+	callArgs := strings.Join(append([]string{"ctx", "c", fmt.Sprintf("%q", method.Name)}, args...), ", ")
+	fmt.Fprintf(&g.methods, "// %s calls %s\n", name, method.Name)
+	if method.Description != "" {
+		fmt.Fprintf(&g.methods, "// %s\n", method.Description)
+	}
+	fmt.Fprintf(&g.methods, "//\n// %s 调用 %s\n", name, method.Name)
+	if g.isStruct(resultType) {
+		fmt.Fprintf(&g.methods, "func (c *Client) %s(%s) (*%s, error) {\n\treturn call[%s](%s)\n}\n\n", name, signature, resultType, resultType, callArgs)
+	} else {
+		fmt.Fprintf(&g.methods, "func (c *Client) %s(%s) (%s, error) {\n\treturn callValue[%s](%s)\n}\n\n", name, signature, resultType, resultType, callArgs)
+	}
+	return nil
+}
+
+// writeDoc writes doc comment of declared type
+//
+// writeDoc 写入已声明类型的文档注释
+func (g *generator) writeDoc(buffer *bytes.Buffer, typeName string, description string) {
+	buffer.WriteString("\n")
+	if description != "" {
+		fmt.Fprintf(buffer, "// %s represents %s\n", typeName, lowerFirst(description))
+	} else {
+		fmt.Fprintf(buffer, "// %s is generated from the OpenRPC document\n", typeName)
+	}
+}
+
+// isStruct checks if Go type is generated or mapped struct
+//
+// isStruct 检查 Go 类型是否为生成的或映射的结构体
+func (g *generator) isStruct(goType string) bool {
+	return g.mapped[goType] || (g.declared[goType] && !g.enums[goType])
+}
+
+// baseType returns first non-null JSON type, object when schema only has properties
+//
+// baseType 返回首个非 null 的 JSON 类型，模式只有属性时返回 object
+func baseType(schema *Schema) string {
+	for _, name := range schema.Type {
+		if name != "null" {
+			return name
+		}
+	}
+	if len(schema.Properties.Names) > 0 {
+		return "object"
+	}
+	return ""
+}
+
+// nullable returns Go type able to hold null, pointer unless already nil-able
+//
+// nullable 返回可容纳 null 的 Go 类型，除非已可为 nil，否则返回指针
+func nullable(goType string) string {
+	if nilable(goType) {
+		return goType
+	}
+	return "*" + goType
+}
+
+// optionalField returns Go type of optional struct field
+// Structs become pointers, primitives and enums stay values left out when empty
+//
+// optionalField 返回可选结构体字段的 Go 类型
+// 结构体变为指针，原始类型和枚举保持值类型并在为空时省略
+func (g *generator) optionalField(goType string) string {
+	if g.isStruct(goType) {
+		return "*" + goType
+	}
+	return goType
+}
+
+// elemType returns element type of slices and maps, pointers to generated structs
+//
+// elemType 返回切片和映射的元素类型，生成的结构体使用指针
+func (g *generator) elemType(goType string) string {
+	if g.isStruct(goType) {
+		return "*" + goType
+	}
+	return goType
+}
+
+// nilable checks if Go type already holds nil
+//
+// nilable 检查 Go 类型是否已可为 nil
+func nilable(goType string) bool {
+	return strings.HasPrefix(goType, "*") || strings.HasPrefix(goType, "[]") || strings.HasPrefix(goType, "map[") || goType == "json.RawMessage"
+}
+
+// methodName returns Go method name of RPC method, unsafe_ methods keep Unsafe prefix
+//
+// methodName 返回 RPC 方法的 Go 方法名，unsafe_ 方法保留 Unsafe 前缀
+func methodName(name string) string {
+	if rest, ok := strings.CutPrefix(name, "unsafe_"); ok {
+		return "Unsafe" + goName(rest)
+	}
+	_, rest, _ := strings.Cut(name, "_")
+	return goName(rest)
+}
+
+// goName returns exported Go name of JSON name, joining snake case parts in camel case
+//
+// goName 返回 JSON 名称对应的导出 Go 名称，将蛇形命名的各部分拼接为驼峰命名
+func goName(name string) string {
+	var builder strings.Builder
+	for _, part := range strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		runes := []rune(part)
+		runes[0] = unicode.ToUpper(runes[0])
+		builder.WriteString(string(runes))
+	}
+	return builder.String()
+}
+
+// lowerFirst lowers first letter of sentence
+//
+// lowerFirst 将句子首字母小写
+func lowerFirst(text string) string {
+	runes := []rune(text)
+	if len(runes) > 1 && unicode.IsUpper(runes[0]) && !unicode.IsUpper(runes[1]) {
+		runes[0] = unicode.ToLower(runes[0])
+	}
+	return string(runes)
+}
+
+// lineComment returns trailing comment of struct field
+//
+// lineComment 返回结构体字段的行尾注释
+func lineComment(description string) string {
+	if description == "" {
+		return ""
+	}
+	return " // " + description
+}
@@ -0,0 +1,97 @@
+package openrpcgen_test
+
+import (
+	"testing"
+
+	"github.com/go-xlan/sui-go-guide/internal/openrpcgen"
+	"github.com/stretchr/testify/require"
+)
+
+// parseDemo parses small OpenRPC document covering enums, refs, unions, quoted integers and optional params
+//
+// parseDemo 解析覆盖枚举、引用、联合类型、字符串编码整数和可选参数的小型 OpenRPC 文档
+func parseDemo(t *testing.T) *openrpcgen.Document {
+	document, err := openrpcgen.ParseDocument([]byte(`{
+		"openrpc": "1.2.6",
+		"info": {"title": "Demo", "version": "1.0"},
+		"methods": [
+			{"name": "rpc.discover", "params": [], "result": {"name": "Doc", "schema": {}}},
+			{"name": "sui_getThing", "description": "Returns thing", "params": [
+				{"name": "thing_id", "required": true, "schema": {"$ref": "#/components/schemas/ObjectID"}},
+				{"name": "mode", "schema": {"$ref": "#/components/schemas/Mode"}},
+				{"name": "height", "schema": {"$ref": "#/components/schemas/BigInt_for_uint64"}}
+			], "result": {"name": "Thing", "schema": {"$ref": "#/components/schemas/Thing"}}},
+			{"name": "sui_getName", "params": [], "result": {"name": "Name", "schema": {"type": ["string", "null"]}}}
+		],
+		"components": {"schemas": {
+			"ObjectID": {"type": "string"},
+			"BigInt_for_uint64": {"type": "string"},
+			"Mode": {"description": "Lookup mode", "type": "string", "enum": ["Fast", "full_scan"]},
+			"Thing": {"description": "Thing found", "type": "object", "required": ["zeta", "alpha"], "properties": {
+				"zeta": {"type": "integer", "format": "uint64"},
+				"alpha": {"type": "array", "items": {"$ref": "#/components/schemas/Part"}},
+				"parent": {"anyOf": [{"$ref": "#/components/schemas/Part"}, {"type": "null"}]},
+				"kind": {"oneOf": [{"type": "string"}, {"type": "object"}]},
+				"extra": {"type": "object", "additionalProperties": true},
+				"height": {"$ref": "#/components/schemas/BigInt_for_uint64"},
+				"epoch": {"anyOf": [{"$ref": "#/components/schemas/BigInt_for_uint64"}, {"type": "null"}]},
+				"versions": {"type": "array", "items": {"$ref": "#/components/schemas/BigInt_for_uint64"}}
+			}},
+			"Part": {"type": "object", "required": ["name"], "properties": {"name": {"type": "string"}}}
+		}}
+	}`))
+	require.NoError(t, err)
+	return document
+}
+
+// TestGenerate tests enums, nullable refs, quoted integers, optional params and property order in generated code
+//
+// TestGenerate 测试生成代码中的枚举、可空引用、字符串编码整数、可选参数和属性顺序
+func TestGenerate(t *testing.T) {
+	document := parseDemo(t)
+
+	source, err := openrpcgen.Generate(document, openrpcgen.Options{PackageName: "demo", Source: "demo.json", Prefixes: []string{"sui_"}})
+	require.NoError(t, err)
+	code := string(source)
+
+	require.Contains(t, code, "// Code generated by openrpcgen from demo.json (Demo 1.0). DO NOT EDIT.")
+	require.Contains(t, code, "ModeFullScan Mode = \"full_scan\"")
+	require.Regexp(t, `(?s)type Thing struct \{\s+Zeta\s+uint64 .*Alpha\s+\[\]\*Part .*Parent\s+\*Part\s+`+"`json:\"parent,omitempty\"`"+`.*Kind\s+json.RawMessage.*Extra\s+map\[string\]json.RawMessage`, code)
+	require.Regexp(t, `Height\s+uint64\s+`+"`json:\"height,string,omitempty\"`", code)
+	require.Regexp(t, `Epoch\s+\*uint64\s+`+"`json:\"epoch,string,omitempty\"`", code)
+	require.Regexp(t, `Versions\s+\[\]string\s+`+"`json:\"versions,omitempty\"`", code)
+	require.Contains(t, code, "Height  *string")
+	require.Contains(t, code, "ThingId string")
+	require.Contains(t, code, "Mode    *Mode")
+	require.Contains(t, code, "func (c *Client) GetThing(ctx context.Context, params GetThingParams) (*Thing, error) {")
+	require.Contains(t, code, `return call[Thing](ctx, c, "sui_getThing", params.ThingId, params.Mode, params.Height)`)
+	require.Contains(t, code, "func (c *Client) GetName(ctx context.Context) (*string, error) {")
+	require.NotContains(t, code, "Discover")
+}
+
+// TestGenerate_Config tests mapped schemas resolve to hand-written types and skipped methods stay out
+//
+// TestGenerate_Config 测试映射的模式解析为手写类型且跳过的方法不被生成
+func TestGenerate_Config(t *testing.T) {
+	document := parseDemo(t)
+
+	source, err := openrpcgen.Generate(document, openrpcgen.Options{
+		PackageName: "demo",
+		Source:      "demo.json",
+		Prefixes:    []string{"sui_"},
+		Types:       map[string]string{"Part": "Piece", "Thing": "Page[Piece, string]"},
+		Skip:        []string{"sui_getName"},
+	})
+	require.NoError(t, err)
+	code := string(source)
+
+	require.NotContains(t, code, "type Part struct")
+	require.NotContains(t, code, "type Thing struct")
+	require.Contains(t, code, "func (c *Client) GetThing(ctx context.Context, params GetThingParams) (*Page[Piece, string], error) {")
+	require.NotContains(t, code, "GetName")
+
+	_, err = openrpcgen.Generate(document, openrpcgen.Options{PackageName: "demo", Types: map[string]string{"Missing": "Piece"}})
+	require.Error(t, err)
+	_, err = openrpcgen.Generate(document, openrpcgen.Options{PackageName: "demo", Skip: []string{"sui_missing"}})
+	require.Error(t, err)
+}
@@ -0,0 +1,182 @@
+// Package openrpcgen: Go code generator reading OpenRPC documents published by Sui fullnodes
+// Emits enum types, result structs, param structs and typed client methods
+// Keeps property order of the document so generated structs follow the spec
+//
+// openrpcgen: 读取 Sui 全节点发布的 OpenRPC 文档的 Go 代码生成器
+// 生成枚举类型、结果结构体、参数结构体和类型化的客户端方法
+// 保持文档中的属性顺序，使生成的结构体与规范一致
+package openrpcgen
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/yyle88/erero"
+)
+
+// Document represents OpenRPC document, limited to parts the generator reads
+//
+// Document 表示 OpenRPC 文档，仅包含生成器读取的部分
+type Document struct {
+	Openrpc    string     `json:"openrpc"`    // OpenRPC version // OpenRPC 版本
+	Info       Info       `json:"info"`       // API title and version // API 标题和版本
+	Methods    []*Method  `json:"methods"`    // RPC methods // RPC 方法
+	Components Components `json:"components"` // Shared schemas // 共享的模式
+}
+
+// Info represents API metadata of the document
+//
+// Info 表示文档的 API 元数据
+type Info struct {
+	Title   string `json:"title"`   // API title // API 标题
+	Version string `json:"version"` // API version // API 版本
+}
+
+// Components represents reusable parts of the document
+//
+// Components 表示文档中可复用的部分
+type Components struct {
+	Schemas Properties `json:"schemas"` // Named schemas in document order // 按文档顺序排列的命名模式
+}
+
+// Method represents one RPC method
+//
+// Method 表示单个 RPC 方法
+type Method struct {
+	Name        string        `json:"name"`        // Method name such as sui_getObject // 方法名，例如 sui_getObject
+	Description string        `json:"description"` // Method description // 方法描述
+	Params      []*Descriptor `json:"params"`      // Positional params // 按位置排列的参数
+	Result      *Descriptor   `json:"result"`      // Method result // 方法结果
+}
+
+// Descriptor represents OpenRPC content descriptor of param or result
+//
+// Descriptor 表示参数或结果的 OpenRPC 内容描述符
+type Descriptor struct {
+	Name        string  `json:"name"`        // Param or result name // 参数或结果名称
+	Description string  `json:"description"` // Param description // 参数描述
+	Required    bool    `json:"required"`    // Param must be set // 参数必须设置
+	Schema      *Schema `json:"schema"`      // Value schema // 值模式
+}
+
+// Schema represents JSON schema subset used by Sui OpenRPC documents
+//
+// Schema 表示 Sui OpenRPC 文档使用的 JSON schema 子集
+type Schema struct {
+	Ref                  string     `json:"$ref"`                 // Reference to named schema // 对命名模式的引用
+	Description          string     `json:"description"`          // Schema description // 模式描述
+	Type                 TypeList   `json:"type"`                 // JSON types, "null" marks nullable // JSON 类型，"null" 表示可为空
+	Format               string     `json:"format"`               // Integer width such as uint64 // 整数宽度，例如 uint64
+	Enum                 []string   `json:"enum"`                 // Allowed string values // 允许的字符串值
+	Properties           Properties `json:"properties"`           // Object properties in order // 按顺序排列的对象属性
+	Required             []string   `json:"required"`             // Required property names // 必需属性名
+	Items                *Schema    `json:"items"`                // Array item schema // 数组元素模式
+	AdditionalProperties *Schema    `json:"additionalProperties"` // Map value schema // 映射值模式
+	OneOf                []*Schema  `json:"oneOf"`                // Exclusive variants // 互斥变体
+	AnyOf                []*Schema  `json:"anyOf"`                // Variants // 变体
+	AllOf                []*Schema  `json:"allOf"`                // Combined schemas // 组合模式
+}
+
+// UnmarshalJSON decodes schema, boolean schema true or false counts as schema of any value
+//
+// UnmarshalJSON 解码模式，布尔模式 true 或 false 视为任意值的模式
+func (schema *Schema) UnmarshalJSON(data []byte) error {
+	switch string(bytes.TrimSpace(data)) {
+	case "true", "false":
+		*schema = Schema{}
+		return nil
+	}
+	type plain Schema
+	if err := json.Unmarshal(data, (*plain)(schema)); err != nil {
+		return erero.Wro(err)
+	}
+	return nil
+}
+
+// TypeList represents schema type given as one string or a list
+//
+// TypeList 表示以单个字符串或列表给出的模式类型
+type TypeList []string
+
+// UnmarshalJSON decodes type from string or string list
+//
+// UnmarshalJSON 从字符串或字符串列表解码类型
+func (types *TypeList) UnmarshalJSON(data []byte) error {
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("[")) {
+		var list []string
+		if err := json.Unmarshal(data, &list); err != nil {
+			return erero.Wro(err)
+		}
+		*types = list
+		return nil
+	}
+	var single string
+	if err := json.Unmarshal(data, &single); err != nil {
+		return erero.Wro(err)
+	}
+	*types = TypeList{single}
+	return nil
+}
+
+// Properties represents named schemas keeping document order
+//
+// Properties 表示保持文档顺序的命名模式
+type Properties struct {
+	Names   []string           // Names in document order // 按文档顺序排列的名称
+	Schemas map[string]*Schema // Schemas by name // 按名称索引的模式
+}
+
+// UnmarshalJSON decodes JSON object token by token to keep key order
+//
+// UnmarshalJSON 逐个词法单元解码 JSON 对象以保持键的顺序
+func (properties *Properties) UnmarshalJSON(data []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if token, err := decoder.Token(); err != nil || token != json.Delim('{') {
+		return erero.Errorf("properties must be object, got %v err=%v", token, err)
+	}
+	properties.Schemas = map[string]*Schema{}
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return erero.Wro(err)
+		}
+		name := token.(string)
+		var schema Schema
+		if err := decoder.Decode(&schema); err != nil {
+			return erero.WithMessagef(err, "schema %s", name)
+		}
+		properties.Names = append(properties.Names, name)
+		properties.Schemas[name] = &schema
+	}
+	return nil
+}
+
+// ParseDocument decodes OpenRPC document from JSON
+//
+// ParseDocument 从 JSON 解码 OpenRPC 文档
+func ParseDocument(data []byte) (*Document, error) {
+	var document Document
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil, erero.Wro(err)
+	}
+	return &document, nil
+}
+
+// Config represents hand-written parts of target package, which generation leaves alone
+//
+// Config 表示目标包中手写的部分，生成时不予处理
+type Config struct {
+	Types map[string]string `json:"types"` // Go struct types by schema name // 按模式名索引的 Go 结构体类型
+	Skip  []string          `json:"skip"`  // Names of hand-wrapped methods // 手写包装的方法名
+}
+
+// ParseConfig decodes generation config from JSON
+//
+// ParseConfig 从 JSON 解码生成配置
+func ParseConfig(data []byte) (*Config, error) {
+	var config Config
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, erero.Wro(err)
+	}
+	return &config, nil
+}
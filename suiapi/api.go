@@ -11,125 +11,153 @@ package suiapi
 
 import (
 	"context"
+	"encoding/json"
 
 	"github.com/go-xlan/sui-go-guide/suirpc"
 	"github.com/yyle88/erero"
-	"github.com/yyle88/must"
 )
 
 // DryRunTransactionBlock simulates transaction execution without committing to blockchain
-// Accepts context, server URL, and Base64-encoded transaction bytes
+// Accepts context, RPC client, and Base64-encoded transaction bytes
 // Returns typed response with effects or error if simulation fails
+// Failure status comes back as error matching suirpc.ErrExecutionFailed
 // Useful to validate transactions before actual execution
 //
 // DryRunTransactionBlock 模拟交易执行而不提交到区块链
-// 接受上下文、服务器 URL 和 Base64 编码的交易字节
+// 接受上下文、RPC 客户端 和 Base64 编码的交易字节
 // 返回带有效果的类型化响应，如果模拟失败则返回错误
+// 失败状态以匹配 suirpc.ErrExecutionFailed 的错误返回
 // 在实际执行前验证交易非常有用
-func DryRunTransactionBlock[RES any](ctx context.Context, serverUrl string, txBytes string) (*RES, error) {
+func DryRunTransactionBlock[RES any](ctx context.Context, client *suirpc.Client, txBytes string) (*RES, error) {
 	request := &suirpc.RpcRequest{
 		Jsonrpc: "2.0",
 		Method:  "sui_dryRunTransactionBlock",
 		Params: []any{
 			txBytes,
 		},
-		ID: 1,
 	}
-	response, err := suirpc.SendRpc[RES](ctx, serverUrl, request)
-	if err != nil {
-		return nil, erero.Wro(err)
-	}
-	return &response.Result, nil
+	return callExecution[RES](ctx, client, request)
+}
+
+// ExecuteOptions represents signatures, response options and request type of execution
+// Sponsored transactions carry sender and sponsor signatures, multisig carries one combined signature
+//
+// ExecuteOptions 表示执行时的签名、响应选项和请求类型
+// 赞助交易携带发送方和赞助方的签名，多签携带一个组合签名
+type ExecuteOptions struct {
+	Signatures  []string                         // Signatures of each required signer in Base64 // 每个必需签名者的 Base64 签名
+	Options     *TransactionBlockResponseOptions // Response sections, nil returns digest only // 响应部分，nil 时仅返回摘要
+	RequestType ExecuteTransactionRequestType    // Wait mode, empty lets node pick // 等待模式，为空时由节点决定
 }
 
 // ExecuteTransactionBlock executes signed transaction on blockchain
-// Accepts context, server URL, transaction bytes, and signature string
+// Accepts context, RPC client, transaction bytes, and signature string
 // Returns typed response with execution results or error if execution fails
-// Uses WaitForLocalExecution mode to ensure transaction confirmation
+// Failure status comes back as error matching suirpc.ErrExecutionFailed
+// Uses WaitForLocalExecution mode and each response section, see ExecuteTransactionBlockWithOptions to pick them
+// Use SuiTransactionBlockResponse as RES to decode each returned section
 //
 // ExecuteTransactionBlock 在区块链上执行已签名的交易
-// 接受上下文、服务器 URL、交易字节和签名字符串
+// 接受上下文、RPC 客户端、交易字节和签名字符串
 // 返回带有执行结果的类型化响应，如果执行失败则返回错误
-// 使用 WaitForLocalExecution 模式确保交易确认
-func ExecuteTransactionBlock[RES any](ctx context.Context, serverUrl string, txBytes string, signatures string) (*RES, error) {
-	type TransactionBlockResponseOptions struct {
-		ShowInput          bool `json:"showInput"`          // Include transaction input data // 包含交易输入数据
-		ShowRawInput       bool `json:"showRawInput"`       // Include raw input bytes // 包含原始输入字节
-		ShowEffects        bool `json:"showEffects"`        // Include transaction effects // 包含交易效果
-		ShowEvents         bool `json:"showEvents"`         // Include emitted events // 包含发出的事件
-		ShowObjectChanges  bool `json:"showObjectChanges"`  // Include object changes // 包含对象更改
-		ShowBalanceChanges bool `json:"showBalanceChanges"` // Include balance changes // 包含余额更改
-		ShowRawEffects     bool `json:"showRawEffects"`     // Include raw effects data // 包含原始效果数据
-	}
+// 失败状态以匹配 suirpc.ErrExecutionFailed 的错误返回
+// 使用 WaitForLocalExecution 模式和每个响应部分，如需选择请参阅 ExecuteTransactionBlockWithOptions
+// 将 SuiTransactionBlockResponse 用作 RES 可解码返回的每个部分
+func ExecuteTransactionBlock[RES any](ctx context.Context, client *suirpc.Client, txBytes string, signatures string) (*RES, error) {
+	return ExecuteTransactionBlockWithOptions[RES](ctx, client, txBytes, ExecuteOptions{
+		Signatures:  []string{signatures},
+		Options:     FullResponseOptions(),
+		RequestType: ExecuteTransactionRequestTypeWaitForLocalExecution,
+	})
+}
 
-	// Build JSON RPC request with comprehensive response options
-	// Explicitly set WaitForLocalExecution mode to ensure transaction confirmation
-	// Without explicit request_type, default would be WaitForEffectsCert
-	// However, setting show_events or show_effects to true auto-switches to WaitForLocalExecution
-	// This explicit configuration avoids server-side or developer inference
-	// Request fails if configuration conflicts with constraints
-	// Therefore, recommend to set options instead of using defaults
+// ExecuteTransactionBlockWithOptions executes transaction with given signatures, response options and request type
+// Failure status comes back as error matching suirpc.ErrExecutionFailed
+//
+// ExecuteTransactionBlockWithOptions 使用给定的签名、响应选项和请求类型执行交易
+// 失败状态以匹配 suirpc.ErrExecutionFailed 的错误返回
+func ExecuteTransactionBlockWithOptions[RES any](ctx context.Context, client *suirpc.Client, txBytes string, options ExecuteOptions) (*RES, error) {
+	if len(options.Signatures) == 0 {
+		return nil, erero.New("execute needs at least one signature")
+	}
+	// Without explicit request type, node picks WaitForLocalExecution when object or balance changes are asked for
+	// Otherwise it picks WaitForEffectsCert, and asking those changes along with WaitForEffectsCert fails
+	// Check the conflict here to fail before sending
 	//
-	// 构建包含完整响应选项的 JSON RPC 请求
-	// 显式设置 WaitForLocalExecution 模式以确保交易确认
-	// 如果不显式设置 request_type，默认是 WaitForEffectsCert
-	// 但如果将 show_events 或 show_effects 设置为 true，会自动切换到 WaitForLocalExecution
-	// 这个显式配置避免了服务端或开发者的推断
-	// 如果配置与约束冲突，请求会失败
-	// 因此建议设置选项而不是使用默认值
+	// 如果不显式设置请求类型，请求对象变更或余额变更时节点选择 WaitForLocalExecution
+	// 否则选择 WaitForEffectsCert，而在 WaitForEffectsCert 下请求这些变更会失败
+	// 在此检查冲突以便在发送前失败
+	if options.RequestType == ExecuteTransactionRequestTypeWaitForEffectsCert && options.Options.RequiresLocalExecution() {
+		return nil, erero.Errorf("request type %s can not show object or balance changes", options.RequestType)
+	}
 	request := &suirpc.RpcRequest{
 		Jsonrpc: "2.0",
 		Method:  "sui_executeTransactionBlock",
 		Params: []any{
 			txBytes,
-			[]string{signatures},
-			TransactionBlockResponseOptions{
-				ShowInput:          true,
-				ShowRawInput:       true,
-				ShowEffects:        true,
-				ShowEvents:         true,
-				ShowObjectChanges:  true,
-				ShowBalanceChanges: true,
-				ShowRawEffects:     true,
-			},
-			"WaitForLocalExecution", // WaitForLocalExecution = TransactionEffectsCert + execution confirmed // WaitForLocalExecution = TransactionEffectsCert + 确认已执行
+			options.Signatures,
+			options.Options,
+			optional(options.RequestType),
 		},
-		ID: 1,
 	}
-	response, err := suirpc.SendRpc[RES](ctx, serverUrl, request)
+	return callExecution[RES](ctx, client, request)
+}
+
+// callExecution sends execute or dry run request and decodes result into RES
+// Returns suirpc.ExecutionError when effects report failure status
+//
+// callExecution 发送执行或模拟执行请求并将结果解码为 RES
+// 效果报告失败状态时返回 suirpc.ExecutionError
+func callExecution[RES any](ctx context.Context, client *suirpc.Client, request *suirpc.RpcRequest) (*RES, error) {
+	response, err := client.SendRpc(ctx, request)
 	if err != nil {
 		return nil, erero.Wro(err)
 	}
-	return &response.Result, nil
+	if err := suirpc.CheckExecution(response.Result); err != nil {
+		return nil, erero.Wro(err)
+	}
+	var result RES
+	if err := json.Unmarshal(response.Result, &result); err != nil {
+		return nil, erero.Wro(err)
+	}
+	return &result, nil
 }
 
 // GetSuiCoinsInTopPage retrieves SUI coins owned by address in first page
-// Accepts context, server URL, and wallet address string
+// Accepts context, RPC client, and wallet address string
 // Returns slice of coin objects or error if query fails
-// Verifies each coin type matches SUI native token
+// Use Client.GetCoinsOfType to read each page
 //
 // GetSuiCoinsInTopPage 检索地址在第一页拥有的 SUI 代币
-// 接受上下文、服务器 URL 和钱包地址字符串
+// 接受上下文、RPC 客户端 和钱包地址字符串
 // 返回代币对象切片，如果查询失败则返回错误
-// 验证每个代币类型与 SUI 原生代币匹配
-func GetSuiCoinsInTopPage(ctx context.Context, serverUrl string, address string) ([]*CoinType, error) {
-	// Build JSON-RPC request to query SUI coins
-	// 构建 JSON-RPC 请求以查询 SUI 代币
+// 使用 Client.GetCoinsOfType 读取全部分页
+func GetSuiCoinsInTopPage(ctx context.Context, client *suirpc.Client, address string) ([]*CoinType, error) {
+	return GetCoinsInTopPage(ctx, client, address, SuiCoinType)
+}
+
+// GetCoinsInTopPage retrieves coins of coin type owned by address in first page
+// Returns error matching ErrInvalidCoinType when coin type is malformed or node answers other types
+//
+// GetCoinsInTopPage 检索地址在第一页拥有的某种代币
+// 代币类型格式错误或节点返回其他类型时返回匹配 ErrInvalidCoinType 的错误
+func GetCoinsInTopPage(ctx context.Context, client *suirpc.Client, address string, coinType string) ([]*CoinType, error) {
+	if _, err := NormalizeCoinType(coinType); err != nil {
+		return nil, erero.Wro(err)
+	}
+
+	// Build JSON-RPC request to query coins of the type
+	// 构建 JSON-RPC 请求以查询该类型的代币
 	request := &suirpc.RpcRequest{
 		Jsonrpc: "2.0",
 		Method:  "suix_getCoins",
 		Params: []any{
 			address,
-			"0x2::sui::SUI", // Default to 0x2::sui::SUI (parameter can be omitted) // 默认为 0x2::sui::SUI（参数可以省略）
+			coinType,
 		},
-		ID: 1,
 	}
 
-	type Result struct {
-		Data []*CoinType `json:"data"` // Coin list in current page // 当前页面的代币列表
-	}
-
-	response, err := suirpc.SendRpc[Result](ctx, serverUrl, request)
+	response, err := suirpc.Call[Page[CoinType, string]](ctx, client, request)
 	if err != nil {
 		return nil, erero.Wro(err)
 	}
@@ -137,9 +165,8 @@ func GetSuiCoinsInTopPage(ctx context.Context, serverUrl string, address string)
 	// Extract coins and validate coin types
 	// 提取代币并验证代币类型
 	coins := response.Result.Data
-	for _, coin := range coins {
-		must.Same("0x2::sui::SUI", coin.CoinType)
+	if err := checkCoinTypes(coins, coinType); err != nil {
+		return nil, erero.Wro(err)
 	}
-
 	return coins, nil
 }
@@ -0,0 +1,104 @@
+package suiapi_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-xlan/sui-go-guide/suiapi"
+	"github.com/go-xlan/sui-go-guide/suirpc"
+	"github.com/go-xlan/sui-go-guide/suisigntx"
+	"github.com/stretchr/testify/require"
+)
+
+// newReplayClient creates client answering from recorded cassette without network
+//
+// newReplayClient 创建不经网络、从已录制磁带应答的客户端
+func newReplayClient(t *testing.T) *suirpc.Client {
+	cassette, err := suirpc.NewCassette("testdata/cassette.json", suirpc.CassetteReplay)
+	require.NoError(t, err)
+	return suirpc.NewClient("https://fullnode.testnet.sui.io/").Use(cassette.Middleware())
+}
+
+// TestGetSuiCoinsInTopPage tests coin query against recorded response
+//
+// TestGetSuiCoinsInTopPage 测试针对已录制响应的代币查询
+func TestGetSuiCoinsInTopPage(t *testing.T) {
+	client := newReplayClient(t)
+
+	coins, err := suiapi.GetSuiCoinsInTopPage(context.Background(), client, "0x207ed5c0ad36b96c730ed0f71e3c26a0ffb59bc20ab21d08067ca4c035d4d062")
+	require.NoError(t, err)
+	require.Len(t, coins, 2)
+	require.Equal(t, "989998000", coins[0].Balance)
+}
+
+// TestDryRunTransactionBlock tests failure status becomes ErrExecutionFailed
+//
+// TestDryRunTransactionBlock 测试失败状态转换为 ErrExecutionFailed
+func TestDryRunTransactionBlock(t *testing.T) {
+	client := newReplayClient(t)
+
+	res, err := suiapi.DryRunTransactionBlock[suiapi.EffectsStatusStatusMessage](context.Background(), client, "AAACAAgQJwAAAAAAAAAg")
+	require.NoError(t, err)
+	require.Equal(t, "success", res.Effects.Status.Status)
+
+	_, err = suiapi.DryRunTransactionBlock[suiapi.EffectsStatusStatusMessage](context.Background(), client, "AAACAAgA4fUFAAAAAAAg")
+	require.ErrorIs(t, err, suirpc.ErrExecutionFailed)
+	require.Contains(t, err.Error(), "InsufficientCoinBalance")
+}
+
+// TestExecuteTransactionBlockWithOptions tests response options, request type and several signatures reach the node
+//
+// TestExecuteTransactionBlockWithOptions 测试响应选项、请求类型和多个签名传达到节点
+func TestExecuteTransactionBlockWithOptions(t *testing.T) {
+	const sponsorKeyHex = "1111111111111111111111111111111111111111111111111111111111111111"
+
+	server := newSimulator(t)
+	client := server.Client()
+	ctx := context.Background()
+	coin := server.Mint(address, 100_000_000)
+
+	built, err := suirpc.Call[suiapi.TxBytesMessage](ctx, client, &suirpc.RpcRequest{
+		Jsonrpc: "2.0",
+		Method:  "unsafe_transferSui",
+		Params:  []any{address, coin.CoinObjectId, gasBudget, recipient, "1000"},
+	})
+	require.NoError(t, err)
+	txBytes := built.Result.TxBytes
+	signature, err := suisigntx.Sign(privateKeyHex, txBytes)
+	require.NoError(t, err)
+	sponsorSignature, err := suisigntx.Sign(sponsorKeyHex, txBytes)
+	require.NoError(t, err)
+
+	// Conflicts and missing signatures fail before sending
+	// 冲突和缺少签名会在发送前失败
+	_, err = suiapi.ExecuteTransactionBlockWithOptions[suiapi.SuiTransactionBlockResponse](ctx, client, txBytes, suiapi.ExecuteOptions{})
+	require.Error(t, err)
+	_, err = suiapi.ExecuteTransactionBlockWithOptions[suiapi.SuiTransactionBlockResponse](ctx, client, txBytes, suiapi.ExecuteOptions{
+		Signatures:  []string{signature},
+		Options:     &suiapi.TransactionBlockResponseOptions{ShowBalanceChanges: true},
+		RequestType: suiapi.ExecuteTransactionRequestTypeWaitForEffectsCert,
+	})
+	require.Error(t, err)
+
+	_, err = suiapi.ExecuteTransactionBlockWithOptions[suiapi.SuiTransactionBlockResponse](ctx, client, txBytes, suiapi.ExecuteOptions{
+		Signatures: []string{sponsorSignature},
+	})
+	require.Error(t, err)
+
+	res, err := suiapi.ExecuteTransactionBlockWithOptions[suiapi.SuiTransactionBlockResponse](ctx, client, txBytes, suiapi.ExecuteOptions{
+		Signatures:  []string{signature, sponsorSignature},
+		Options:     &suiapi.TransactionBlockResponseOptions{ShowEffects: true},
+		RequestType: suiapi.ExecuteTransactionRequestTypeWaitForEffectsCert,
+	})
+	require.NoError(t, err)
+	require.True(t, res.Succeeded())
+	require.Nil(t, res.BalanceChanges)
+	require.Nil(t, res.ObjectChanges)
+	require.False(t, *res.ConfirmedLocalExecution)
+	require.Equal(t, uint64(1000), server.Balance(recipient, suiapi.SuiCoinType))
+
+	tx, err := suiapi.NewClient(client).GetTransactionBlock(ctx, res.Digest, &suiapi.TransactionBlockResponseOptions{ShowBalanceChanges: true})
+	require.NoError(t, err)
+	require.Nil(t, tx.Effects)
+	require.Len(t, tx.BalanceChanges, 2)
+}
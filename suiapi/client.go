@@ -0,0 +1,203 @@
+package suiapi
+
+//go:generate go run ../cmd/openrpcgen -spec openrpc.json -config openrpcgen.json -out client_gen.go
+
+import (
+	"context"
+	"strconv"
+	"sync"
+
+	"github.com/go-xlan/sui-go-guide/suirpc"
+	"github.com/yyle88/erero"
+)
+
+// Client represents typed read API over suirpc.Client
+// Each method returns concrete Go types instead of maps
+// Keeps middlewares, retries and endpoint pool of the wrapped client
+//
+// Client 表示基于 suirpc.Client 的类型化读取 API
+// 每个方法返回具体的 Go 类型而不是映射
+// 保留被包装客户端的中间件、重试和端点池
+type Client struct {
+	client   *suirpc.Client // Wrapped RPC client // 被包装的 RPC 客户端
+	mutex    sync.Mutex     // Guards decimals // 保护 decimals
+	decimals map[string]int // Coin decimals by normalized coin type // 按规范化代币类型索引的代币小数位数
+}
+
+// NewClient creates typed client wrapping given RPC client
+//
+// NewClient 创建包装给定 RPC 客户端的类型化客户端
+func NewClient(client *suirpc.Client) *Client {
+	return &Client{client: client, decimals: map[string]int{}}
+}
+
+// RpcClient returns wrapped RPC client
+//
+// RpcClient 返回被包装的 RPC 客户端
+func (c *Client) RpcClient() *suirpc.Client {
+	return c.client
+}
+
+// GetChainIdentifier returns chain identifier, first four bytes of genesis checkpoint digest
+//
+// GetChainIdentifier 返回链标识符，即创世检查点摘要的前四个字节
+func (c *Client) GetChainIdentifier(ctx context.Context) (string, error) {
+	return callValue[string](ctx, c, "sui_getChainIdentifier")
+}
+
+// GetCheckpoint returns checkpoint by sequence number or digest
+//
+// GetCheckpoint 按序号或摘要返回检查点
+func (c *Client) GetCheckpoint(ctx context.Context, id string) (*Checkpoint, error) {
+	return call[Checkpoint](ctx, c, "sui_getCheckpoint", id)
+}
+
+// GetCheckpoints returns page of checkpoints after cursor, empty cursor starts at first or latest
+// Zero limit lets the node pick page size
+//
+// GetCheckpoints 返回游标之后的一页检查点，空游标从最早或最新处开始
+// limit 为零时由节点决定分页大小
+func (c *Client) GetCheckpoints(ctx context.Context, cursor string, limit int, descending bool) (*Page[Checkpoint, string], error) {
+	return call[Page[Checkpoint, string]](ctx, c, "sui_getCheckpoints", optional(cursor), optional(limit), descending)
+}
+
+// GetLatestCheckpointSequenceNumber returns sequence number of latest executed checkpoint
+//
+// GetLatestCheckpointSequenceNumber 返回最新已执行检查点的序号
+func (c *Client) GetLatestCheckpointSequenceNumber(ctx context.Context) (uint64, error) {
+	return callUint(ctx, c, "sui_getLatestCheckpointSequenceNumber")
+}
+
+// GetTotalTransactionBlocks returns number of transaction blocks known to the node
+//
+// GetTotalTransactionBlocks 返回节点已知的交易区块总数
+func (c *Client) GetTotalTransactionBlocks(ctx context.Context) (uint64, error) {
+	return callUint(ctx, c, "sui_getTotalTransactionBlocks")
+}
+
+// GetTransactionBlock returns transaction block with sections asked for in options
+// Nil options return digest only, use ReadResponseOptions to get each decoded section
+//
+// GetTransactionBlock 返回带有选项所请求部分的交易区块
+// options 为 nil 时仅返回摘要，使用 ReadResponseOptions 获取每个已解码部分
+func (c *Client) GetTransactionBlock(ctx context.Context, digest string, options *TransactionBlockResponseOptions) (*SuiTransactionBlockResponse, error) {
+	return call[SuiTransactionBlockResponse](ctx, c, "sui_getTransactionBlock", digest, options)
+}
+
+// MultiGetTransactionBlocks returns transaction blocks of given digests in one call
+//
+// MultiGetTransactionBlocks 在一次调用中返回给定摘要的交易区块
+func (c *Client) MultiGetTransactionBlocks(ctx context.Context, digests []string, options *TransactionBlockResponseOptions) ([]*SuiTransactionBlockResponse, error) {
+	return callValue[[]*SuiTransactionBlockResponse](ctx, c, "sui_multiGetTransactionBlocks", digests, options)
+}
+
+// GetBalance returns total balance of coin type owned by address, empty coin type means SUI
+//
+// GetBalance 返回地址拥有的某种代币的总余额，代币类型为空表示 SUI
+func (c *Client) GetBalance(ctx context.Context, owner string, coinType string) (*Balance, error) {
+	return call[Balance](ctx, c, "suix_getBalance", owner, optional(coinType))
+}
+
+// GetAllBalances returns total balance of each coin type owned by address
+//
+// GetAllBalances 返回地址拥有的每种代币的总余额
+func (c *Client) GetAllBalances(ctx context.Context, owner string) ([]*Balance, error) {
+	return callValue[[]*Balance](ctx, c, "suix_getAllBalances", owner)
+}
+
+// GetCoinMetadata returns metadata of coin type
+// Returns error matching suirpc.ErrObjectNotFound when coin type has no metadata
+//
+// GetCoinMetadata 返回代币类型的元数据
+// 代币类型没有元数据时返回匹配 suirpc.ErrObjectNotFound 的错误
+func (c *Client) GetCoinMetadata(ctx context.Context, coinType string) (*CoinMetadata, error) {
+	metadata, err := callValue[*CoinMetadata](ctx, c, "suix_getCoinMetadata", coinType)
+	if err != nil {
+		return nil, erero.Wro(err)
+	}
+	if metadata == nil {
+		return nil, erero.WithMessagef(suirpc.ErrObjectNotFound, "no metadata of coin type %s", coinType)
+	}
+	return metadata, nil
+}
+
+// GetTotalSupply returns total supply of coin type in minimal units
+//
+// GetTotalSupply 返回代币类型以最小单位表示的总供应量
+func (c *Client) GetTotalSupply(ctx context.Context, coinType string) (uint64, error) {
+	supply, err := call[Supply](ctx, c, "suix_getTotalSupply", coinType)
+	if err != nil {
+		return 0, erero.Wro(err)
+	}
+	return supply.Value, nil
+}
+
+// DryRunTransactionBlock simulates transaction without committing and returns effects with gas cost
+// Failure status comes back as error matching suirpc.ErrExecutionFailed
+//
+// DryRunTransactionBlock 模拟执行交易而不提交，返回包含 gas 费用的效果
+// 失败状态以匹配 suirpc.ErrExecutionFailed 的错误返回
+func (c *Client) DryRunTransactionBlock(ctx context.Context, txBytes string) (*DryRunTransactionBlockResponse, error) {
+	return DryRunTransactionBlock[DryRunTransactionBlockResponse](ctx, c.client, txBytes)
+}
+
+// ExecuteTransactionBlock executes signed transaction with given signatures, response options and request type
+// Failure status comes back as error matching suirpc.ErrExecutionFailed
+//
+// ExecuteTransactionBlock 使用给定的签名、响应选项和请求类型执行已签名的交易
+// 失败状态以匹配 suirpc.ErrExecutionFailed 的错误返回
+func (c *Client) ExecuteTransactionBlock(ctx context.Context, txBytes string, options ExecuteOptions) (*SuiTransactionBlockResponse, error) {
+	return ExecuteTransactionBlockWithOptions[SuiTransactionBlockResponse](ctx, c.client, txBytes, options)
+}
+
+// call sends method with positional params and decodes result into RES
+//
+// call 以按位置排列的参数发送方法并将结果解码为 RES
+func call[RES any](ctx context.Context, c *Client, method string, params ...any) (*RES, error) {
+	if params == nil {
+		params = []any{}
+	}
+	response, err := suirpc.Call[RES](ctx, c.client, &suirpc.RpcRequest{Jsonrpc: "2.0", Method: method, Params: params})
+	if err != nil {
+		return nil, erero.Wro(err)
+	}
+	return &response.Result, nil
+}
+
+// callValue works like call but returns result by value, suits strings, slices and maps
+//
+// callValue 与 call 相同但按值返回结果，适用于字符串、切片和映射
+func callValue[RES any](ctx context.Context, c *Client, method string, params ...any) (RES, error) {
+	result, err := call[RES](ctx, c, method, params...)
+	if err != nil {
+		var zero RES
+		return zero, erero.Wro(err)
+	}
+	return *result, nil
+}
+
+// callUint sends method answering u64 as decimal string and parses it
+//
+// callUint 发送以十进制字符串应答 u64 的方法并解析结果
+func callUint(ctx context.Context, c *Client, method string, params ...any) (uint64, error) {
+	text, err := callValue[string](ctx, c, method, params...)
+	if err != nil {
+		return 0, erero.Wro(err)
+	}
+	value, err := strconv.ParseUint(text, 10, 64)
+	if err != nil {
+		return 0, erero.WithMessagef(err, "method %s answered %q", method, text)
+	}
+	return value, nil
+}
+
+// optional returns nil for zero value so the param goes out as JSON null
+//
+// optional 对零值返回 nil，使参数以 JSON null 发出
+func optional[T comparable](value T) any {
+	var zero T
+	if value == zero {
+		return nil
+	}
+	return value
+}
@@ -0,0 +1,717 @@
+// Code generated by openrpcgen from openrpc.json (Sui JSON-RPC 1.40.0). DO NOT EDIT.
+
+package suiapi
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// GasCostSummary represents gas cost breakdown of one transaction or epoch
+type GasCostSummary struct {
+	ComputationCost         uint64 `json:"computationCost,string"`
+	StorageCost             uint64 `json:"storageCost,string"`
+	StorageRebate           uint64 `json:"storageRebate,string"`
+	NonRefundableStorageFee uint64 `json:"nonRefundableStorageFee,string"`
+}
+
+// Checkpoint represents checkpoint summary with its transaction digests
+type Checkpoint struct {
+	Epoch                      uint64            `json:"epoch,string"`
+	SequenceNumber             uint64            `json:"sequenceNumber,string"`
+	Digest                     string            `json:"digest"`
+	NetworkTotalTransactions   uint64            `json:"networkTotalTransactions,string"`
+	PreviousDigest             *string           `json:"previousDigest,omitempty"`
+	EpochRollingGasCostSummary GasCostSummary    `json:"epochRollingGasCostSummary"`
+	TimestampMs                uint64            `json:"timestampMs,string"`
+	EndOfEpochData             json.RawMessage   `json:"endOfEpochData,omitempty"`
+	Transactions               []string          `json:"transactions"`
+	CheckpointCommitments      []json.RawMessage `json:"checkpointCommitments"`
+	ValidatorSignature         string            `json:"validatorSignature"`
+}
+
+// Balance represents total balance of one coin type owned by an address
+type Balance struct {
+	CoinType        string            `json:"coinType"`
+	CoinObjectCount uint              `json:"coinObjectCount"`
+	TotalBalance    string            `json:"totalBalance"`
+	LockedBalance   map[string]string `json:"lockedBalance"`
+}
+
+// Supply represents total supply of one coin type
+type Supply struct {
+	Value uint64 `json:"value,string"`
+}
+
+// ExecuteTransactionRequestType represents how long execution waits before answering
+type ExecuteTransactionRequestType string
+
+const (
+	ExecuteTransactionRequestTypeWaitForEffectsCert    ExecuteTransactionRequestType = "WaitForEffectsCert"
+	ExecuteTransactionRequestTypeWaitForLocalExecution ExecuteTransactionRequestType = "WaitForLocalExecution"
+)
+
+// SuiTransactionBlockBuilderMode represents transaction builder mode
+type SuiTransactionBlockBuilderMode string
+
+const (
+	SuiTransactionBlockBuilderModeCommit     SuiTransactionBlockBuilderMode = "Commit"
+	SuiTransactionBlockBuilderModeDevInspect SuiTransactionBlockBuilderMode = "DevInspect"
+)
+
+// DryRunTransactionBlockResponse represents dry run result
+type DryRunTransactionBlockResponse struct {
+	Effects        TransactionEffects `json:"effects"`
+	Events         []*SuiEvent        `json:"events"`
+	ObjectChanges  []*ObjectChange    `json:"objectChanges"`
+	BalanceChanges []*BalanceChange   `json:"balanceChanges"`
+	Input          json.RawMessage    `json:"input"`
+}
+
+// DevInspectArgs represents extra dev inspect arguments
+type DevInspectArgs struct {
+	GasBudget                uint64       `json:"gasBudget,string,omitempty"`
+	GasObjects               []*ObjectRef `json:"gasObjects,omitempty"`
+	GasSponsor               string       `json:"gasSponsor,omitempty"`
+	SkipChecks               bool         `json:"skipChecks,omitempty"`
+	ShowRawTxnDataAndEffects bool         `json:"showRawTxnDataAndEffects,omitempty"`
+}
+
+// DevInspectResults represents dev inspect result
+type DevInspectResults struct {
+	Effects    TransactionEffects `json:"effects"`
+	Events     []*SuiEvent        `json:"events"`
+	Results    []json.RawMessage  `json:"results,omitempty"`
+	Error      string             `json:"error,omitempty"`
+	RawTxnData []uint8            `json:"rawTxnData,omitempty"`
+	RawEffects []uint8            `json:"rawEffects,omitempty"`
+}
+
+// TransactionBlockBytes represents unsigned transaction built by unsafe methods
+type TransactionBlockBytes struct {
+	TxBytes      string            `json:"txBytes"`
+	Gas          []*ObjectRef      `json:"gas"`
+	InputObjects []json.RawMessage `json:"inputObjects"`
+}
+
+// SuiMoveVisibility represents move function visibility
+type SuiMoveVisibility string
+
+const (
+	SuiMoveVisibilityPrivate SuiMoveVisibility = "Private"
+	SuiMoveVisibilityPublic  SuiMoveVisibility = "Public"
+	SuiMoveVisibilityFriend  SuiMoveVisibility = "Friend"
+)
+
+// SuiMoveAbility represents move ability
+type SuiMoveAbility string
+
+const (
+	SuiMoveAbilityCopy  SuiMoveAbility = "Copy"
+	SuiMoveAbilityDrop  SuiMoveAbility = "Drop"
+	SuiMoveAbilityStore SuiMoveAbility = "Store"
+	SuiMoveAbilityKey   SuiMoveAbility = "Key"
+)
+
+// SuiMoveAbilitySet represents set of Move abilities
+type SuiMoveAbilitySet struct {
+	Abilities []SuiMoveAbility `json:"abilities"`
+}
+
+// SuiMoveNormalizedFunction represents normalized Move function
+type SuiMoveNormalizedFunction struct {
+	Visibility     SuiMoveVisibility    `json:"visibility"`
+	IsEntry        bool                 `json:"isEntry"`
+	TypeParameters []*SuiMoveAbilitySet `json:"typeParameters"`
+	Parameters     []json.RawMessage    `json:"parameters"`
+	Return         []json.RawMessage    `json:"return"`
+}
+
+// SuiMoveStructTypeParameter represents move struct type parameter
+type SuiMoveStructTypeParameter struct {
+	Constraints SuiMoveAbilitySet `json:"constraints"`
+	IsPhantom   bool              `json:"isPhantom"`
+}
+
+// SuiMoveNormalizedField represents move struct field
+type SuiMoveNormalizedField struct {
+	Name string          `json:"name"`
+	Type json.RawMessage `json:"type"`
+}
+
+// SuiMoveNormalizedStruct represents normalized Move struct
+type SuiMoveNormalizedStruct struct {
+	Abilities      SuiMoveAbilitySet             `json:"abilities"`
+	TypeParameters []*SuiMoveStructTypeParameter `json:"typeParameters"`
+	Fields         []*SuiMoveNormalizedField     `json:"fields"`
+}
+
+// SuiMoveModuleId represents move module identifier
+type SuiMoveModuleId struct {
+	Address string `json:"address"`
+	Name    string `json:"name"`
+}
+
+// SuiMoveNormalizedModule represents normalized Move module
+type SuiMoveNormalizedModule struct {
+	FileFormatVersion uint32                                `json:"fileFormatVersion"`
+	Address           string                                `json:"address"`
+	Name              string                                `json:"name"`
+	Friends           []*SuiMoveModuleId                    `json:"friends"`
+	Structs           map[string]*SuiMoveNormalizedStruct   `json:"structs"`
+	ExposedFunctions  map[string]*SuiMoveNormalizedFunction `json:"exposedFunctions"`
+}
+
+// ProtocolConfig represents protocol configuration of one version
+type ProtocolConfig struct {
+	MinSupportedProtocolVersion uint64                     `json:"minSupportedProtocolVersion,string"`
+	MaxSupportedProtocolVersion uint64                     `json:"maxSupportedProtocolVersion,string"`
+	ProtocolVersion             uint64                     `json:"protocolVersion,string"`
+	FeatureFlags                map[string]bool            `json:"featureFlags"`
+	Attributes                  map[string]json.RawMessage `json:"attributes"`
+}
+
+// CommitteeInfo represents validator committee of one epoch
+type CommitteeInfo struct {
+	Epoch      uint64            `json:"epoch,string"`
+	Validators []json.RawMessage `json:"validators"`
+}
+
+// SuiValidatorSummary represents summary of one active validator
+type SuiValidatorSummary struct {
+	SuiAddress            string `json:"suiAddress"`
+	Name                  string `json:"name"`
+	Description           string `json:"description"`
+	ImageUrl              string `json:"imageUrl"`
+	ProjectUrl            string `json:"projectUrl"`
+	StakingPoolId         string `json:"stakingPoolId"`
+	VotingPower           uint64 `json:"votingPower,string"`
+	GasPrice              uint64 `json:"gasPrice,string"`
+	CommissionRate        uint64 `json:"commissionRate,string"`
+	StakingPoolSuiBalance uint64 `json:"stakingPoolSuiBalance,string"`
+	NextEpochStake        uint64 `json:"nextEpochStake,string"`
+}
+
+// SuiSystemStateSummary represents summary of the Sui system state object
+type SuiSystemStateSummary struct {
+	Epoch                 uint64                 `json:"epoch,string"`
+	ProtocolVersion       uint64                 `json:"protocolVersion,string"`
+	SystemStateVersion    uint64                 `json:"systemStateVersion,string"`
+	ReferenceGasPrice     uint64                 `json:"referenceGasPrice,string"`
+	TotalStake            uint64                 `json:"totalStake,string"`
+	EpochStartTimestampMs uint64                 `json:"epochStartTimestampMs,string"`
+	EpochDurationMs       uint64                 `json:"epochDurationMs,string"`
+	SafeMode              bool                   `json:"safeMode"`
+	ActiveValidators      []*SuiValidatorSummary `json:"activeValidators"`
+}
+
+// DelegatedStake represents stakes of one owner in one validator pool
+type DelegatedStake struct {
+	ValidatorAddress string            `json:"validatorAddress"`
+	StakingPool      string            `json:"stakingPool"`
+	Stakes           []json.RawMessage `json:"stakes"`
+}
+
+// ValidatorApy represents APY of one validator
+type ValidatorApy struct {
+	Address string  `json:"address"`
+	Apy     float64 `json:"apy"`
+}
+
+// ValidatorApys represents APYs of active validators
+type ValidatorApys struct {
+	Apys  []*ValidatorApy `json:"apys"`
+	Epoch uint64          `json:"epoch,string"`
+}
+
+// LoadedChildObject represents child object loaded by a transaction
+type LoadedChildObject struct {
+	ObjectId       string `json:"objectId"`
+	SequenceNumber string `json:"sequenceNumber"`
+}
+
+// LoadedChildObjectsResponse represents child objects loaded by a transaction
+type LoadedChildObjectsResponse struct {
+	LoadedChildObjects []*LoadedChildObject `json:"loadedChildObjects"`
+}
+
+// ZkLoginIntentScope represents intent scope of zkLogin signature
+type ZkLoginIntentScope string
+
+const (
+	ZkLoginIntentScopeTransactionData ZkLoginIntentScope = "TransactionData"
+	ZkLoginIntentScopePersonalMessage ZkLoginIntentScope = "PersonalMessage"
+)
+
+// ZkLoginVerifyResult represents zkLogin signature verification result
+type ZkLoginVerifyResult struct {
+	Success bool     `json:"success"`
+	Errors  []string `json:"errors"`
+}
+
+// NameServicePage represents page of SuiNS names
+type NameServicePage struct {
+	Data        []string `json:"data"`
+	NextCursor  *string  `json:"nextCursor,omitempty"`
+	HasNextPage bool     `json:"hasNextPage"`
+}
+
+// DevInspectTransactionBlockParams represents positional params of sui_devInspectTransactionBlock
+type DevInspectTransactionBlockParams struct {
+	SenderAddress  string          // Sender address
+	TxBytes        string          // BCS encoded TransactionKind
+	GasPrice       *string         // Gas price, reference price when null
+	Epoch          *string         // Epoch to run in, current when null
+	AdditionalArgs *DevInspectArgs // Extra dev inspect arguments
+}
+
+// DevInspectTransactionBlock calls sui_devInspectTransactionBlock
+// Runs transaction in dev-inspect mode, allowing nearly any transaction and returning results without committing
+//
+// DevInspectTransactionBlock 调用 sui_devInspectTransactionBlock
+func (c *Client) DevInspectTransactionBlock(ctx context.Context, params DevInspectTransactionBlockParams) (*DevInspectResults, error) {
+	return call[DevInspectResults](ctx, c, "sui_devInspectTransactionBlock", params.SenderAddress, params.TxBytes, params.GasPrice, params.Epoch, params.AdditionalArgs)
+}
+
+// GetEventsParams represents positional params of sui_getEvents
+type GetEventsParams struct {
+	TransactionDigest string // Transaction digest
+}
+
+// GetEvents calls sui_getEvents
+// Returns events emitted by transaction
+//
+// GetEvents 调用 sui_getEvents
+func (c *Client) GetEvents(ctx context.Context, params GetEventsParams) ([]*SuiEvent, error) {
+	return callValue[[]*SuiEvent](ctx, c, "sui_getEvents", params.TransactionDigest)
+}
+
+// GetLoadedChildObjectsParams represents positional params of sui_getLoadedChildObjects
+type GetLoadedChildObjectsParams struct {
+	Digest string // Transaction digest
+}
+
+// GetLoadedChildObjects calls sui_getLoadedChildObjects
+// Returns child objects loaded by transaction
+//
+// GetLoadedChildObjects 调用 sui_getLoadedChildObjects
+func (c *Client) GetLoadedChildObjects(ctx context.Context, params GetLoadedChildObjectsParams) (*LoadedChildObjectsResponse, error) {
+	return call[LoadedChildObjectsResponse](ctx, c, "sui_getLoadedChildObjects", params.Digest)
+}
+
+// GetMoveFunctionArgTypesParams represents positional params of sui_getMoveFunctionArgTypes
+type GetMoveFunctionArgTypesParams struct {
+	Package  string // Package ID
+	Module   string // Module name
+	Function string // Function name
+}
+
+// GetMoveFunctionArgTypes calls sui_getMoveFunctionArgTypes
+// Returns argument kinds of Move function
+//
+// GetMoveFunctionArgTypes 调用 sui_getMoveFunctionArgTypes
+func (c *Client) GetMoveFunctionArgTypes(ctx context.Context, params GetMoveFunctionArgTypesParams) ([]json.RawMessage, error) {
+	return callValue[[]json.RawMessage](ctx, c, "sui_getMoveFunctionArgTypes", params.Package, params.Module, params.Function)
+}
+
+// GetNormalizedMoveFunctionParams represents positional params of sui_getNormalizedMoveFunction
+type GetNormalizedMoveFunctionParams struct {
+	Package      string // Package ID
+	ModuleName   string // Module name
+	FunctionName string // Function name
+}
+
+// GetNormalizedMoveFunction calls sui_getNormalizedMoveFunction
+// Returns normalized Move function
+//
+// GetNormalizedMoveFunction 调用 sui_getNormalizedMoveFunction
+func (c *Client) GetNormalizedMoveFunction(ctx context.Context, params GetNormalizedMoveFunctionParams) (*SuiMoveNormalizedFunction, error) {
+	return call[SuiMoveNormalizedFunction](ctx, c, "sui_getNormalizedMoveFunction", params.Package, params.ModuleName, params.FunctionName)
+}
+
+// GetNormalizedMoveModuleParams represents positional params of sui_getNormalizedMoveModule
+type GetNormalizedMoveModuleParams struct {
+	Package    string // Package ID
+	ModuleName string // Module name
+}
+
+// GetNormalizedMoveModule calls sui_getNormalizedMoveModule
+// Returns normalized Move module
+//
+// GetNormalizedMoveModule 调用 sui_getNormalizedMoveModule
+func (c *Client) GetNormalizedMoveModule(ctx context.Context, params GetNormalizedMoveModuleParams) (*SuiMoveNormalizedModule, error) {
+	return call[SuiMoveNormalizedModule](ctx, c, "sui_getNormalizedMoveModule", params.Package, params.ModuleName)
+}
+
+// GetNormalizedMoveModulesByPackageParams represents positional params of sui_getNormalizedMoveModulesByPackage
+type GetNormalizedMoveModulesByPackageParams struct {
+	Package string // Package ID
+}
+
+// GetNormalizedMoveModulesByPackage calls sui_getNormalizedMoveModulesByPackage
+// Returns normalized Move modules of package keyed by name
+//
+// GetNormalizedMoveModulesByPackage 调用 sui_getNormalizedMoveModulesByPackage
+func (c *Client) GetNormalizedMoveModulesByPackage(ctx context.Context, params GetNormalizedMoveModulesByPackageParams) (map[string]*SuiMoveNormalizedModule, error) {
+	return callValue[map[string]*SuiMoveNormalizedModule](ctx, c, "sui_getNormalizedMoveModulesByPackage", params.Package)
+}
+
+// GetNormalizedMoveStructParams represents positional params of sui_getNormalizedMoveStruct
+type GetNormalizedMoveStructParams struct {
+	Package    string // Package ID
+	ModuleName string // Module name
+	StructName string // Struct name
+}
+
+// GetNormalizedMoveStruct calls sui_getNormalizedMoveStruct
+// Returns normalized Move struct
+//
+// GetNormalizedMoveStruct 调用 sui_getNormalizedMoveStruct
+func (c *Client) GetNormalizedMoveStruct(ctx context.Context, params GetNormalizedMoveStructParams) (*SuiMoveNormalizedStruct, error) {
+	return call[SuiMoveNormalizedStruct](ctx, c, "sui_getNormalizedMoveStruct", params.Package, params.ModuleName, params.StructName)
+}
+
+// GetProtocolConfigParams represents positional params of sui_getProtocolConfig
+type GetProtocolConfigParams struct {
+	Version *string // Protocol version
+}
+
+// GetProtocolConfig calls sui_getProtocolConfig
+// Returns protocol config of version, latest when null
+//
+// GetProtocolConfig 调用 sui_getProtocolConfig
+func (c *Client) GetProtocolConfig(ctx context.Context, params GetProtocolConfigParams) (*ProtocolConfig, error) {
+	return call[ProtocolConfig](ctx, c, "sui_getProtocolConfig", params.Version)
+}
+
+// VerifyZkLoginSignatureParams represents positional params of sui_verifyZkLoginSignature
+type VerifyZkLoginSignatureParams struct {
+	Bytes       string             // Signed bytes, Base64 encoded
+	Signature   string             // zkLogin signature, Base64 encoded
+	IntentScope ZkLoginIntentScope // Intent scope of the bytes
+	Author      string             // Signer address
+}
+
+// VerifyZkLoginSignature calls sui_verifyZkLoginSignature
+// Verifies zkLogin signature over bytes
+//
+// VerifyZkLoginSignature 调用 sui_verifyZkLoginSignature
+func (c *Client) VerifyZkLoginSignature(ctx context.Context, params VerifyZkLoginSignatureParams) (*ZkLoginVerifyResult, error) {
+	return call[ZkLoginVerifyResult](ctx, c, "sui_verifyZkLoginSignature", params.Bytes, params.Signature, params.IntentScope, params.Author)
+}
+
+// GetCommitteeInfoParams represents positional params of suix_getCommitteeInfo
+type GetCommitteeInfoParams struct {
+	Epoch *string // Epoch
+}
+
+// GetCommitteeInfo calls suix_getCommitteeInfo
+// Returns committee of epoch, current when null
+//
+// GetCommitteeInfo 调用 suix_getCommitteeInfo
+func (c *Client) GetCommitteeInfo(ctx context.Context, params GetCommitteeInfoParams) (*CommitteeInfo, error) {
+	return call[CommitteeInfo](ctx, c, "suix_getCommitteeInfo", params.Epoch)
+}
+
+// GetLatestSuiSystemState calls suix_getLatestSuiSystemState
+// Returns latest Sui system state summary
+//
+// GetLatestSuiSystemState 调用 suix_getLatestSuiSystemState
+func (c *Client) GetLatestSuiSystemState(ctx context.Context) (*SuiSystemStateSummary, error) {
+	return call[SuiSystemStateSummary](ctx, c, "suix_getLatestSuiSystemState")
+}
+
+// GetReferenceGasPrice calls suix_getReferenceGasPrice
+// Returns reference gas price of current epoch
+//
+// GetReferenceGasPrice 调用 suix_getReferenceGasPrice
+func (c *Client) GetReferenceGasPrice(ctx context.Context) (string, error) {
+	return callValue[string](ctx, c, "suix_getReferenceGasPrice")
+}
+
+// GetStakesParams represents positional params of suix_getStakes
+type GetStakesParams struct {
+	Owner string // Owner address
+}
+
+// GetStakes calls suix_getStakes
+// Returns stakes of owner
+//
+// GetStakes 调用 suix_getStakes
+func (c *Client) GetStakes(ctx context.Context, params GetStakesParams) ([]*DelegatedStake, error) {
+	return callValue[[]*DelegatedStake](ctx, c, "suix_getStakes", params.Owner)
+}
+
+// GetStakesByIdsParams represents positional params of suix_getStakesByIds
+type GetStakesByIdsParams struct {
+	StakedSuiIds []string // Staked SUI object IDs
+}
+
+// GetStakesByIds calls suix_getStakesByIds
+// Returns stakes by staked SUI object IDs
+//
+// GetStakesByIds 调用 suix_getStakesByIds
+func (c *Client) GetStakesByIds(ctx context.Context, params GetStakesByIdsParams) ([]*DelegatedStake, error) {
+	return callValue[[]*DelegatedStake](ctx, c, "suix_getStakesByIds", params.StakedSuiIds)
+}
+
+// GetValidatorsApy calls suix_getValidatorsApy
+// Returns APY of each active validator
+//
+// GetValidatorsApy 调用 suix_getValidatorsApy
+func (c *Client) GetValidatorsApy(ctx context.Context) (*ValidatorApys, error) {
+	return call[ValidatorApys](ctx, c, "suix_getValidatorsApy")
+}
+
+// ResolveNameServiceAddressParams represents positional params of suix_resolveNameServiceAddress
+type ResolveNameServiceAddressParams struct {
+	Name string // SuiNS name
+}
+
+// ResolveNameServiceAddress calls suix_resolveNameServiceAddress
+// Returns address of SuiNS name, null when unknown
+//
+// ResolveNameServiceAddress 调用 suix_resolveNameServiceAddress
+func (c *Client) ResolveNameServiceAddress(ctx context.Context, params ResolveNameServiceAddressParams) (*string, error) {
+	return callValue[*string](ctx, c, "suix_resolveNameServiceAddress", params.Name)
+}
+
+// ResolveNameServiceNamesParams represents positional params of suix_resolveNameServiceNames
+type ResolveNameServiceNamesParams struct {
+	Address string  // Address
+	Cursor  *string // Cursor returned by previous page, null for first page
+	Limit   *uint   // Max items per page
+}
+
+// ResolveNameServiceNames calls suix_resolveNameServiceNames
+// Returns page of SuiNS names pointing to address
+//
+// ResolveNameServiceNames 调用 suix_resolveNameServiceNames
+func (c *Client) ResolveNameServiceNames(ctx context.Context, params ResolveNameServiceNamesParams) (*NameServicePage, error) {
+	return call[NameServicePage](ctx, c, "suix_resolveNameServiceNames", params.Address, params.Cursor, params.Limit)
+}
+
+// UnsafeBatchTransactionParams represents positional params of unsafe_batchTransaction
+type UnsafeBatchTransactionParams struct {
+	Signer                  string                          // Transaction signer
+	SingleTransactionParams []json.RawMessage               // Commands to run
+	Gas                     *string                         // Gas object, node picks one when null
+	GasBudget               string                          // Gas budget in MIST
+	TxnBuilderMode          *SuiTransactionBlockBuilderMode // Builder mode, Commit when null
+}
+
+// UnsafeBatchTransaction calls unsafe_batchTransaction
+// Builds transaction running several commands
+//
+// UnsafeBatchTransaction 调用 unsafe_batchTransaction
+func (c *Client) UnsafeBatchTransaction(ctx context.Context, params UnsafeBatchTransactionParams) (*TransactionBlockBytes, error) {
+	return call[TransactionBlockBytes](ctx, c, "unsafe_batchTransaction", params.Signer, params.SingleTransactionParams, params.Gas, params.GasBudget, params.TxnBuilderMode)
+}
+
+// UnsafeMergeCoinsParams represents positional params of unsafe_mergeCoins
+type UnsafeMergeCoinsParams struct {
+	Signer      string  // Transaction signer
+	PrimaryCoin string  // Coin receiving the balance
+	CoinToMerge string  // Coin merged and deleted
+	Gas         *string // Gas object, node picks one when null
+	GasBudget   string  // Gas budget in MIST
+}
+
+// UnsafeMergeCoins calls unsafe_mergeCoins
+// Builds transaction merging two coins into the primary one
+//
+// UnsafeMergeCoins 调用 unsafe_mergeCoins
+func (c *Client) UnsafeMergeCoins(ctx context.Context, params UnsafeMergeCoinsParams) (*TransactionBlockBytes, error) {
+	return call[TransactionBlockBytes](ctx, c, "unsafe_mergeCoins", params.Signer, params.PrimaryCoin, params.CoinToMerge, params.Gas, params.GasBudget)
+}
+
+// UnsafeMoveCallParams represents positional params of unsafe_moveCall
+type UnsafeMoveCallParams struct {
+	Signer          string                          // Transaction signer
+	PackageObjectId string                          // Package ID
+	Module          string                          // Module name
+	Function        string                          // Function name
+	TypeArguments   []string                        // Type arguments
+	Arguments       []json.RawMessage               // Call arguments
+	Gas             *string                         // Gas object, node picks one when null
+	GasBudget       string                          // Gas budget in MIST
+	ExecutionMode   *SuiTransactionBlockBuilderMode // Builder mode, Commit when null
+}
+
+// UnsafeMoveCall calls unsafe_moveCall
+// Builds transaction calling Move function
+//
+// UnsafeMoveCall 调用 unsafe_moveCall
+func (c *Client) UnsafeMoveCall(ctx context.Context, params UnsafeMoveCallParams) (*TransactionBlockBytes, error) {
+	return call[TransactionBlockBytes](ctx, c, "unsafe_moveCall", params.Signer, params.PackageObjectId, params.Module, params.Function, params.TypeArguments, params.Arguments, params.Gas, params.GasBudget, params.ExecutionMode)
+}
+
+// UnsafePayParams represents positional params of unsafe_pay
+type UnsafePayParams struct {
+	Signer     string   // Transaction signer
+	InputCoins []string // Coins to pay from
+	Recipients []string // Recipient addresses
+	Amounts    []string // Amounts in coin units, one per recipient
+	Gas        *string  // Gas object, node picks one when null
+	GasBudget  string   // Gas budget in MIST
+}
+
+// UnsafePay calls unsafe_pay
+// Builds transaction paying amounts from coins to recipients
+//
+// UnsafePay 调用 unsafe_pay
+func (c *Client) UnsafePay(ctx context.Context, params UnsafePayParams) (*TransactionBlockBytes, error) {
+	return call[TransactionBlockBytes](ctx, c, "unsafe_pay", params.Signer, params.InputCoins, params.Recipients, params.Amounts, params.Gas, params.GasBudget)
+}
+
+// UnsafePayAllSuiParams represents positional params of unsafe_payAllSui
+type UnsafePayAllSuiParams struct {
+	Signer     string   // Transaction signer
+	InputCoins []string // SUI coins to send
+	Recipient  string   // Recipient address
+	GasBudget  string   // Gas budget in MIST
+}
+
+// UnsafePayAllSui calls unsafe_payAllSui
+// Builds transaction sending whole balance of SUI coins to recipient, first coin pays gas
+//
+// UnsafePayAllSui 调用 unsafe_payAllSui
+func (c *Client) UnsafePayAllSui(ctx context.Context, params UnsafePayAllSuiParams) (*TransactionBlockBytes, error) {
+	return call[TransactionBlockBytes](ctx, c, "unsafe_payAllSui", params.Signer, params.InputCoins, params.Recipient, params.GasBudget)
+}
+
+// UnsafePaySuiParams represents positional params of unsafe_paySui
+type UnsafePaySuiParams struct {
+	Signer     string   // Transaction signer
+	InputCoins []string // SUI coins to pay from
+	Recipients []string // Recipient addresses
+	Amounts    []string // Amounts in MIST, one per recipient
+	GasBudget  string   // Gas budget in MIST
+}
+
+// UnsafePaySui calls unsafe_paySui
+// Builds transaction paying SUI amounts to recipients, first coin pays gas
+//
+// UnsafePaySui 调用 unsafe_paySui
+func (c *Client) UnsafePaySui(ctx context.Context, params UnsafePaySuiParams) (*TransactionBlockBytes, error) {
+	return call[TransactionBlockBytes](ctx, c, "unsafe_paySui", params.Signer, params.InputCoins, params.Recipients, params.Amounts, params.GasBudget)
+}
+
+// UnsafePublishParams represents positional params of unsafe_publish
+type UnsafePublishParams struct {
+	Sender          string   // Transaction sender
+	CompiledModules []string // Compiled modules, Base64 encoded
+	Dependencies    []string // Dependency package IDs
+	Gas             *string  // Gas object, node picks one when null
+	GasBudget       string   // Gas budget in MIST
+}
+
+// UnsafePublish calls unsafe_publish
+// Builds transaction publishing Move package
+//
+// UnsafePublish 调用 unsafe_publish
+func (c *Client) UnsafePublish(ctx context.Context, params UnsafePublishParams) (*TransactionBlockBytes, error) {
+	return call[TransactionBlockBytes](ctx, c, "unsafe_publish", params.Sender, params.CompiledModules, params.Dependencies, params.Gas, params.GasBudget)
+}
+
+// UnsafeRequestAddStakeParams represents positional params of unsafe_requestAddStake
+type UnsafeRequestAddStakeParams struct {
+	Signer    string   // Transaction signer
+	Coins     []string // Coins to stake
+	Amount    *string  // Amount to stake, whole coins when null
+	Validator string   // Validator address
+	Gas       *string  // Gas object, node picks one when null
+	GasBudget string   // Gas budget in MIST
+}
+
+// UnsafeRequestAddStake calls unsafe_requestAddStake
+// Builds transaction staking coins with validator
+//
+// UnsafeRequestAddStake 调用 unsafe_requestAddStake
+func (c *Client) UnsafeRequestAddStake(ctx context.Context, params UnsafeRequestAddStakeParams) (*TransactionBlockBytes, error) {
+	return call[TransactionBlockBytes](ctx, c, "unsafe_requestAddStake", params.Signer, params.Coins, params.Amount, params.Validator, params.Gas, params.GasBudget)
+}
+
+// UnsafeRequestWithdrawStakeParams represents positional params of unsafe_requestWithdrawStake
+type UnsafeRequestWithdrawStakeParams struct {
+	Signer    string  // Transaction signer
+	StakedSui string  // Staked SUI object ID
+	Gas       *string // Gas object, node picks one when null
+	GasBudget string  // Gas budget in MIST
+}
+
+// UnsafeRequestWithdrawStake calls unsafe_requestWithdrawStake
+// Builds transaction withdrawing stake
+//
+// UnsafeRequestWithdrawStake 调用 unsafe_requestWithdrawStake
+func (c *Client) UnsafeRequestWithdrawStake(ctx context.Context, params UnsafeRequestWithdrawStakeParams) (*TransactionBlockBytes, error) {
+	return call[TransactionBlockBytes](ctx, c, "unsafe_requestWithdrawStake", params.Signer, params.StakedSui, params.Gas, params.GasBudget)
+}
+
+// UnsafeSplitCoinParams represents positional params of unsafe_splitCoin
+type UnsafeSplitCoinParams struct {
+	Signer       string   // Transaction signer
+	CoinObjectId string   // Coin to split
+	SplitAmounts []string // Amounts of new coins
+	Gas          *string  // Gas object, node picks one when null
+	GasBudget    string   // Gas budget in MIST
+}
+
+// UnsafeSplitCoin calls unsafe_splitCoin
+// Builds transaction splitting coin into amounts
+//
+// UnsafeSplitCoin 调用 unsafe_splitCoin
+func (c *Client) UnsafeSplitCoin(ctx context.Context, params UnsafeSplitCoinParams) (*TransactionBlockBytes, error) {
+	return call[TransactionBlockBytes](ctx, c, "unsafe_splitCoin", params.Signer, params.CoinObjectId, params.SplitAmounts, params.Gas, params.GasBudget)
+}
+
+// UnsafeSplitCoinEqualParams represents positional params of unsafe_splitCoinEqual
+type UnsafeSplitCoinEqualParams struct {
+	Signer       string  // Transaction signer
+	CoinObjectId string  // Coin to split
+	SplitCount   string  // Number of parts
+	Gas          *string // Gas object, node picks one when null
+	GasBudget    string  // Gas budget in MIST
+}
+
+// UnsafeSplitCoinEqual calls unsafe_splitCoinEqual
+// Builds transaction splitting coin into equal parts
+//
+// UnsafeSplitCoinEqual 调用 unsafe_splitCoinEqual
+func (c *Client) UnsafeSplitCoinEqual(ctx context.Context, params UnsafeSplitCoinEqualParams) (*TransactionBlockBytes, error) {
+	return call[TransactionBlockBytes](ctx, c, "unsafe_splitCoinEqual", params.Signer, params.CoinObjectId, params.SplitCount, params.Gas, params.GasBudget)
+}
+
+// UnsafeTransferObjectParams represents positional params of unsafe_transferObject
+type UnsafeTransferObjectParams struct {
+	Signer    string  // Transaction signer
+	ObjectId  string  // Object to transfer
+	Gas       *string // Gas object, node picks one when null
+	GasBudget string  // Gas budget in MIST
+	Recipient string  // Recipient address
+}
+
+// UnsafeTransferObject calls unsafe_transferObject
+// Builds transaction transferring object to recipient
+//
+// UnsafeTransferObject 调用 unsafe_transferObject
+func (c *Client) UnsafeTransferObject(ctx context.Context, params UnsafeTransferObjectParams) (*TransactionBlockBytes, error) {
+	return call[TransactionBlockBytes](ctx, c, "unsafe_transferObject", params.Signer, params.ObjectId, params.Gas, params.GasBudget, params.Recipient)
+}
+
+// UnsafeTransferSuiParams represents positional params of unsafe_transferSui
+type UnsafeTransferSuiParams struct {
+	Signer      string  // Transaction signer
+	SuiObjectId string  // SUI coin to transfer
+	GasBudget   string  // Gas budget in MIST
+	Recipient   string  // Recipient address
+	Amount      *string // Amount to transfer, whole coin when null
+}
+
+// UnsafeTransferSui calls unsafe_transferSui
+// Builds transaction transferring SUI coin or part of it to recipient, the coin pays gas
+//
+// UnsafeTransferSui 调用 unsafe_transferSui
+func (c *Client) UnsafeTransferSui(ctx context.Context, params UnsafeTransferSuiParams) (*TransactionBlockBytes, error) {
+	return call[TransactionBlockBytes](ctx, c, "unsafe_transferSui", params.Signer, params.SuiObjectId, params.GasBudget, params.Recipient, params.Amount)
+}
@@ -0,0 +1,113 @@
+package suiapi_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/go-xlan/sui-go-guide/internal/openrpcgen"
+	"github.com/go-xlan/sui-go-guide/suiapi"
+	"github.com/go-xlan/sui-go-guide/suirpc"
+	"github.com/go-xlan/sui-go-guide/suisigntx"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGenerated tests checked-in client_gen.go matches output of the generator
+// Fails after editing openrpc.json or openrpcgen.json without running go generate
+//
+// TestGenerated 测试已提交的 client_gen.go 与生成器输出一致
+// 修改 openrpc.json 或 openrpcgen.json 后未运行 go generate 时失败
+func TestGenerated(t *testing.T) {
+	data, err := os.ReadFile("openrpc.json")
+	require.NoError(t, err)
+	document, err := openrpcgen.ParseDocument(data)
+	require.NoError(t, err)
+	data, err = os.ReadFile("openrpcgen.json")
+	require.NoError(t, err)
+	config, err := openrpcgen.ParseConfig(data)
+	require.NoError(t, err)
+	source, err := openrpcgen.Generate(document, openrpcgen.Options{
+		PackageName: "suiapi",
+		Source:      "openrpc.json",
+		Prefixes:    []string{"sui_", "suix_", "unsafe_"},
+		Types:       config.Types,
+		Skip:        config.Skip,
+	})
+	require.NoError(t, err)
+
+	current, err := os.ReadFile("client_gen.go")
+	require.NoError(t, err)
+	require.Equal(t, string(source), string(current), "run go generate ./suiapi")
+}
+
+// TestClient_Generated tests generated methods build, sign and execute against the simulator with hand-written types
+//
+// TestClient_Generated 测试生成的方法使用手写类型针对模拟器构建、签名并执行
+func TestClient_Generated(t *testing.T) {
+	server := newSimulator(t)
+	coin := server.Mint(address, 100_000_000)
+	client := suiapi.NewClient(server.Client())
+	ctx := context.Background()
+
+	amount := "1000"
+	built, err := client.UnsafeTransferSui(ctx, suiapi.UnsafeTransferSuiParams{
+		Signer:      address,
+		SuiObjectId: coin.CoinObjectId,
+		GasBudget:   gasBudget,
+		Recipient:   recipient,
+		Amount:      &amount,
+	})
+	require.NoError(t, err)
+	require.Len(t, built.Gas, 1)
+	require.Equal(t, coin.CoinObjectId, built.Gas[0].ObjectId)
+
+	signature, err := suisigntx.Sign(privateKeyHex, built.TxBytes)
+	require.NoError(t, err)
+	response, err := client.ExecuteTransactionBlock(ctx, built.TxBytes, suiapi.ExecuteOptions{
+		Signatures:  []string{signature},
+		Options:     &suiapi.TransactionBlockResponseOptions{ShowEffects: true, ShowBalanceChanges: true},
+		RequestType: suiapi.ExecuteTransactionRequestTypeWaitForLocalExecution,
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, response.Digest)
+	require.True(t, response.Succeeded())
+	require.Len(t, response.BalanceChanges, 2)
+
+	balance, err := client.GetBalance(ctx, recipient, "")
+	require.NoError(t, err)
+	require.Equal(t, "1000", balance.TotalBalance)
+}
+
+// TestClient_ExecutionFailure tests dry run and execute methods report failure status as ErrExecutionFailed
+//
+// TestClient_ExecutionFailure 测试模拟执行和执行方法将失败状态报告为 ErrExecutionFailed
+func TestClient_ExecutionFailure(t *testing.T) {
+	server := newSimulator(t)
+	coin := server.Mint(address, 100_000_000)
+	server.Mint(address, 50_000_000)
+	client := suiapi.NewClient(server.Client())
+	ctx := context.Background()
+
+	built, err := client.UnsafeSplitCoin(ctx, suiapi.UnsafeSplitCoinParams{
+		Signer:       address,
+		CoinObjectId: coin.CoinObjectId,
+		SplitAmounts: []string{"200000000"},
+		GasBudget:    gasBudget,
+	})
+	require.NoError(t, err)
+
+	_, err = client.DryRunTransactionBlock(ctx, built.TxBytes)
+	require.ErrorIs(t, err, suirpc.ErrExecutionFailed)
+	require.Contains(t, err.Error(), "InsufficientCoinBalance")
+
+	signature, err := suisigntx.Sign(privateKeyHex, built.TxBytes)
+	require.NoError(t, err)
+	_, err = client.ExecuteTransactionBlock(ctx, built.TxBytes, suiapi.ExecuteOptions{
+		Signatures: []string{signature},
+		Options:    &suiapi.TransactionBlockResponseOptions{ShowEffects: true},
+	})
+	var executionError *suirpc.ExecutionError
+	require.ErrorAs(t, err, &executionError)
+	require.NotEmpty(t, executionError.Digest)
+	require.Contains(t, executionError.Message, "InsufficientCoinBalance")
+}
@@ -0,0 +1,153 @@
+package suiapi_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-xlan/sui-go-guide/suiapi"
+	"github.com/go-xlan/sui-go-guide/suirpc"
+	"github.com/go-xlan/sui-go-guide/suirpctest"
+	"github.com/go-xlan/sui-go-guide/suisigntx"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	address       = "0x353a47f8fedca2d8cd1352222300f06b1f36789a55fffdecc6fe414ee1998969"
+	privateKeyHex = "0e51bb6e96264505b7c36c71d6a7f8053ed73b20f6f4476fb4f7877b8934ae6b"
+	recipient     = "0x207ed5c0ad36b96c730ed0f71e3c26a0ffb59bc20ab21d08067ca4c035d4d062"
+	gasBudget     = "10000000"
+)
+
+// newSimulator starts simulator closed at test end
+//
+// newSimulator 启动在测试结束时关闭的模拟器
+func newSimulator(t *testing.T) *suirpctest.Server {
+	server := suirpctest.NewServer()
+	t.Cleanup(server.Close)
+	return server
+}
+
+// transferSui sends SUI from address to recipient on simulator and returns transaction digest
+//
+// transferSui 在模拟器上从 address 向 recipient 发送 SUI 并返回交易摘要
+func transferSui(t *testing.T, client *suirpc.Client, coinObjectId string, amount string) string {
+	built, err := suirpc.Call[suiapi.TxBytesMessage](context.Background(), client, &suirpc.RpcRequest{
+		Jsonrpc: "2.0",
+		Method:  "unsafe_transferSui",
+		Params:  []any{address, coinObjectId, gasBudget, recipient, amount},
+	})
+	require.NoError(t, err)
+	return execute(t, client, built.Result.TxBytes)
+}
+
+// execute signs transaction bytes with address key, executes them and returns transaction digest
+//
+// execute 使用 address 的私钥签名交易字节，执行并返回交易摘要
+func execute(t *testing.T, client *suirpc.Client, txBytes string) string {
+	signatures, err := suisigntx.Sign(privateKeyHex, txBytes)
+	require.NoError(t, err)
+	res, err := suiapi.ExecuteTransactionBlock[suiapi.DigestMessage](context.Background(), client, txBytes, signatures)
+	require.NoError(t, err)
+	return res.Digest
+}
+
+// TestClient_Chain tests chain identifier, checkpoints and transaction blocks come back typed
+//
+// TestClient_Chain 测试链标识符、检查点和交易区块以类型化结果返回
+func TestClient_Chain(t *testing.T) {
+	server := newSimulator(t)
+	client := suiapi.NewClient(server.Client())
+	ctx := context.Background()
+
+	chainId, err := client.GetChainIdentifier(ctx)
+	require.NoError(t, err)
+	require.Equal(t, suirpctest.DefaultChainIdentifier, chainId)
+
+	coin := server.Mint(address, 100_000_000)
+	digest := transferSui(t, client.RpcClient(), coin.CoinObjectId, "30000000")
+
+	latest, err := client.GetLatestCheckpointSequenceNumber(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), latest)
+
+	total, err := client.GetTotalTransactionBlocks(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), total)
+
+	checkpoint, err := client.GetCheckpoint(ctx, "1")
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), checkpoint.SequenceNumber)
+	require.Equal(t, uint64(1), checkpoint.NetworkTotalTransactions)
+	require.Equal(t, []string{digest}, checkpoint.Transactions)
+	require.NotEmpty(t, checkpoint.TimestampMs)
+	require.NotNil(t, checkpoint.PreviousDigest)
+
+	page, err := client.GetCheckpoints(ctx, "", 1, true)
+	require.NoError(t, err)
+	require.Len(t, page.Data, 1)
+	require.True(t, page.HasNextPage)
+	require.Equal(t, "1", *page.NextCursor)
+
+	page, err = client.GetCheckpoints(ctx, *page.NextCursor, 10, true)
+	require.NoError(t, err)
+	require.Len(t, page.Data, 1)
+	require.False(t, page.HasNextPage)
+	require.Equal(t, *checkpoint.PreviousDigest, page.Data[0].Digest)
+
+	tx, err := client.GetTransactionBlock(ctx, digest, suiapi.ReadResponseOptions())
+	require.NoError(t, err)
+	require.Equal(t, digest, tx.Digest)
+	require.Equal(t, "success", tx.Effects.Status.Status)
+	require.Equal(t, uint64(1000000), tx.Effects.GasUsed.ComputationCost)
+	require.Equal(t, uint64(1), tx.Checkpoint)
+	require.Len(t, tx.BalanceChanges, 2)
+
+	txs, err := client.MultiGetTransactionBlocks(ctx, []string{digest}, &suiapi.TransactionBlockResponseOptions{ShowEffects: true})
+	require.NoError(t, err)
+	require.Len(t, txs, 1)
+	require.Equal(t, tx.TimestampMs, txs[0].TimestampMs)
+	require.NotNil(t, txs[0].Effects)
+	require.Nil(t, txs[0].BalanceChanges)
+}
+
+// TestClient_Coins tests balance, metadata and supply queries
+//
+// TestClient_Coins 测试余额、元数据和供应量查询
+func TestClient_Coins(t *testing.T) {
+	const coinType = "0x5d4b302506645c37ff133b98c4b50a5ae14841659738d6d733d59d0d217a93bf::coin::COIN"
+
+	server := newSimulator(t)
+	client := suiapi.NewClient(server.Client())
+	ctx := context.Background()
+
+	server.Mint(address, 100)
+	server.Mint(address, 200)
+	server.MintCoin(address, coinType, 5_000_000)
+	server.Mint(recipient, 1_000)
+
+	balance, err := client.GetBalance(ctx, address, "")
+	require.NoError(t, err)
+	require.Equal(t, "300", balance.TotalBalance)
+	require.Equal(t, uint(2), balance.CoinObjectCount)
+
+	balances, err := client.GetAllBalances(ctx, address)
+	require.NoError(t, err)
+	require.Len(t, balances, 2)
+
+	supply, err := client.GetTotalSupply(ctx, suirpctest.SuiCoinType)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1300), supply)
+
+	metadata, err := client.GetCoinMetadata(ctx, suirpctest.SuiCoinType)
+	require.NoError(t, err)
+	require.Equal(t, 9, metadata.Decimals)
+	require.Equal(t, "SUI", metadata.Symbol)
+
+	_, err = client.GetCoinMetadata(ctx, coinType)
+	require.ErrorIs(t, err, suirpc.ErrObjectNotFound)
+
+	server.SetCoinMetadata(coinType, 6, "USDC", "USD Coin")
+	metadata, err = client.GetCoinMetadata(ctx, coinType)
+	require.NoError(t, err)
+	require.Equal(t, 6, metadata.Decimals)
+}
@@ -0,0 +1,371 @@
+package suiapi
+
+import (
+	"cmp"
+	"context"
+	"math/big"
+	"math/bits"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/yyle88/erero"
+)
+
+// GetCoins returns page of coins of coin type owned by address, empty coin type means SUI
+//
+// GetCoins 返回地址拥有的某种代币的一页代币，代币类型为空表示 SUI
+func (c *Client) GetCoins(ctx context.Context, owner string, coinType string, cursor *string, limit int) (*Page[CoinType, string], error) {
+	return call[Page[CoinType, string]](ctx, c, "suix_getCoins", owner, optional(coinType), cursor, optional(limit))
+}
+
+// GetAllCoins returns page of coins of each type owned by address
+//
+// GetAllCoins 返回地址拥有的各类代币的一页代币
+func (c *Client) GetAllCoins(ctx context.Context, owner string, cursor *string, limit int) (*Page[CoinType, string], error) {
+	return call[Page[CoinType, string]](ctx, c, "suix_getAllCoins", owner, cursor, optional(limit))
+}
+
+// PaginateCoins walks each coin of coin type owned by address across pages
+//
+// PaginateCoins 跨分页遍历地址拥有的某种代币的每个代币
+func (c *Client) PaginateCoins(owner string, coinType string, options PageOptions[string]) *Paginator[CoinType, string] {
+	fetch := func(ctx context.Context, cursor *string, limit int, _ bool) (*Page[CoinType, string], error) {
+		return c.GetCoins(ctx, owner, coinType, cursor, limit)
+	}
+	return NewPaginator(fetch, coinCursor, options)
+}
+
+// PaginateAllCoins walks each coin of each type owned by address across pages
+//
+// PaginateAllCoins 跨分页遍历地址拥有的各类代币的每个代币
+func (c *Client) PaginateAllCoins(owner string, options PageOptions[string]) *Paginator[CoinType, string] {
+	fetch := func(ctx context.Context, cursor *string, limit int, _ bool) (*Page[CoinType, string], error) {
+		return c.GetAllCoins(ctx, owner, cursor, limit)
+	}
+	return NewPaginator(fetch, coinCursor, options)
+}
+
+// coinCursor returns cursor pointing at coin, coin queries page by coin object ID
+//
+// coinCursor 返回指向代币的游标，代币查询按代币对象 ID 分页
+func coinCursor(coin *CoinType) (string, bool) {
+	return coin.CoinObjectId, true
+}
+
+// GetCoinsOfType returns each coin of coin type owned by address across pages
+// Returns error matching ErrInvalidCoinType when coin type is malformed or node answers other types
+//
+// GetCoinsOfType 跨分页返回地址拥有的某种代币的每个代币
+// 代币类型格式错误或节点返回其他类型时返回匹配 ErrInvalidCoinType 的错误
+func (c *Client) GetCoinsOfType(ctx context.Context, owner string, coinType string) ([]*CoinType, error) {
+	if _, err := NormalizeCoinType(coinType); err != nil {
+		return nil, erero.Wro(err)
+	}
+	coins, err := c.PaginateCoins(owner, coinType, PageOptions[string]{PageSize: MaxPageSize}).All(ctx)
+	if err != nil {
+		return nil, erero.Wro(err)
+	}
+	if err := checkCoinTypes(coins, coinType); err != nil {
+		return nil, erero.Wro(err)
+	}
+	return coins, nil
+}
+
+// checkCoinTypes checks each coin has coin type, node answering other types means a bad query
+//
+// checkCoinTypes 检查每个代币都是该代币类型，节点返回其他类型说明查询有误
+func checkCoinTypes(coins []*CoinType, coinType string) error {
+	for _, coin := range coins {
+		if !SameCoinType(coin.CoinType, coinType) {
+			return erero.WithMessagef(ErrInvalidCoinType, "coin %s has type %s, want %s", coin.CoinObjectId, coin.CoinType, coinType)
+		}
+	}
+	return nil
+}
+
+// SelectCoins picks coins of coin type, largest first, until their sum covers amount
+// Excluded coin object IDs are skipped, such as gas coin already in use
+// Returns error matching ErrInsufficientBalance when coins cannot cover amount
+//
+// SelectCoins 按余额从大到小挑选某种代币，直到总和足以支付金额
+// 跳过被排除的代币对象 ID，例如已在使用的 gas 代币
+// 代币不足以支付金额时返回匹配 ErrInsufficientBalance 的错误
+func (c *Client) SelectCoins(ctx context.Context, owner string, coinType string, amount uint64, excluded ...string) ([]*CoinType, error) {
+	coins, err := c.GetCoinsOfType(ctx, owner, coinType)
+	if err != nil {
+		return nil, erero.Wro(err)
+	}
+	coins = slices.DeleteFunc(coins, func(coin *CoinType) bool {
+		return slices.Contains(excluded, coin.CoinObjectId)
+	})
+	balances, err := coinBalances(coins)
+	if err != nil {
+		return nil, erero.Wro(err)
+	}
+	slices.SortStableFunc(coins, func(a, b *CoinType) int {
+		return cmp.Compare(balances[b.CoinObjectId], balances[a.CoinObjectId])
+	})
+
+	var selected []*CoinType
+	var total uint64
+	for _, coin := range coins {
+		if total >= amount && len(selected) > 0 {
+			break
+		}
+		selected = append(selected, coin)
+		if total, err = addAmount(total, balances[coin.CoinObjectId]); err != nil {
+			return nil, erero.WithMessagef(err, "sum of %s coins owned by %s", coinType, owner)
+		}
+	}
+	if total < amount || len(selected) == 0 {
+		return nil, erero.WithMessagef(ErrInsufficientBalance, "owner %s has %d of %s in %d coins, want %d", owner, total, coinType, len(selected), amount)
+	}
+	return selected, nil
+}
+
+// GetCoinDecimals returns decimals of coin type from suix_getCoinMetadata, cached per client
+//
+// GetCoinDecimals 通过 suix_getCoinMetadata 返回代币类型的小数位数，按客户端缓存
+func (c *Client) GetCoinDecimals(ctx context.Context, coinType string) (int, error) {
+	normalized, err := NormalizeCoinType(coinType)
+	if err != nil {
+		return 0, erero.Wro(err)
+	}
+	c.mutex.Lock()
+	decimals, ok := c.decimals[normalized]
+	c.mutex.Unlock()
+	if ok {
+		return decimals, nil
+	}
+
+	metadata, err := c.GetCoinMetadata(ctx, coinType)
+	if err != nil {
+		return 0, erero.Wro(err)
+	}
+	c.mutex.Lock()
+	c.decimals[normalized] = metadata.Decimals
+	c.mutex.Unlock()
+	return metadata.Decimals, nil
+}
+
+// ParseCoinAmount converts display amount such as "12.5" to minimal units of coin type
+//
+// ParseCoinAmount 将 "12.5" 这样的显示金额转换为代币类型的最小单位
+func (c *Client) ParseCoinAmount(ctx context.Context, coinType string, text string) (uint64, error) {
+	decimals, err := c.GetCoinDecimals(ctx, coinType)
+	if err != nil {
+		return 0, erero.Wro(err)
+	}
+	return ParseUnits(text, decimals)
+}
+
+// FormatCoinAmount converts minimal units of coin type to display amount
+//
+// FormatCoinAmount 将代币类型的最小单位转换为显示金额
+func (c *Client) FormatCoinAmount(ctx context.Context, coinType string, amount uint64) (string, error) {
+	decimals, err := c.GetCoinDecimals(ctx, coinType)
+	if err != nil {
+		return "", erero.Wro(err)
+	}
+	return FormatUnits(amount, decimals), nil
+}
+
+// addAmount returns total plus amount, error matching ErrAmountOverflow when the sum exceeds u64
+//
+// addAmount 返回 total 加 amount 的和，超出 u64 时返回匹配 ErrAmountOverflow 的错误
+func addAmount(total uint64, amount uint64) (uint64, error) {
+	sum, carry := bits.Add64(total, amount, 0)
+	if carry != 0 {
+		return 0, erero.WithMessagef(ErrAmountOverflow, "%d + %d", total, amount)
+	}
+	return sum, nil
+}
+
+// ParseUnits converts decimal text with given decimals to minimal units
+// Rejects negative values, extra fraction digits and values above u64
+//
+// ParseUnits 将带给定小数位数的十进制文本转换为最小单位
+// 拒绝负数、多余的小数位和超过 u64 的值
+func ParseUnits(text string, decimals int) (uint64, error) {
+	whole, fraction, _ := strings.Cut(strings.TrimSpace(text), ".")
+	if len(fraction) > decimals {
+		return 0, erero.Errorf("amount %q has more than %d decimals", text, decimals)
+	}
+	digits := whole + fraction + strings.Repeat("0", decimals-len(fraction))
+	if whole == "" || strings.ContainsFunc(digits, func(char rune) bool { return char < '0' || char > '9' }) {
+		return 0, erero.Errorf("amount %q is not decimal number", text)
+	}
+	value, ok := new(big.Int).SetString(digits, 10)
+	if !ok || !value.IsUint64() {
+		return 0, erero.Errorf("amount %q does not fit u64", text)
+	}
+	return value.Uint64(), nil
+}
+
+// FormatUnits converts minimal units to decimal text with given decimals, trailing zeros dropped
+//
+// FormatUnits 将最小单位转换为带给定小数位数的十进制文本，去掉末尾的零
+func FormatUnits(amount uint64, decimals int) string {
+	digits := strconv.FormatUint(amount, 10)
+	if decimals <= 0 {
+		return digits
+	}
+	if len(digits) <= decimals {
+		digits = strings.Repeat("0", decimals-len(digits)+1) + digits
+	}
+	whole, fraction := digits[:len(digits)-decimals], strings.TrimRight(digits[len(digits)-decimals:], "0")
+	if fraction == "" {
+		return whole
+	}
+	return whole + "." + fraction
+}
+
+// BuildTransferCoin builds transaction sending amount of coin type to recipient
+// SUI goes through unsafe_paySui and pays gas from the selected coins
+// Other coin types go through unsafe_pay and pay gas from a separate SUI coin
+//
+// BuildTransferCoin 构建向接收方发送某种代币金额的交易
+// SUI 通过 unsafe_paySui 发送，并由所选代币支付 gas
+// 其他代币类型通过 unsafe_pay 发送，由单独的 SUI 代币支付 gas
+func (c *Client) BuildTransferCoin(ctx context.Context, owner string, coinType string, recipient string, amount uint64, gasBudget uint64) (*TxBytesMessage, error) {
+	if IsSuiCoinType(coinType) {
+		total, err := addAmount(amount, gasBudget)
+		if err != nil {
+			return nil, erero.WithMessagef(err, "amount plus gas budget")
+		}
+		coins, err := c.SelectCoins(ctx, owner, coinType, total)
+		if err != nil {
+			return nil, erero.Wro(err)
+		}
+		return call[TxBytesMessage](ctx, c, "unsafe_paySui", owner, coinObjectIds(coins), []string{recipient}, []string{strconv.FormatUint(amount, 10)}, strconv.FormatUint(gasBudget, 10))
+	}
+	coins, err := c.SelectCoins(ctx, owner, coinType, amount)
+	if err != nil {
+		return nil, erero.Wro(err)
+	}
+	return call[TxBytesMessage](ctx, c, "unsafe_pay", owner, coinObjectIds(coins), []string{recipient}, []string{strconv.FormatUint(amount, 10)}, nil, strconv.FormatUint(gasBudget, 10))
+}
+
+// BuildSplitCoin builds transaction splitting amounts off the largest coin of coin type
+// Gas comes from another SUI coin picked by the node
+//
+// BuildSplitCoin 构建从某种代币中余额最大的代币拆分出各金额的交易
+// gas 由节点选择的另一个 SUI 代币支付
+func (c *Client) BuildSplitCoin(ctx context.Context, owner string, coinType string, amounts []uint64, gasBudget uint64) (*TxBytesMessage, error) {
+	var total uint64
+	splitAmounts := make([]string, 0, len(amounts))
+	for _, amount := range amounts {
+		var err error
+		if total, err = addAmount(total, amount); err != nil {
+			return nil, erero.WithMessagef(err, "sum of split amounts")
+		}
+		splitAmounts = append(splitAmounts, strconv.FormatUint(amount, 10))
+	}
+	coins, err := c.SelectCoins(ctx, owner, coinType, total)
+	if err != nil {
+		return nil, erero.Wro(err)
+	}
+	if len(coins) > 1 {
+		return nil, erero.WithMessagef(ErrInsufficientBalance, "no single coin of %s holds %d, merge coins first", coinType, total)
+	}
+	return call[TxBytesMessage](ctx, c, "unsafe_splitCoin", owner, coins[0].CoinObjectId, splitAmounts, nil, strconv.FormatUint(gasBudget, 10))
+}
+
+// BuildMergeCoins builds one transaction merging coins of coin type owned by address
+// SUI goes through unsafe_payAllSui back to owner, which merges each coin and pays gas from the merged coin
+// Other coin types go through unsafe_mergeCoins, which merges the second largest coin into the largest one
+// Use MergeCoins to repeat the merge until one coin of the type is left
+//
+// BuildMergeCoins 构建一笔合并地址拥有的某种代币的交易
+// SUI 通过 unsafe_payAllSui 发回给所有者，合并全部代币并由合并后的代币支付 gas
+// 其他代币类型通过 unsafe_mergeCoins 将余额第二大的代币合并到最大的代币中
+// 使用 MergeCoins 重复合并，直到该类型只剩一个代币
+func (c *Client) BuildMergeCoins(ctx context.Context, owner string, coinType string, gasBudget uint64) (*TxBytesMessage, error) {
+	coins, err := c.GetCoinsOfType(ctx, owner, coinType)
+	if err != nil {
+		return nil, erero.Wro(err)
+	}
+	if len(coins) < 2 {
+		return nil, erero.Errorf("owner %s has %d coins of %s, nothing to merge", owner, len(coins), coinType)
+	}
+	return c.buildMerge(ctx, owner, coinType, coins, gasBudget)
+}
+
+// MergeCoins merges each coin of coin type owned by address into one coin
+// Builds one merge after another with BuildMergeCoins and hands each to execute, which signs and executes it
+// Non-SUI types take one transaction per merged coin, since each merge bumps the version of the largest coin
+//
+// MergeCoins 将地址拥有的某种代币的全部代币合并为一个代币
+// 使用 BuildMergeCoins 依次构建合并交易，并交给 execute 签名和执行
+// 非 SUI 类型每合并一个代币需要一笔交易，因为每次合并都会提升最大代币的版本
+func (c *Client) MergeCoins(ctx context.Context, owner string, coinType string, gasBudget uint64, execute func(ctx context.Context, txBytes string) error) error {
+	previous := 0
+	for {
+		coins, err := c.GetCoinsOfType(ctx, owner, coinType)
+		if err != nil {
+			return erero.Wro(err)
+		}
+		if len(coins) < 2 {
+			return nil
+		}
+		// Coin count must drop after each merge, otherwise execute did not run the transaction
+		// 每次合并后代币数量必须减少，否则说明 execute 没有执行该交易
+		if previous > 0 && len(coins) >= previous {
+			return erero.Errorf("owner %s still has %d coins of %s after merge", owner, len(coins), coinType)
+		}
+		previous = len(coins)
+		built, err := c.buildMerge(ctx, owner, coinType, coins, gasBudget)
+		if err != nil {
+			return erero.Wro(err)
+		}
+		if err := execute(ctx, built.TxBytes); err != nil {
+			return erero.Wro(err)
+		}
+	}
+}
+
+// buildMerge builds merge transaction over two or more coins of coin type
+//
+// buildMerge 基于某种代币的两个或更多代币构建合并交易
+func (c *Client) buildMerge(ctx context.Context, owner string, coinType string, coins []*CoinType, gasBudget uint64) (*TxBytesMessage, error) {
+	balances, err := coinBalances(coins)
+	if err != nil {
+		return nil, erero.Wro(err)
+	}
+	slices.SortStableFunc(coins, func(a, b *CoinType) int {
+		return cmp.Compare(balances[b.CoinObjectId], balances[a.CoinObjectId])
+	})
+	budget := strconv.FormatUint(gasBudget, 10)
+
+	if IsSuiCoinType(coinType) {
+		return call[TxBytesMessage](ctx, c, "unsafe_payAllSui", owner, coinObjectIds(coins), owner, budget)
+	}
+	return call[TxBytesMessage](ctx, c, "unsafe_mergeCoins", owner, coins[0].CoinObjectId, coins[1].CoinObjectId, nil, budget)
+}
+
+// coinBalances parses coin balances by coin object ID
+//
+// coinBalances 按代币对象 ID 解析代币余额
+func coinBalances(coins []*CoinType) (map[string]uint64, error) {
+	balances := make(map[string]uint64, len(coins))
+	for _, coin := range coins {
+		balance, err := strconv.ParseUint(coin.Balance, 10, 64)
+		if err != nil {
+			return nil, erero.WithMessagef(err, "coin %s balance %q", coin.CoinObjectId, coin.Balance)
+		}
+		balances[coin.CoinObjectId] = balance
+	}
+	return balances, nil
+}
+
+// coinObjectIds returns object IDs of coins in order
+//
+// coinObjectIds 按顺序返回代币的对象 ID
+func coinObjectIds(coins []*CoinType) []string {
+	objectIds := make([]string, 0, len(coins))
+	for _, coin := range coins {
+		objectIds = append(objectIds, coin.CoinObjectId)
+	}
+	return objectIds
+}
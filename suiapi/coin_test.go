@@ -0,0 +1,186 @@
+package suiapi_test
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/go-xlan/sui-go-guide/suiapi"
+	"github.com/go-xlan/sui-go-guide/suirpc"
+	"github.com/go-xlan/sui-go-guide/suirpctest"
+	"github.com/stretchr/testify/require"
+)
+
+const usdcCoinType = "0xdba34672e30cb065b1f93e3ab55318768fd6fef66c15942c9f7cb846e2f900e7::usdc::USDC"
+
+// TestNormalizeCoinType tests coin type validation and address padding
+//
+// TestNormalizeCoinType 测试代币类型校验和地址补齐
+func TestNormalizeCoinType(t *testing.T) {
+	normalized, err := suiapi.NormalizeCoinType("0x2::sui::SUI")
+	require.NoError(t, err)
+	require.Equal(t, "0x0000000000000000000000000000000000000000000000000000000000000002::sui::SUI", normalized)
+
+	normalized, err = suiapi.NormalizeCoinType("0x2::coin::Coin<0x2::sui::SUI>")
+	require.NoError(t, err)
+	require.Equal(t, "0x0000000000000000000000000000000000000000000000000000000000000002::coin::Coin<0x0000000000000000000000000000000000000000000000000000000000000002::sui::SUI>", normalized)
+
+	_, err = suiapi.NormalizeCoinType("0x2::table::Table<address, vector<u8>>")
+	require.NoError(t, err)
+
+	for _, coinType := range []string{"", "SUI", "0x2::sui", "0xZZ::sui::SUI", "0x2::sui::SUI<", "0x2::sui::SUI extra", "0x2::coin::Coin<float>"} {
+		_, err := suiapi.NormalizeCoinType(coinType)
+		require.ErrorIs(t, err, suiapi.ErrInvalidCoinType, coinType)
+	}
+
+	require.True(t, suiapi.IsSuiCoinType("0x0000000000000000000000000000000000000000000000000000000000000002::sui::SUI"))
+	require.False(t, suiapi.SameCoinType(usdcCoinType, suiapi.SuiCoinType))
+}
+
+// TestParseUnits tests display amounts convert to and from minimal units
+//
+// TestParseUnits 测试显示金额与最小单位之间的转换
+func TestParseUnits(t *testing.T) {
+	amount, err := suiapi.ParseUnits("12.5", 6)
+	require.NoError(t, err)
+	require.Equal(t, uint64(12_500_000), amount)
+
+	amount, err = suiapi.ParseUnits("3", 9)
+	require.NoError(t, err)
+	require.Equal(t, uint64(3_000_000_000), amount)
+
+	for _, text := range []string{"", "-1", "1.2345678", "1e3", "99999999999999999999"} {
+		_, err := suiapi.ParseUnits(text, 6)
+		require.Error(t, err, text)
+	}
+
+	require.Equal(t, "12.5", suiapi.FormatUnits(12_500_000, 6))
+	require.Equal(t, "0.000001", suiapi.FormatUnits(1, 6))
+	require.Equal(t, "3", suiapi.FormatUnits(3_000_000_000, 9))
+	require.Equal(t, "42", suiapi.FormatUnits(42, 0))
+}
+
+// TestClient_CoinHelpers tests select, transfer, merge and split on non-SUI coin type
+//
+// TestClient_CoinHelpers 测试非 SUI 代币类型的挑选、转账、合并和拆分
+func TestClient_CoinHelpers(t *testing.T) {
+	server := newSimulator(t)
+	server.SetCoinMetadata(usdcCoinType, 6, "USDC", "USD Coin")
+	client := suiapi.NewClient(server.Client())
+	ctx := context.Background()
+
+	server.Mint(address, 100_000_000)
+	server.MintCoin(address, usdcCoinType, 3_000_000)
+	server.MintCoin(address, usdcCoinType, 5_000_000)
+	server.MintCoin(address, usdcCoinType, 1_000_000)
+
+	coins, err := client.GetCoinsOfType(ctx, address, usdcCoinType)
+	require.NoError(t, err)
+	require.Len(t, coins, 3)
+
+	_, err = client.GetCoinsOfType(ctx, address, "usdc")
+	require.ErrorIs(t, err, suiapi.ErrInvalidCoinType)
+
+	selected, err := client.SelectCoins(ctx, address, usdcCoinType, 7_000_000)
+	require.NoError(t, err)
+	require.Len(t, selected, 2)
+	require.Equal(t, "5000000", selected[0].Balance)
+
+	_, err = client.SelectCoins(ctx, address, usdcCoinType, 10_000_000)
+	require.ErrorIs(t, err, suiapi.ErrInsufficientBalance)
+
+	amount, err := client.ParseCoinAmount(ctx, usdcCoinType, "7.5")
+	require.NoError(t, err)
+	require.Equal(t, uint64(7_500_000), amount)
+	display, err := client.FormatCoinAmount(ctx, usdcCoinType, 1_250_000)
+	require.NoError(t, err)
+	require.Equal(t, "1.25", display)
+
+	built, err := client.BuildTransferCoin(ctx, address, usdcCoinType, recipient, amount, 10_000_000)
+	require.NoError(t, err)
+	execute(t, client.RpcClient(), built.TxBytes)
+	require.Equal(t, uint64(7_500_000), server.Balance(recipient, usdcCoinType))
+	require.Equal(t, uint64(1_500_000), server.Balance(address, usdcCoinType))
+	require.Equal(t, uint64(100_000_000-suirpctest.DefaultGasCost), server.Balance(address, suirpctest.SuiCoinType))
+
+	server.MintCoin(address, usdcCoinType, 2_000_000)
+	built, err = client.BuildMergeCoins(ctx, address, usdcCoinType, 10_000_000)
+	require.NoError(t, err)
+	execute(t, client.RpcClient(), built.TxBytes)
+	// One build merges the second largest coin into the largest one
+	// 一次构建将余额第二大的代币合并到最大的代币中
+	coins, err = client.GetCoinsOfType(ctx, address, usdcCoinType)
+	require.NoError(t, err)
+	require.Len(t, coins, 2)
+	require.ElementsMatch(t, []string{"3000000", "500000"}, []string{coins[0].Balance, coins[1].Balance})
+
+	// Merge that never gets executed stops the chain instead of looping
+	// 从未被执行的合并会终止链式合并而不是无限循环
+	err = client.MergeCoins(ctx, address, usdcCoinType, 10_000_000, func(ctx context.Context, txBytes string) error {
+		return nil
+	})
+	require.Error(t, err)
+
+	var merges int
+	require.NoError(t, client.MergeCoins(ctx, address, usdcCoinType, 10_000_000, func(ctx context.Context, txBytes string) error {
+		merges++
+		execute(t, client.RpcClient(), txBytes)
+		return nil
+	}))
+	require.Equal(t, 1, merges)
+	coins, err = client.GetCoinsOfType(ctx, address, usdcCoinType)
+	require.NoError(t, err)
+	require.Len(t, coins, 1)
+	require.Equal(t, "3500000", coins[0].Balance)
+
+	built, err = client.BuildSplitCoin(ctx, address, usdcCoinType, []uint64{1_000_000, 500_000}, 10_000_000)
+	require.NoError(t, err)
+	execute(t, client.RpcClient(), built.TxBytes)
+	coins, err = client.GetCoinsOfType(ctx, address, usdcCoinType)
+	require.NoError(t, err)
+	require.Len(t, coins, 3)
+	require.Equal(t, uint64(3_500_000), server.Balance(address, usdcCoinType))
+}
+
+// TestClient_CoinOverflow tests sums above u64 come back as errors instead of wrapping around
+//
+// TestClient_CoinOverflow 测试超过 u64 的求和以错误返回而不是回绕
+func TestClient_CoinOverflow(t *testing.T) {
+	server := newSimulator(t)
+	client := suiapi.NewClient(server.Client())
+	ctx := context.Background()
+
+	server.Mint(address, 100_000_000)
+	server.MintCoin(address, usdcCoinType, math.MaxUint64/2+1)
+	server.MintCoin(address, usdcCoinType, math.MaxUint64/2+1)
+
+	_, err := client.SelectCoins(ctx, address, usdcCoinType, math.MaxUint64)
+	require.ErrorIs(t, err, suiapi.ErrAmountOverflow)
+
+	_, err = client.BuildSplitCoin(ctx, address, usdcCoinType, []uint64{math.MaxUint64, 1}, 10_000_000)
+	require.ErrorIs(t, err, suiapi.ErrAmountOverflow)
+
+	_, err = client.BuildTransferCoin(ctx, address, suiapi.SuiCoinType, recipient, math.MaxUint64, 10_000_000)
+	require.ErrorIs(t, err, suiapi.ErrAmountOverflow)
+}
+
+// TestClient_TransferSui tests SUI transfer pays gas from selected coins
+//
+// TestClient_TransferSui 测试 SUI 转账由所选代币支付 gas
+func TestClient_TransferSui(t *testing.T) {
+	server := newSimulator(t)
+	client := suiapi.NewClient(server.Client())
+	ctx := context.Background()
+
+	server.Mint(address, 40_000_000)
+	server.Mint(address, 30_000_000)
+
+	built, err := client.BuildTransferCoin(ctx, address, suiapi.SuiCoinType, recipient, 50_000_000, 10_000_000)
+	require.NoError(t, err)
+	execute(t, client.RpcClient(), built.TxBytes)
+	require.Equal(t, uint64(50_000_000), server.Balance(recipient, suirpctest.SuiCoinType))
+	require.Equal(t, uint64(20_000_000-suirpctest.DefaultGasCost), server.Balance(address, suirpctest.SuiCoinType))
+
+	_, err = client.GetCoinDecimals(ctx, usdcCoinType)
+	require.ErrorIs(t, err, suirpc.ErrObjectNotFound)
+}
@@ -0,0 +1,204 @@
+package suiapi
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/yyle88/erero"
+)
+
+// SuiCoinType is the coin type of native SUI coins
+//
+// SuiCoinType 是原生 SUI 代币的代币类型
+const SuiCoinType = "0x2::sui::SUI"
+
+var (
+	ErrInvalidCoinType     = errors.New("invalid coin type")    // Coin type is not address::module::Name // 代币类型不是 address::module::Name 形式
+	ErrInsufficientBalance = errors.New("insufficient balance") // Coins of the type cannot cover the amount // 该类型的代币不足以支付金额
+	ErrAmountOverflow      = errors.New("amount overflows u64") // Sum of amounts does not fit u64 // 金额之和超出 u64 范围
+)
+
+// NormalizeCoinType validates coin type and returns it with full-length lowercase addresses
+// Accepts type params such as 0x2::coin::Coin<0x2::sui::SUI> and vector<u8>
+//
+// NormalizeCoinType 校验代币类型并返回地址为完整长度小写形式的类型
+// 接受类型参数，例如 0x2::coin::Coin<0x2::sui::SUI> 和 vector<u8>
+func NormalizeCoinType(coinType string) (string, error) {
+	normalized, err := parseStructTag(coinType)
+	if err != nil {
+		return "", erero.WithMessagef(ErrInvalidCoinType, "coin type %q: %v", coinType, err)
+	}
+	return normalized, nil
+}
+
+// SameCoinType checks if two coin types name the same type, ignoring address padding and case
+//
+// SameCoinType 检查两个代币类型是否为同一类型，忽略地址补零和大小写差异
+func SameCoinType(a string, b string) bool {
+	normalizedA, errA := NormalizeCoinType(a)
+	normalizedB, errB := NormalizeCoinType(b)
+	return errA == nil && errB == nil && normalizedA == normalizedB
+}
+
+// IsSuiCoinType checks if coin type is native SUI
+//
+// IsSuiCoinType 检查代币类型是否为原生 SUI
+func IsSuiCoinType(coinType string) bool {
+	return SameCoinType(coinType, SuiCoinType)
+}
+
+// parseStructTag reads whole text as struct tag and returns it with full-length lowercase addresses
+//
+// parseStructTag 将整个文本读取为结构体标签并返回地址为完整长度小写形式的标签
+func parseStructTag(text string) (string, error) {
+	parser := &typeTagParser{text: strings.TrimSpace(text)}
+	normalized, err := parser.structTag()
+	if err != nil {
+		return "", err
+	}
+	if parser.pos != len(parser.text) {
+		return "", fmt.Errorf("unexpected %q at %d", parser.text[parser.pos:], parser.pos)
+	}
+	return normalized, nil
+}
+
+// typeTagParser reads Move type tags, writing normalized form while reading
+//
+// typeTagParser 读取 Move 类型标签，读取时输出规范化形式
+type typeTagParser struct {
+	text string // Type tag text // 类型标签文本
+	pos  int    // Read position // 读取位置
+}
+
+// typeTag reads primitive, vector or struct type tag
+//
+// typeTag 读取基本类型、vector 或结构体类型标签
+func (p *typeTagParser) typeTag() (string, error) {
+	if !strings.HasPrefix(p.text[p.pos:], "0x") {
+		name := p.identifier()
+		switch name {
+		case "bool", "u8", "u16", "u32", "u64", "u128", "u256", "address", "signer":
+			return name, nil
+		case "vector":
+			params, err := p.typeParams()
+			if err != nil {
+				return "", err
+			}
+			if len(params) != 1 {
+				return "", fmt.Errorf("vector takes one type param, got %d", len(params))
+			}
+			return "vector<" + params[0] + ">", nil
+		default:
+			return "", fmt.Errorf("unknown type %q at %d", name, p.pos)
+		}
+	}
+	return p.structTag()
+}
+
+// structTag reads address::module::Name with optional type params
+//
+// structTag 读取带可选类型参数的 address::module::Name
+func (p *typeTagParser) structTag() (string, error) {
+	address, err := p.address()
+	if err != nil {
+		return "", err
+	}
+	parts := []string{address}
+	for range 2 {
+		if !strings.HasPrefix(p.text[p.pos:], "::") {
+			return "", fmt.Errorf("want :: at %d", p.pos)
+		}
+		p.pos += 2
+		name := p.identifier()
+		if name == "" {
+			return "", fmt.Errorf("want identifier at %d", p.pos)
+		}
+		parts = append(parts, name)
+	}
+	tag := strings.Join(parts, "::")
+	if p.pos < len(p.text) && p.text[p.pos] == '<' {
+		params, err := p.typeParams()
+		if err != nil {
+			return "", err
+		}
+		tag += "<" + strings.Join(params, ", ") + ">"
+	}
+	return tag, nil
+}
+
+// typeParams reads <T1, T2, ...>
+//
+// typeParams 读取 <T1, T2, ...>
+func (p *typeTagParser) typeParams() ([]string, error) {
+	if p.pos >= len(p.text) || p.text[p.pos] != '<' {
+		return nil, fmt.Errorf("want < at %d", p.pos)
+	}
+	p.pos++
+	var params []string
+	for {
+		p.skipSpaces()
+		param, err := p.typeTag()
+		if err != nil {
+			return nil, err
+		}
+		params = append(params, param)
+		p.skipSpaces()
+		if p.pos >= len(p.text) {
+			return nil, fmt.Errorf("want > at %d", p.pos)
+		}
+		switch p.text[p.pos] {
+		case ',':
+			p.pos++
+		case '>':
+			p.pos++
+			return params, nil
+		default:
+			return nil, fmt.Errorf("unexpected %q at %d", p.text[p.pos], p.pos)
+		}
+	}
+}
+
+// address reads 0x-prefixed hex address and pads it to 32 bytes
+//
+// address 读取 0x 前缀的十六进制地址并补齐到 32 字节
+func (p *typeTagParser) address() (string, error) {
+	if !strings.HasPrefix(p.text[p.pos:], "0x") {
+		return "", fmt.Errorf("want 0x address at %d", p.pos)
+	}
+	start := p.pos + 2
+	end := start
+	for end < len(p.text) && strings.ContainsRune("0123456789abcdefABCDEF", rune(p.text[end])) {
+		end++
+	}
+	if end == start || end-start > 64 {
+		return "", fmt.Errorf("address at %d needs 1 to 64 hex digits", p.pos)
+	}
+	p.pos = end
+	return "0x" + strings.Repeat("0", 64-(end-start)) + strings.ToLower(p.text[start:end]), nil
+}
+
+// identifier reads Move identifier, empty when none
+//
+// identifier 读取 Move 标识符，没有时为空
+func (p *typeTagParser) identifier() string {
+	start := p.pos
+	for p.pos < len(p.text) {
+		char := p.text[p.pos]
+		letter := char == '_' || (char >= 'a' && char <= 'z') || (char >= 'A' && char <= 'Z')
+		if !letter && (p.pos == start || char < '0' || char > '9') {
+			break
+		}
+		p.pos++
+	}
+	return p.text[start:p.pos]
+}
+
+// skipSpaces moves past spaces
+//
+// skipSpaces 跳过空格
+func (p *typeTagParser) skipSpaces() {
+	for p.pos < len(p.text) && p.text[p.pos] == ' ' {
+		p.pos++
+	}
+}
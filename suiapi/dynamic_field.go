@@ -0,0 +1,296 @@
+package suiapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/yyle88/erero"
+)
+
+const (
+	DynamicFieldKindField  = "DynamicField"  // Value kept inside 0x2::dynamic_field::Field, such as Table and Bag // 值保存在 0x2::dynamic_field::Field 中，例如 Table 和 Bag
+	DynamicFieldKindObject = "DynamicObject" // Value is standalone object, such as ObjectTable and ObjectBag // 值为独立对象，例如 ObjectTable 和 ObjectBag
+)
+
+var (
+	moveStdAddress      = "0x" + strings.Repeat("0", 63) + "1" // Address of Move standard library // Move 标准库地址
+	suiFrameworkAddress = "0x" + strings.Repeat("0", 63) + "2" // Address of Sui framework // Sui 框架地址
+)
+
+// DynamicFieldName represents dynamic field name as Move type and JSON value
+//
+// DynamicFieldName 表示以 Move 类型和 JSON 值给出的动态字段名
+type DynamicFieldName struct {
+	Type  string          `json:"type"`  // Move type of the name // 名称的 Move 类型
+	Value json.RawMessage `json:"value"` // Name value in JSON // JSON 形式的名称值
+}
+
+// NewDynamicFieldName builds dynamic field name from Go value and Move type tag
+// bool, u8, u16 and u32 take Go bool and integers, u64, u128 and u256 also take *big.Int and decimal strings
+// address and 0x2::object::ID take hex strings, 0x1::string::String and 0x1::ascii::String take strings
+// vector<T> takes Go slices, vector<u8> also takes strings as bytes
+// Other struct types take value already in fullnode JSON form
+//
+// NewDynamicFieldName 根据 Go 值和 Move 类型标签构建动态字段名
+// bool、u8、u16 和 u32 接收 Go 布尔值和整数，u64、u128 和 u256 还接收 *big.Int 和十进制字符串
+// address 和 0x2::object::ID 接收十六进制字符串，0x1::string::String 和 0x1::ascii::String 接收字符串
+// vector<T> 接收 Go 切片，vector<u8> 还接收作为字节的字符串
+// 其他结构体类型接收已是全节点 JSON 形式的值
+func NewDynamicFieldName(typeTag string, value any) (*DynamicFieldName, error) {
+	parser := &typeTagParser{text: strings.TrimSpace(typeTag)}
+	normalized, err := parser.typeTag()
+	if err == nil && parser.pos != len(parser.text) {
+		err = fmt.Errorf("unexpected %q at %d", parser.text[parser.pos:], parser.pos)
+	}
+	if err != nil {
+		return nil, erero.Errorf("type tag %q: %v", typeTag, err)
+	}
+	nameValue, err := moveNameValue(normalized, value)
+	if err != nil {
+		return nil, erero.WithMessagef(err, "name of type %s", normalized)
+	}
+	data, err := json.Marshal(nameValue)
+	if err != nil {
+		return nil, erero.Wro(err)
+	}
+	return &DynamicFieldName{Type: normalized, Value: data}, nil
+}
+
+// moveNameValue converts Go value into fullnode JSON form of normalized Move type
+//
+// moveNameValue 将 Go 值转换为规范化 Move 类型的全节点 JSON 形式
+func moveNameValue(typeTag string, value any) (any, error) {
+	switch typeTag {
+	case "bool":
+		flag, ok := value.(bool)
+		if !ok {
+			return nil, erero.Errorf("want bool, got %T", value)
+		}
+		return flag, nil
+	case "u8", "u16", "u32":
+		number, err := moveInteger(typeTag, value)
+		if err != nil {
+			return nil, erero.Wro(err)
+		}
+		return json.Number(number.String()), nil
+	case "u64", "u128", "u256":
+		number, err := moveInteger(typeTag, value)
+		if err != nil {
+			return nil, erero.Wro(err)
+		}
+		return number.String(), nil
+	case "address", suiFrameworkAddress + "::object::ID":
+		text, ok := value.(string)
+		if !ok {
+			return nil, erero.Errorf("want hex string, got %T", value)
+		}
+		parser := &typeTagParser{text: text}
+		address, err := parser.address()
+		if err == nil && parser.pos != len(text) {
+			err = fmt.Errorf("unexpected %q at %d", text[parser.pos:], parser.pos)
+		}
+		if err != nil {
+			return nil, erero.Errorf("address %q: %v", text, err)
+		}
+		return address, nil
+	case moveStdAddress + "::string::String", moveStdAddress + "::ascii::String":
+		text, ok := value.(string)
+		if !ok {
+			return nil, erero.Errorf("want string, got %T", value)
+		}
+		return text, nil
+	}
+	if element, ok := strings.CutPrefix(typeTag, "vector<"); ok {
+		element = strings.TrimSuffix(element, ">")
+		if text, ok := value.(string); ok && element == "u8" {
+			value = []byte(text)
+		}
+		items := reflect.ValueOf(value)
+		if items.Kind() != reflect.Slice && items.Kind() != reflect.Array {
+			return nil, erero.Errorf("want slice, got %T", value)
+		}
+		result := make([]any, 0, items.Len())
+		for idx := range items.Len() {
+			item, err := moveNameValue(element, items.Index(idx).Interface())
+			if err != nil {
+				return nil, erero.WithMessagef(err, "item %d", idx)
+			}
+			result = append(result, item)
+		}
+		return result, nil
+	}
+	return value, nil
+}
+
+// moveInteger reads Go integer, *big.Int or decimal string and checks it fits unsigned Move integer type
+//
+// moveInteger 读取 Go 整数、*big.Int 或十进制字符串，并检查其是否在无符号 Move 整数类型范围内
+func moveInteger(typeTag string, value any) (*big.Int, error) {
+	number := new(big.Int)
+	switch value := value.(type) {
+	case *big.Int:
+		number.Set(value)
+	case string:
+		if _, ok := number.SetString(value, 10); !ok {
+			return nil, erero.Errorf("invalid integer %q", value)
+		}
+	default:
+		item := reflect.ValueOf(value)
+		switch item.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			number.SetInt64(item.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			number.SetUint64(item.Uint())
+		default:
+			return nil, erero.Errorf("want integer, got %T", value)
+		}
+	}
+	var bits uint
+	if _, err := fmt.Sscanf(typeTag, "u%d", &bits); err != nil {
+		return nil, erero.Wro(err)
+	}
+	if number.Sign() < 0 || number.BitLen() > int(bits) {
+		return nil, erero.Errorf("%s out of %s range", number, typeTag)
+	}
+	return number, nil
+}
+
+// DynamicFieldInfo represents dynamic field of parent object
+//
+// DynamicFieldInfo 表示父对象的动态字段
+type DynamicFieldInfo struct {
+	Name       DynamicFieldName `json:"name"`       // Field name // 字段名
+	BcsName    string           `json:"bcsName"`    // Field name in base58 BCS // base58 BCS 形式的字段名
+	Type       string           `json:"type"`       // DynamicFieldKindField or DynamicFieldKindObject // DynamicFieldKindField 或 DynamicFieldKindObject
+	ObjectType string           `json:"objectType"` // Move type of field value // 字段值的 Move 类型
+	ObjectId   string           `json:"objectId"`   // Field object ID // 字段对象 ID
+	Version    uint64           `json:"version"`    // Field object version // 字段对象版本
+	Digest     string           `json:"digest"`     // Field object digest // 字段对象摘要
+}
+
+// GetDynamicFields returns page of dynamic fields of parent object
+//
+// GetDynamicFields 返回父对象的一页动态字段
+func (c *Client) GetDynamicFields(ctx context.Context, parentObjectId string, cursor *string, limit int) (*Page[DynamicFieldInfo, string], error) {
+	return call[Page[DynamicFieldInfo, string]](ctx, c, "suix_getDynamicFields", parentObjectId, cursor, optional(limit))
+}
+
+// PaginateDynamicFields walks each dynamic field of parent object across pages
+//
+// PaginateDynamicFields 跨分页遍历父对象的每个动态字段
+func (c *Client) PaginateDynamicFields(parentObjectId string, options PageOptions[string]) *Paginator[DynamicFieldInfo, string] {
+	fetch := func(ctx context.Context, cursor *string, limit int, _ bool) (*Page[DynamicFieldInfo, string], error) {
+		return c.GetDynamicFields(ctx, parentObjectId, cursor, limit)
+	}
+	return NewPaginator(fetch, func(field *DynamicFieldInfo) (string, bool) { return field.ObjectId, true }, options)
+}
+
+// GetDynamicFieldObject returns object of dynamic field with given name under parent object
+// Fields added with dynamic_field return the 0x2::dynamic_field::Field wrapper, use DecodeDynamicFieldValue to read its value
+// Fields added with dynamic_object_field return the value object itself
+// Missing field comes back as response Error with code dynamicFieldNotFound
+//
+// GetDynamicFieldObject 返回父对象下给定名称的动态字段对象
+// 通过 dynamic_field 添加的字段返回 0x2::dynamic_field::Field 包装对象，使用 DecodeDynamicFieldValue 读取其值
+// 通过 dynamic_object_field 添加的字段直接返回值对象
+// 字段不存在时以 code 为 dynamicFieldNotFound 的响应 Error 返回
+func (c *Client) GetDynamicFieldObject(ctx context.Context, parentObjectId string, name *DynamicFieldName) (*SuiObjectResponse, error) {
+	return call[SuiObjectResponse](ctx, c, "suix_getDynamicFieldObject", parentObjectId, name)
+}
+
+// DecodeDynamicFieldValue decodes value of dynamic field object into V
+// Unwraps value of 0x2::dynamic_field::Field, decodes other objects whole
+//
+// DecodeDynamicFieldValue 将动态字段对象的值解码为 V
+// 展开 0x2::dynamic_field::Field 的值，其他对象整体解码
+func DecodeDynamicFieldValue[V any](object *ObjectData) (*V, error) {
+	if object == nil || object.Content == nil {
+		return nil, erero.New("object has no content, ask for it with ShowContent")
+	}
+	parser := &typeTagParser{text: object.Content.Type}
+	objectType, err := parser.structTag()
+	if err != nil {
+		return nil, erero.Errorf("object %s type %q: %v", object.ObjectId, object.Content.Type, err)
+	}
+	if !strings.HasPrefix(objectType, suiFrameworkAddress+"::dynamic_field::Field<") {
+		return DecodeMoveObject[V](object)
+	}
+	field, err := DecodeMoveObject[struct {
+		Value V `json:"value"`
+	}](object)
+	if err != nil {
+		return nil, erero.Wro(err)
+	}
+	return &field.Value, nil
+}
+
+// GetTableValue returns value stored under key in Table, Bag, ObjectTable or ObjectBag
+// tableId is the UID of the table, returns error matching suirpc.ErrObjectNotFound when key is missing
+//
+// GetTableValue 返回 Table、Bag、ObjectTable 或 ObjectBag 中键对应的值
+// tableId 为表的 UID，键不存在时返回匹配 suirpc.ErrObjectNotFound 的错误
+func GetTableValue[V any](ctx context.Context, c *Client, tableId string, key *DynamicFieldName) (*V, error) {
+	response, err := c.GetDynamicFieldObject(ctx, tableId, key)
+	if err != nil {
+		return nil, erero.Wro(err)
+	}
+	object, err := response.Object()
+	if err != nil {
+		return nil, erero.Wro(err)
+	}
+	return DecodeDynamicFieldValue[V](object)
+}
+
+// ReadTable walks each entry of Table<K, V> or ObjectTable<K, V> into Go map
+// tableId is the UID of the table, keys decode from field names and values from field objects
+// Field objects come in batches of multiGetObjectsLimit
+//
+// ReadTable 将 Table<K, V> 或 ObjectTable<K, V> 的每个条目读入 Go 映射
+// tableId 为表的 UID，键从字段名解码，值从字段对象解码
+// 字段对象按 multiGetObjectsLimit 分批获取
+func ReadTable[K comparable, V any](ctx context.Context, c *Client, tableId string) (map[K]V, error) {
+	fields, err := c.PaginateDynamicFields(tableId, PageOptions[string]{}).All(ctx)
+	if err != nil {
+		return nil, erero.Wro(err)
+	}
+	table := make(map[K]V, len(fields))
+	for start := 0; start < len(fields); start += multiGetObjectsLimit {
+		batch := fields[start:min(start+multiGetObjectsLimit, len(fields))]
+		objectIds := make([]string, 0, len(batch))
+		for _, field := range batch {
+			objectIds = append(objectIds, field.ObjectId)
+		}
+		responses, err := c.MultiGetObjects(ctx, objectIds, &ObjectDataOptions{ShowType: true, ShowContent: true})
+		if err != nil {
+			return nil, erero.Wro(err)
+		}
+		if len(responses) != len(batch) {
+			return nil, erero.Errorf("asked %d objects, got %d", len(batch), len(responses))
+		}
+		for idx, field := range batch {
+			key, err := DecodeMoveValue[K](field.Name.Value)
+			if err != nil {
+				return nil, erero.WithMessagef(err, "key of field %s", field.ObjectId)
+			}
+			object, err := responses[idx].Object()
+			if err != nil {
+				return nil, erero.Wro(err)
+			}
+			value, err := DecodeDynamicFieldValue[V](object)
+			if err != nil {
+				return nil, erero.WithMessagef(err, "value of field %s", field.ObjectId)
+			}
+			table[*key] = *value
+		}
+	}
+	return table, nil
+}
+
+// multiGetObjectsLimit is the most object IDs fullnodes take in one sui_multiGetObjects call
+//
+// multiGetObjectsLimit 是全节点在一次 sui_multiGetObjects 调用中接受的最多对象 ID 数量
+const multiGetObjectsLimit = 50
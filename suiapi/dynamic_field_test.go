@@ -0,0 +1,157 @@
+package suiapi_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/go-xlan/sui-go-guide/suiapi"
+	"github.com/go-xlan/sui-go-guide/suirpc"
+	"github.com/stretchr/testify/require"
+)
+
+// TestNewDynamicFieldName tests names build in fullnode form from Go values
+//
+// TestNewDynamicFieldName 测试根据 Go 值构建全节点形式的名称
+func TestNewDynamicFieldName(t *testing.T) {
+	u128Max, _ := new(big.Int).SetString("340282366920938463463374607431768211455", 10)
+
+	cases := []struct {
+		typeTag string
+		value   any
+		wantTag string
+		want    string
+	}{
+		{"bool", true, "bool", `true`},
+		{"u8", 7, "u8", `7`},
+		{"u32", uint32(4294967295), "u32", `4294967295`},
+		{"u64", uint64(42), "u64", `"42"`},
+		{"u128", u128Max, "u128", `"340282366920938463463374607431768211455"`},
+		{"u256", "12345678901234567890123456789", "u256", `"12345678901234567890123456789"`},
+		{"address", "0x2", "address", `"0x0000000000000000000000000000000000000000000000000000000000000002"`},
+		{"0x2::object::ID", "0xABC", "0x0000000000000000000000000000000000000000000000000000000000000002::object::ID", `"0x0000000000000000000000000000000000000000000000000000000000000abc"`},
+		{"0x1::string::String", "alice", "0x0000000000000000000000000000000000000000000000000000000000000001::string::String", `"alice"`},
+		{"vector<u8>", "hi", "vector<u8>", `[104,105]`},
+		{"vector<u8>", []byte{1, 2}, "vector<u8>", `[1,2]`},
+		{"vector<u64>", []uint64{1, 2}, "vector<u64>", `["1","2"]`},
+		{"0xabc::registry::Key", map[string]any{"owner": address}, "0x0000000000000000000000000000000000000000000000000000000000000abc::registry::Key", `{"owner":"` + address + `"}`},
+	}
+	for _, tc := range cases {
+		name, err := suiapi.NewDynamicFieldName(tc.typeTag, tc.value)
+		require.NoError(t, err, tc.typeTag)
+		require.Equal(t, tc.wantTag, name.Type)
+		require.JSONEq(t, tc.want, string(name.Value), tc.typeTag)
+	}
+
+	for _, tc := range []struct {
+		typeTag string
+		value   any
+	}{
+		{"u8", 256},
+		{"u64", -1},
+		{"u64", "0x10"},
+		{"u16", 1.5},
+		{"bool", "true"},
+		{"address", "0xzz"},
+		{"vector<u16>", []int{1, 70000}},
+		{"map<u8>", 1},
+	} {
+		_, err := suiapi.NewDynamicFieldName(tc.typeTag, tc.value)
+		require.Error(t, err, tc.typeTag)
+	}
+}
+
+// Record represents value kept in registry table
+//
+// Record 表示保存在注册表中的值
+type Record struct {
+	Name  string `json:"name"`
+	Score uint64 `json:"score"`
+}
+
+// Badge represents object kept in object table
+//
+// Badge 表示保存在对象表中的对象
+type Badge struct {
+	Id    suiapi.UID `json:"id"`
+	Level uint8      `json:"level"`
+}
+
+// TestClient_DynamicFields tests listing, lookup and table walk against the simulator
+//
+// TestClient_DynamicFields 针对模拟器测试列出、查找和遍历表
+func TestClient_DynamicFields(t *testing.T) {
+	const (
+		tableId  = "0x00000000000000000000000000000000000000000000000000000000007ab1e1"
+		objectId = "0x00000000000000000000000000000000000000000000000000000000007ab1e2"
+	)
+
+	server := newSimulator(t)
+	client := suiapi.NewClient(server.Client())
+	ctx := context.Background()
+
+	users := map[string]Record{address: {Name: "alice", Score: 10}, recipient: {Name: "bob", Score: 20}}
+	for owner, record := range users {
+		server.AddDynamicField(tableId, "address", owner, "0xabc::registry::Record", map[string]any{
+			"type":   "0xabc::registry::Record",
+			"fields": map[string]any{"name": record.Name, "score": big.NewInt(int64(record.Score)).String()},
+		})
+	}
+	var badgeIds []string
+	for level := range 3 {
+		badgeIds = append(badgeIds, server.AddDynamicObjectField(objectId, "u64", big.NewInt(int64(level)).String(), "0xabc::badge::Badge", map[string]any{"level": level + 1}))
+	}
+
+	page, err := client.GetDynamicFields(ctx, objectId, nil, 2)
+	require.NoError(t, err)
+	require.Len(t, page.Data, 2)
+	require.True(t, page.HasNextPage)
+	require.Equal(t, suiapi.DynamicFieldKindObject, page.Data[0].Type)
+	require.Equal(t, "0xabc::badge::Badge", page.Data[0].ObjectType)
+
+	fields, err := client.PaginateDynamicFields(tableId, suiapi.PageOptions[string]{PageSize: 1}).All(ctx)
+	require.NoError(t, err)
+	require.Len(t, fields, 2)
+	require.Equal(t, suiapi.DynamicFieldKindField, fields[0].Type)
+
+	// Name built from Go value finds the stored field and its Field wrapper
+	// 根据 Go 值构建的名称可以找到保存的字段及其 Field 包装对象
+	key, err := suiapi.NewDynamicFieldName("address", address)
+	require.NoError(t, err)
+	response, err := client.GetDynamicFieldObject(ctx, tableId, key)
+	require.NoError(t, err)
+	object, err := response.Object()
+	require.NoError(t, err)
+	require.Equal(t, tableId, object.Owner.ObjectOwner)
+	record, err := suiapi.DecodeDynamicFieldValue[Record](object)
+	require.NoError(t, err)
+	require.Equal(t, users[address], *record)
+
+	badgeKey, err := suiapi.NewDynamicFieldName("u64", 1)
+	require.NoError(t, err)
+	badge, err := suiapi.GetTableValue[Badge](ctx, client, objectId, badgeKey)
+	require.NoError(t, err)
+	require.Equal(t, Badge{Id: suiapi.UID{Id: badgeIds[1]}, Level: 2}, *badge)
+
+	missing, err := suiapi.NewDynamicFieldName("address", "0x1")
+	require.NoError(t, err)
+	response, err = client.GetDynamicFieldObject(ctx, tableId, missing)
+	require.NoError(t, err)
+	require.Equal(t, "dynamicFieldNotFound", response.Error.Code)
+	_, err = suiapi.GetTableValue[Record](ctx, client, tableId, missing)
+	require.ErrorIs(t, err, suirpc.ErrObjectNotFound)
+
+	table, err := suiapi.ReadTable[string, Record](ctx, client, tableId)
+	require.NoError(t, err)
+	require.Equal(t, users, table)
+
+	badges, err := suiapi.ReadTable[uint64, Badge](ctx, client, objectId)
+	require.NoError(t, err)
+	require.Len(t, badges, 3)
+	require.Equal(t, uint8(3), badges[2].Level)
+	require.Equal(t, badgeIds[2], badges[2].Id.Id)
+
+	empty, err := suiapi.ReadTable[string, Record](ctx, client, "0x0000000000000000000000000000000000000000000000000000000000000bad")
+	require.NoError(t, err)
+	require.Empty(t, empty)
+}
@@ -0,0 +1,58 @@
+package suiapi
+
+// TransactionEffects represents outcome of transaction execution
+// Object lists come back empty when the transaction did not touch objects that way
+//
+// TransactionEffects 表示交易执行的结果
+// 交易未以相应方式触及对象时对象列表为空
+type TransactionEffects struct {
+	MessageVersion       string             `json:"messageVersion"`                 // Effects format version such as v1 // 效果格式版本，例如 v1
+	Status               ExecutionStatus    `json:"status"`                         // Success or failure // 成功或失败
+	ExecutedEpoch        uint64             `json:"executedEpoch,string"`           // Epoch of execution // 执行所在纪元
+	GasUsed              *GasCostSummary    `json:"gasUsed"`                        // Gas charged // 收取的 gas
+	ModifiedAtVersions   []*ModifiedVersion `json:"modifiedAtVersions,omitempty"`   // Input versions of changed objects // 变更对象的输入版本
+	SharedObjects        []*ObjectRef       `json:"sharedObjects,omitempty"`        // Shared objects used as input // 用作输入的共享对象
+	TransactionDigest    string             `json:"transactionDigest"`              // Transaction digest // 交易摘要
+	Created              []*OwnedObjectRef  `json:"created,omitempty"`              // Objects created // 创建的对象
+	Mutated              []*OwnedObjectRef  `json:"mutated,omitempty"`              // Objects mutated // 修改的对象
+	Unwrapped            []*OwnedObjectRef  `json:"unwrapped,omitempty"`            // Objects taken out of other objects // 从其他对象中取出的对象
+	Deleted              []*ObjectRef       `json:"deleted,omitempty"`              // Objects deleted // 删除的对象
+	UnwrappedThenDeleted []*ObjectRef       `json:"unwrappedThenDeleted,omitempty"` // Objects taken out and then deleted // 取出后又删除的对象
+	Wrapped              []*ObjectRef       `json:"wrapped,omitempty"`              // Objects put into other objects // 放入其他对象的对象
+	GasObject            *OwnedObjectRef    `json:"gasObject,omitempty"`            // Gas coin after charging // 扣费后的 gas 代币
+	EventsDigest         string             `json:"eventsDigest,omitempty"`         // Digest of emitted events // 发出事件的摘要
+	Dependencies         []string           `json:"dependencies,omitempty"`         // Digests of transactions this one depends on // 所依赖交易的摘要
+}
+
+// ExecutionStatus represents effects status, Error explains failure
+//
+// ExecutionStatus 表示效果状态，Error 说明失败原因
+type ExecutionStatus struct {
+	Status string `json:"status"`          // "success" or "failure" // "success" 或 "failure"
+	Error  string `json:"error,omitempty"` // Failure reason // 失败原因
+}
+
+// ModifiedVersion represents version of object before transaction changed it
+//
+// ModifiedVersion 表示交易变更对象之前的版本
+type ModifiedVersion struct {
+	ObjectId       string `json:"objectId"`       // Object ID // 对象 ID
+	SequenceNumber string `json:"sequenceNumber"` // Version before change // 变更前的版本
+}
+
+// ObjectRef represents object reference, version is JSON number in effects and gas data
+//
+// ObjectRef 表示对象引用，在效果和 gas 数据中版本为 JSON 数字
+type ObjectRef struct {
+	ObjectId string `json:"objectId"` // Object ID // 对象 ID
+	Version  uint64 `json:"version"`  // Object version // 对象版本
+	Digest   string `json:"digest"`   // Object digest // 对象摘要
+}
+
+// OwnedObjectRef represents object reference along with its owner after transaction
+//
+// OwnedObjectRef 表示对象引用及交易后的所有者
+type OwnedObjectRef struct {
+	Owner     ObjectOwner `json:"owner"`     // Owner after transaction // 交易后的所有者
+	Reference ObjectRef   `json:"reference"` // Object reference // 对象引用
+}
@@ -0,0 +1,364 @@
+package suiapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+
+	"github.com/yyle88/erero"
+)
+
+// SuiEvent represents event emitted by transaction
+// ParsedJson holds Move struct fields, Bcs holds the same event as BCS bytes
+//
+// SuiEvent 表示交易发出的事件
+// ParsedJson 保存 Move 结构体字段，Bcs 以 BCS 字节保存同一事件
+type SuiEvent struct {
+	Id                EventId         `json:"id"`                           // Event ID // 事件 ID
+	PackageId         string          `json:"packageId"`                    // Package emitting the event // 发出事件的包
+	TransactionModule string          `json:"transactionModule"`            // Module called by transaction // 交易调用的模块
+	Sender            string          `json:"sender"`                       // Transaction sender // 交易发送方
+	Type              string          `json:"type"`                         // Move event type // Move 事件类型
+	ParsedJson        json.RawMessage `json:"parsedJson,omitempty"`         // Event fields in JSON // JSON 形式的事件字段
+	BcsEncoding       string          `json:"bcsEncoding,omitempty"`        // Encoding of Bcs, base64 or base58 // Bcs 的编码，base64 或 base58
+	Bcs               string          `json:"bcs,omitempty"`                // BCS bytes of event // 事件的 BCS 字节
+	TimestampMs       uint64          `json:"timestampMs,string,omitempty"` // Checkpoint time in milliseconds // 检查点时间，单位毫秒
+}
+
+// EventId represents event ID, also used as event query cursor
+//
+// EventId 表示事件 ID，同时用作事件查询游标
+type EventId struct {
+	TxDigest string `json:"txDigest"` // Transaction digest // 交易摘要
+	EventSeq string `json:"eventSeq"` // Event index in transaction // 事件在交易中的序号
+}
+
+// EventFilter represents suix_queryEvents filter, exactly one variant is set
+// Build it with EventSender, EventMoveEventType and the other Event* functions,
+// combine filters with EventAll, EventAny, EventAnd, EventOr and EventNot
+//
+// EventFilter 表示 suix_queryEvents 的过滤条件，恰好设置一个变体
+// 使用 EventSender、EventMoveEventType 等 Event* 函数构建，
+// 使用 EventAll、EventAny、EventAnd、EventOr 和 EventNot 组合过滤条件
+type EventFilter struct {
+	Sender          string           // Events sent by address // 由地址发送的事件
+	Transaction     string           // Events of transaction digest // 交易摘要的事件
+	Package         string           // Events emitted by package // 由包发出的事件
+	MoveModule      *MoveModuleRef   // Events of transactions calling module // 调用模块的交易的事件
+	MoveEventType   string           // Events of Move struct type // Move 结构体类型的事件
+	MoveEventModule *MoveModuleRef   // Events with type defined in module // 类型定义在模块中的事件
+	TimeRange       *EventTimeWindow // Events with checkpoint time in range // 检查点时间在范围内的事件
+	All             []*EventFilter   // Events matching each filter, empty matches each event // 匹配每个过滤条件的事件，为空时匹配每个事件
+	Any             []*EventFilter   // Events matching some filter // 匹配任一过滤条件的事件
+	And             []*EventFilter   // Events matching both of two filters // 同时匹配两个过滤条件的事件
+	Or              []*EventFilter   // Events matching either of two filters // 匹配两个过滤条件之一的事件
+	Not             *EventFilter     // Events not matching filter // 不匹配过滤条件的事件
+}
+
+// MoveModuleRef represents Move module by package ID and module name
+//
+// MoveModuleRef 表示以包 ID 和模块名给出的 Move 模块
+type MoveModuleRef struct {
+	Package string `json:"package"` // Package ID // 包 ID
+	Module  string `json:"module"`  // Module name // 模块名
+}
+
+// EventTimeWindow represents checkpoint time range, start inclusive and end exclusive
+//
+// EventTimeWindow 表示检查点时间范围，包含起点不包含终点
+type EventTimeWindow struct {
+	StartTime uint64 `json:"startTime,string"` // Start time in milliseconds // 起始时间，单位毫秒
+	EndTime   uint64 `json:"endTime,string"`   // End time in milliseconds // 结束时间，单位毫秒
+}
+
+// EventSender returns filter matching events sent by address
+//
+// EventSender 返回匹配由地址发送的事件的过滤条件
+func EventSender(address string) *EventFilter {
+	return &EventFilter{Sender: address}
+}
+
+// EventTransaction returns filter matching events of transaction
+//
+// EventTransaction 返回匹配交易事件的过滤条件
+func EventTransaction(digest string) *EventFilter {
+	return &EventFilter{Transaction: digest}
+}
+
+// EventPackage returns filter matching events emitted by package
+//
+// EventPackage 返回匹配由包发出的事件的过滤条件
+func EventPackage(packageId string) *EventFilter {
+	return &EventFilter{Package: packageId}
+}
+
+// EventMoveModule returns filter matching events of transactions calling module
+//
+// EventMoveModule 返回匹配调用模块的交易事件的过滤条件
+func EventMoveModule(packageId string, module string) *EventFilter {
+	return &EventFilter{MoveModule: &MoveModuleRef{Package: packageId, Module: module}}
+}
+
+// EventMoveEventType returns filter matching events of Move struct type such as 0xabc::math::Added
+//
+// EventMoveEventType 返回匹配 Move 结构体类型事件的过滤条件，例如 0xabc::math::Added
+func EventMoveEventType(eventType string) *EventFilter {
+	return &EventFilter{MoveEventType: eventType}
+}
+
+// EventMoveEventModule returns filter matching events with type defined in module
+//
+// EventMoveEventModule 返回匹配类型定义在模块中的事件的过滤条件
+func EventMoveEventModule(packageId string, module string) *EventFilter {
+	return &EventFilter{MoveEventModule: &MoveModuleRef{Package: packageId, Module: module}}
+}
+
+// EventTimeRange returns filter matching events with checkpoint time in [startMs, endMs)
+//
+// EventTimeRange 返回匹配检查点时间位于 [startMs, endMs) 的事件的过滤条件
+func EventTimeRange(startMs uint64, endMs uint64) *EventFilter {
+	return &EventFilter{TimeRange: &EventTimeWindow{StartTime: startMs, EndTime: endMs}}
+}
+
+// EventAll returns filter matching events matching each given filter, no filters match each event
+//
+// EventAll 返回匹配同时满足每个给定过滤条件的事件的过滤条件，没有过滤条件时匹配每个事件
+func EventAll(filters ...*EventFilter) *EventFilter {
+	return &EventFilter{All: append([]*EventFilter{}, filters...)}
+}
+
+// EventAny returns filter matching events matching some given filter
+//
+// EventAny 返回匹配满足任一给定过滤条件的事件的过滤条件
+func EventAny(filters ...*EventFilter) *EventFilter {
+	return &EventFilter{Any: append([]*EventFilter{}, filters...)}
+}
+
+// EventNot returns filter matching events not matching given filter
+//
+// EventNot 返回匹配不满足给定过滤条件的事件的过滤条件
+func EventNot(filter *EventFilter) *EventFilter {
+	return &EventFilter{Not: filter}
+}
+
+// EventAnd returns filter matching events matching both filters
+//
+// EventAnd 返回匹配同时满足两个过滤条件的事件的过滤条件
+func EventAnd(filter *EventFilter, other *EventFilter) *EventFilter {
+	return &EventFilter{And: []*EventFilter{filter, other}}
+}
+
+// EventOr returns filter matching events matching either filter
+//
+// EventOr 返回匹配满足任一过滤条件的事件的过滤条件
+func EventOr(filter *EventFilter, other *EventFilter) *EventFilter {
+	return &EventFilter{Or: []*EventFilter{filter, other}}
+}
+
+// MarshalJSON encodes filter in fullnode form such as {"Sender": "0x.."} or {"And": [.., ..]}
+//
+// MarshalJSON 以全节点形式编码过滤条件，例如 {"Sender": "0x.."} 或 {"And": [.., ..]}
+func (filter EventFilter) MarshalJSON() ([]byte, error) {
+	variants := map[string]any{}
+	if filter.Sender != "" {
+		variants["Sender"] = filter.Sender
+	}
+	if filter.Transaction != "" {
+		variants["Transaction"] = filter.Transaction
+	}
+	if filter.Package != "" {
+		variants["Package"] = filter.Package
+	}
+	if filter.MoveModule != nil {
+		variants["MoveModule"] = filter.MoveModule
+	}
+	if filter.MoveEventType != "" {
+		variants["MoveEventType"] = filter.MoveEventType
+	}
+	if filter.MoveEventModule != nil {
+		variants["MoveEventModule"] = filter.MoveEventModule
+	}
+	if filter.TimeRange != nil {
+		variants["TimeRange"] = filter.TimeRange
+	}
+	if filter.All != nil {
+		variants["All"] = filter.All
+	}
+	if filter.Any != nil {
+		variants["Any"] = filter.Any
+	}
+	if filter.And != nil {
+		variants["And"] = filter.And
+	}
+	if filter.Or != nil {
+		variants["Or"] = filter.Or
+	}
+	if filter.Not != nil {
+		variants["Not"] = filter.Not
+	}
+	if len(variants) != 1 {
+		return nil, erero.Errorf("event filter needs exactly one variant, got %d", len(variants))
+	}
+	if (filter.And != nil && len(filter.And) != 2) || (filter.Or != nil && len(filter.Or) != 2) {
+		return nil, erero.New("event filter And and Or take two filters")
+	}
+	return json.Marshal(variants)
+}
+
+// UnmarshalJSON decodes filter from fullnode form
+//
+// UnmarshalJSON 从全节点形式解码过滤条件
+func (filter *EventFilter) UnmarshalJSON(data []byte) error {
+	*filter = EventFilter{}
+	var variants map[string]json.RawMessage
+	if err := json.Unmarshal(data, &variants); err != nil {
+		return erero.Wro(err)
+	}
+	if len(variants) != 1 {
+		return erero.Errorf("event filter needs exactly one variant, got %s", data)
+	}
+	for name, value := range variants {
+		var target any
+		switch name {
+		case "Sender":
+			target = &filter.Sender
+		case "Transaction":
+			target = &filter.Transaction
+		case "Package":
+			target = &filter.Package
+		case "MoveModule":
+			target = &filter.MoveModule
+		case "MoveEventType":
+			target = &filter.MoveEventType
+		case "MoveEventModule":
+			target = &filter.MoveEventModule
+		case "TimeRange":
+			target = &filter.TimeRange
+		case "All":
+			target = &filter.All
+		case "Any":
+			target = &filter.Any
+		case "And":
+			target = &filter.And
+		case "Or":
+			target = &filter.Or
+		case "Not":
+			target = &filter.Not
+		default:
+			return erero.Errorf("unknown event filter %q", name)
+		}
+		if err := json.Unmarshal(value, target); err != nil {
+			return erero.WithMessagef(err, "event filter %s", name)
+		}
+	}
+	return nil
+}
+
+// QueryEvents returns page of events matching filter after cursor
+//
+// QueryEvents 返回游标之后与过滤条件匹配的一页事件
+func (c *Client) QueryEvents(ctx context.Context, filter *EventFilter, cursor *EventId, limit int, descending bool) (*Page[SuiEvent, EventId], error) {
+	if filter == nil {
+		return nil, erero.New("event filter is required, use EventAll() to match each event")
+	}
+	return call[Page[SuiEvent, EventId]](ctx, c, "suix_queryEvents", filter, cursor, optional(limit), descending)
+}
+
+// PaginateEvents walks each event matching filter across pages
+//
+// PaginateEvents 跨分页遍历与过滤条件匹配的每个事件
+func (c *Client) PaginateEvents(filter *EventFilter, options PageOptions[EventId]) *Paginator[SuiEvent, EventId] {
+	fetch := func(ctx context.Context, cursor *EventId, limit int, descending bool) (*Page[SuiEvent, EventId], error) {
+		return c.QueryEvents(ctx, filter, cursor, limit, descending)
+	}
+	return NewPaginator(fetch, func(event *SuiEvent) (EventId, bool) { return event.Id, true }, options)
+}
+
+// ErrUnknownEventType means event type has no Go struct registered
+//
+// ErrUnknownEventType 表示事件类型没有注册 Go 结构体
+var ErrUnknownEventType = errors.New("unknown event type")
+
+// EventRegistry represents Go structs registered by Move event type, decoding parsedJson of events
+// Event types compare regardless of address padding and case
+// Registering type without type params covers each instance of generic event
+//
+// EventRegistry 表示按 Move 事件类型注册的 Go 结构体，用于解码事件的 parsedJson
+// 事件类型比较时忽略地址补零和大小写差异
+// 注册不带类型参数的类型可以覆盖泛型事件的每个实例
+type EventRegistry struct {
+	mutex    sync.RWMutex                                  // Guards decoders // 保护 decoders
+	decoders map[string]func(json.RawMessage) (any, error) // Decoders by normalized event type // 按规范化事件类型索引的解码器
+}
+
+// NewEventRegistry creates empty event registry
+//
+// NewEventRegistry 创建空的事件注册表
+func NewEventRegistry() *EventRegistry {
+	return &EventRegistry{decoders: map[string]func(json.RawMessage) (any, error){}}
+}
+
+// RegisterEvent registers T as Go struct of Move event type, Decode then returns *T
+// Fields map like DecodeMoveValue
+//
+// RegisterEvent 将 T 注册为 Move 事件类型的 Go 结构体，之后 Decode 返回 *T
+// 字段映射方式与 DecodeMoveValue 相同
+func RegisterEvent[T any](registry *EventRegistry, eventType string) error {
+	normalized, err := normalizeEventType(eventType)
+	if err != nil {
+		return erero.Wro(err)
+	}
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	registry.decoders[normalized] = func(data json.RawMessage) (any, error) {
+		return DecodeMoveValue[T](data)
+	}
+	return nil
+}
+
+// Decode decodes parsedJson of event into Go struct registered by its type
+// Returns error matching ErrUnknownEventType when no struct is registered
+//
+// Decode 将事件的 parsedJson 解码为按其类型注册的 Go 结构体
+// 没有注册结构体时返回匹配 ErrUnknownEventType 的错误
+func (registry *EventRegistry) Decode(event *SuiEvent) (any, error) {
+	normalized, err := normalizeEventType(event.Type)
+	if err != nil {
+		return nil, erero.Wro(err)
+	}
+	registry.mutex.RLock()
+	decode, ok := registry.decoders[normalized]
+	if !ok {
+		base, _, _ := strings.Cut(normalized, "<")
+		decode, ok = registry.decoders[base]
+	}
+	registry.mutex.RUnlock()
+	if !ok {
+		return nil, erero.WithMessagef(ErrUnknownEventType, "event %s", event.Type)
+	}
+	value, err := decode(event.ParsedJson)
+	if err != nil {
+		return nil, erero.WithMessagef(err, "event %s", event.Type)
+	}
+	return value, nil
+}
+
+// DecodeEvent decodes parsedJson of event into T without registry
+//
+// DecodeEvent 不经注册表直接将事件的 parsedJson 解码为 T
+func DecodeEvent[T any](event *SuiEvent) (*T, error) {
+	return DecodeMoveValue[T](event.ParsedJson)
+}
+
+// normalizeEventType returns event type with full-length lowercase addresses
+//
+// normalizeEventType 返回地址为完整长度小写形式的事件类型
+func normalizeEventType(eventType string) (string, error) {
+	normalized, err := parseStructTag(eventType)
+	if err != nil {
+		return "", erero.Errorf("event type %q: %v", eventType, err)
+	}
+	return normalized, nil
+}
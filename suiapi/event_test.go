@@ -0,0 +1,150 @@
+package suiapi_test
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/go-xlan/sui-go-guide/suiapi"
+	"github.com/go-xlan/sui-go-guide/suirpctest"
+	"github.com/stretchr/testify/require"
+)
+
+// mathPackage is the package of internal/moves math demos
+//
+// mathPackage 是 internal/moves 中 math 示例的包
+const mathPackage = "0x46ed36947b4912ab1d584d9dc5b578f7eb5e271f4ad39541dd189fefad1c34a2"
+
+// AddedEvent represents math::Added event
+//
+// AddedEvent 表示 math::Added 事件
+type AddedEvent struct {
+	A   uint64 `json:"a"`
+	B   uint64 `json:"b"`
+	Sum uint64 `json:"sum"`
+}
+
+// MaxEvent represents math::Max event
+//
+// MaxEvent 表示 math::Max 事件
+type MaxEvent struct {
+	Values []uint64 `json:"values"`
+	Max    *big.Int `json:"max"`
+}
+
+// TestEventFilter_JSON tests filters encode in fullnode form and decode back
+//
+// TestEventFilter_JSON 测试过滤条件以全节点形式编码并能解码回来
+func TestEventFilter_JSON(t *testing.T) {
+	filter := suiapi.EventAll(
+		suiapi.EventOr(suiapi.EventSender(address), suiapi.EventTransaction("8Ykk")),
+		suiapi.EventAny(suiapi.EventPackage(mathPackage), suiapi.EventMoveModule(mathPackage, "math")),
+		suiapi.EventAnd(suiapi.EventMoveEventType(mathPackage+"::math::Added"), suiapi.EventMoveEventModule(mathPackage, "math")),
+		suiapi.EventNot(suiapi.EventTimeRange(1_700_000_000_000, 1_700_000_005_000)),
+	)
+	data, err := json.Marshal(filter)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"All": [
+		{"Or": [{"Sender": "`+address+`"}, {"Transaction": "8Ykk"}]},
+		{"Any": [{"Package": "`+mathPackage+`"}, {"MoveModule": {"package": "`+mathPackage+`", "module": "math"}}]},
+		{"And": [{"MoveEventType": "`+mathPackage+`::math::Added"}, {"MoveEventModule": {"package": "`+mathPackage+`", "module": "math"}}]},
+		{"Not": {"TimeRange": {"startTime": "1700000000000", "endTime": "1700000005000"}}}
+	]}`, string(data))
+
+	var decoded suiapi.EventFilter
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	require.Equal(t, *filter, decoded)
+
+	data, err = json.Marshal(suiapi.EventAll())
+	require.NoError(t, err)
+	require.JSONEq(t, `{"All": []}`, string(data))
+
+	_, err = json.Marshal(&suiapi.EventFilter{})
+	require.Error(t, err)
+	_, err = json.Marshal(&suiapi.EventFilter{Sender: address, Package: mathPackage})
+	require.Error(t, err)
+	_, err = json.Marshal(&suiapi.EventFilter{And: []*suiapi.EventFilter{suiapi.EventSender(address)}})
+	require.Error(t, err)
+	require.Error(t, json.Unmarshal([]byte(`{"MoveEventField": {"path": "/a", "value": 1}}`), &decoded))
+}
+
+// TestClient_QueryEvents tests filtered queries, paging in both orders and registered decoding
+//
+// TestClient_QueryEvents 测试过滤查询、双向分页和按注册类型解码
+func TestClient_QueryEvents(t *testing.T) {
+	server := newSimulator(t)
+	client := suiapi.NewClient(server.Client())
+	ctx := context.Background()
+
+	first := server.EmitEvents(address,
+		suirpctest.Event{Type: mathPackage + "::math::Added", ParsedJson: map[string]any{"a": "1", "b": "2", "sum": "3"}},
+		suirpctest.Event{Type: mathPackage + "::math::Max", ParsedJson: map[string]any{"values": []string{"7", "9"}, "max": "9"}},
+	)
+	second := server.EmitEvents(recipient,
+		suirpctest.Event{Type: mathPackage + "::math::Added", ParsedJson: map[string]any{"a": "10", "b": "20", "sum": "30"}},
+	)
+	server.EmitEvents(address,
+		suirpctest.Event{Type: "0x2::coin::CoinEvent<0x2::sui::SUI>", ParsedJson: map[string]any{"amount": "5"}},
+	)
+
+	page, err := client.QueryEvents(ctx, suiapi.EventSender(address), nil, 2, false)
+	require.NoError(t, err)
+	require.Len(t, page.Data, 2)
+	require.True(t, page.HasNextPage)
+	require.Equal(t, suiapi.EventId{TxDigest: first, EventSeq: "0"}, page.Data[0].Id)
+	require.Equal(t, "math", page.Data[0].TransactionModule)
+	require.NotZero(t, page.Data[0].TimestampMs)
+
+	events, err := client.PaginateEvents(suiapi.EventMoveEventType(mathPackage+"::math::Added"), suiapi.PageOptions[suiapi.EventId]{PageSize: 1, Descending: true}).All(ctx)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	require.Equal(t, second, events[0].Id.TxDigest)
+	require.Equal(t, first, events[1].Id.TxDigest)
+
+	events, err = client.PaginateEvents(suiapi.EventAnd(
+		suiapi.EventMoveEventModule(mathPackage, "math"),
+		suiapi.EventNot(suiapi.EventTransaction(second)),
+	), suiapi.PageOptions[suiapi.EventId]{}).All(ctx)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+
+	// Checkpoint one holds the first transaction, each next checkpoint comes one second later
+	// 一号检查点包含第一笔交易，之后每个检查点晚一秒
+	events, err = client.PaginateEvents(suiapi.EventTimeRange(events[0].TimestampMs+1, events[0].TimestampMs+2000), suiapi.PageOptions[suiapi.EventId]{}).All(ctx)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, second, events[0].Id.TxDigest)
+
+	events, err = client.PaginateEvents(suiapi.EventAll(), suiapi.PageOptions[suiapi.EventId]{}).All(ctx)
+	require.NoError(t, err)
+	require.Len(t, events, 4)
+
+	_, err = client.QueryEvents(ctx, nil, nil, 0, false)
+	require.Error(t, err)
+
+	registry := suiapi.NewEventRegistry()
+	require.NoError(t, suiapi.RegisterEvent[AddedEvent](registry, mathPackage+"::math::Added"))
+	require.NoError(t, suiapi.RegisterEvent[MaxEvent](registry, "0x46ED36947B4912AB1D584D9DC5B578F7EB5E271F4AD39541DD189FEFAD1C34A2::math::Max"))
+	require.NoError(t, suiapi.RegisterEvent[map[string]string](registry, "0x2::coin::CoinEvent"))
+	require.Error(t, suiapi.RegisterEvent[AddedEvent](registry, "math::Added"))
+
+	decoded := make([]any, 0, len(events))
+	for _, event := range events {
+		value, err := registry.Decode(event)
+		require.NoError(t, err)
+		decoded = append(decoded, value)
+	}
+	require.Equal(t, &AddedEvent{A: 1, B: 2, Sum: 3}, decoded[0])
+	require.Equal(t, []uint64{7, 9}, decoded[1].(*MaxEvent).Values)
+	require.Equal(t, int64(9), decoded[1].(*MaxEvent).Max.Int64())
+	require.Equal(t, &AddedEvent{A: 10, B: 20, Sum: 30}, decoded[2])
+	require.Equal(t, &map[string]string{"amount": "5"}, decoded[3])
+
+	_, err = suiapi.NewEventRegistry().Decode(events[0])
+	require.ErrorIs(t, err, suiapi.ErrUnknownEventType)
+
+	added, err := suiapi.DecodeEvent[AddedEvent](events[2])
+	require.NoError(t, err)
+	require.Equal(t, uint64(30), added.Sum)
+}
@@ -0,0 +1,148 @@
+package suiapi
+
+import (
+	"context"
+	"math/big"
+	"strings"
+
+	"github.com/yyle88/erero"
+)
+
+// TransactionHistoryOptions represents settings of GetTransactionHistory
+//
+// TransactionHistoryOptions 表示 GetTransactionHistory 的配置
+type TransactionHistoryOptions struct {
+	PageSize   int                              // Items per call of each stream, zero lets node pick // 每个流每次调用的条目数，零表示由节点决定
+	Descending bool                             // Newest first // 最新的在前
+	MaxItems   int                              // Stop after this many transactions, zero means no cap // 达到该交易数后停止，零表示不限
+	Options    *TransactionBlockResponseOptions // Extra response sections, balance changes are always asked for // 额外的响应部分，余额变更始终会请求
+}
+
+// TransactionHistoryEntry represents one transaction in history of address
+//
+// TransactionHistoryEntry 表示地址历史中的一笔交易
+type TransactionHistoryEntry struct {
+	Digest        string                       // Transaction digest // 交易摘要
+	TimestampMs   uint64                       // Checkpoint time in milliseconds // 检查点时间，单位毫秒
+	Checkpoint    uint64                       // Checkpoint sequence number // 检查点序号
+	Sent          bool                         // Address sent the transaction // 地址发送了该交易
+	Received      bool                         // Transaction changed objects owned by address // 交易修改了地址所拥有的对象
+	BalanceDeltas map[string]*big.Int          // Balance change of address by coin type, negative when spent // 地址按代币类型的余额变化，支出时为负
+	Transaction   *SuiTransactionBlockResponse // Transaction response // 交易响应
+}
+
+// GetTransactionHistory returns transactions sent or received by address in one time-ordered list
+// Fullnodes take FromAddress and ToAddress apart, so both streams are walked and merged
+// Transactions showing up in both streams come once, with Sent and Received both set
+//
+// GetTransactionHistory 以按时间排序的单个列表返回地址发送或接收的交易
+// 全节点分别处理 FromAddress 和 ToAddress，因此会遍历两个流并合并
+// 同时出现在两个流中的交易只出现一次，Sent 和 Received 均被设置
+func (c *Client) GetTransactionHistory(ctx context.Context, address string, options TransactionHistoryOptions) ([]*TransactionHistoryEntry, error) {
+	responseOptions := &TransactionBlockResponseOptions{}
+	if options.Options != nil {
+		*responseOptions = *options.Options
+	}
+	responseOptions.ShowBalanceChanges = true
+	pageOptions := PageOptions[string]{PageSize: options.PageSize, Descending: options.Descending}
+
+	sent := c.PaginateTransactionBlocks(&TransactionBlockResponseQuery{Filter: TransactionFromAddress(address), Options: responseOptions}, pageOptions)
+	received := c.PaginateTransactionBlocks(&TransactionBlockResponseQuery{Filter: TransactionToAddress(address), Options: responseOptions}, pageOptions)
+	hasSent, hasReceived := sent.Next(ctx), received.Next(ctx)
+
+	var history []*TransactionHistoryEntry
+	entries := map[string]*TransactionHistoryEntry{}
+	for hasSent || hasReceived {
+		var tx *SuiTransactionBlockResponse
+		fromSent := hasSent && (!hasReceived || comesFirst(sent.Item(), received.Item(), options.Descending))
+		if fromSent {
+			tx, hasSent = sent.Item(), sent.Next(ctx)
+		} else {
+			tx, hasReceived = received.Item(), received.Next(ctx)
+		}
+
+		entry, ok := entries[tx.Digest]
+		if !ok {
+			if options.MaxItems > 0 && len(history) >= options.MaxItems {
+				break
+			}
+			deltas, err := balanceDeltas(tx, address)
+			if err != nil {
+				return nil, erero.Wro(err)
+			}
+			entry = &TransactionHistoryEntry{
+				Digest:        tx.Digest,
+				TimestampMs:   tx.TimestampMs,
+				Checkpoint:    tx.Checkpoint,
+				BalanceDeltas: deltas,
+				Transaction:   tx,
+			}
+			entries[tx.Digest] = entry
+			history = append(history, entry)
+		}
+		if fromSent {
+			entry.Sent = true
+		} else {
+			entry.Received = true
+		}
+	}
+	if err := sent.Err(); err != nil {
+		return nil, erero.Wro(err)
+	}
+	if err := received.Err(); err != nil {
+		return nil, erero.Wro(err)
+	}
+	return history, nil
+}
+
+// comesFirst checks if transaction a goes before b in walk order, by checkpoint then time
+//
+// comesFirst 检查交易 a 在遍历顺序中是否位于 b 之前，先按检查点再按时间比较
+func comesFirst(a *SuiTransactionBlockResponse, b *SuiTransactionBlockResponse, descending bool) bool {
+	if a.Checkpoint == b.Checkpoint && a.TimestampMs == b.TimestampMs {
+		return true
+	}
+	earlier := a.Checkpoint < b.Checkpoint || (a.Checkpoint == b.Checkpoint && a.TimestampMs < b.TimestampMs)
+	return earlier != descending
+}
+
+// balanceDeltas sums balance changes owned by address by coin type
+//
+// balanceDeltas 按代币类型汇总地址所拥有的余额变更
+func balanceDeltas(tx *SuiTransactionBlockResponse, address string) (map[string]*big.Int, error) {
+	deltas := map[string]*big.Int{}
+	for _, change := range tx.BalanceChanges {
+		if !sameAddress(change.Owner.Owner(), address) {
+			continue
+		}
+		amount, ok := new(big.Int).SetString(change.Amount, 10)
+		if !ok {
+			return nil, erero.Errorf("transaction %s balance change %q of %s", tx.Digest, change.Amount, change.CoinType)
+		}
+		if delta, ok := deltas[change.CoinType]; ok {
+			delta.Add(delta, amount)
+		} else {
+			deltas[change.CoinType] = amount
+		}
+	}
+	return deltas, nil
+}
+
+// sameAddress checks if two addresses are the same, ignoring padding and case
+//
+// sameAddress 检查两个地址是否相同，忽略补零和大小写差异
+func sameAddress(a string, b string) bool {
+	return normalizeAddress(a) == normalizeAddress(b)
+}
+
+// normalizeAddress returns address padded to 32 bytes in lowercase, input as is when it is no address
+//
+// normalizeAddress 返回补齐到 32 字节的小写地址，不是地址时原样返回
+func normalizeAddress(address string) string {
+	parser := &typeTagParser{text: strings.TrimSpace(address)}
+	normalized, err := parser.address()
+	if err != nil || parser.pos != len(parser.text) {
+		return address
+	}
+	return normalized
+}
@@ -0,0 +1,78 @@
+package suiapi_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/go-xlan/sui-go-guide/suiapi"
+	"github.com/go-xlan/sui-go-guide/suirpctest"
+	"github.com/stretchr/testify/require"
+)
+
+// TestClient_GetTransactionHistory tests sent and received streams merge into one ordered history
+//
+// TestClient_GetTransactionHistory 测试发送流和接收流合并为一个有序的历史
+func TestClient_GetTransactionHistory(t *testing.T) {
+	server := newSimulator(t)
+	client := suiapi.NewClient(server.Client())
+	ctx := context.Background()
+	friend := friendAddress(t)
+
+	coin := server.Mint(address, 100_000_000)
+	friendCoin := server.Mint(friend, 50_000_000)
+	first := transferSui(t, client.RpcClient(), coin.CoinObjectId, "30000000")
+	second := transferFromFriend(t, client.RpcClient(), friend, friendCoin.CoinObjectId, "5000000")
+	emitted := server.EmitEvents(recipient, suirpctest.Event{Type: mathPackage + "::math::Added", ParsedJson: map[string]any{"a": "1", "b": "2", "sum": "3"}})
+	third := transferSui(t, client.RpcClient(), coin.CoinObjectId, "1000000")
+
+	history, err := client.GetTransactionHistory(ctx, address, suiapi.TransactionHistoryOptions{PageSize: 1})
+	require.NoError(t, err)
+	require.Len(t, history, 3)
+
+	// Paying gas mutates own coin, so sent transactions show up as received too
+	// 支付 gas 会修改自己的代币，因此发送的交易也会作为接收出现
+	require.Equal(t, first, history[0].Digest)
+	require.True(t, history[0].Sent)
+	require.True(t, history[0].Received)
+	require.Equal(t, big.NewInt(-31_000_000), history[0].BalanceDeltas[suirpctest.SuiCoinType])
+
+	require.Equal(t, second, history[1].Digest)
+	require.False(t, history[1].Sent)
+	require.True(t, history[1].Received)
+	require.Equal(t, big.NewInt(5_000_000), history[1].BalanceDeltas[suirpctest.SuiCoinType])
+
+	require.Equal(t, third, history[2].Digest)
+	require.Equal(t, big.NewInt(-2_000_000), history[2].BalanceDeltas[suirpctest.SuiCoinType])
+	require.Less(t, history[0].TimestampMs, history[1].TimestampMs)
+	require.Less(t, history[1].Checkpoint, history[2].Checkpoint)
+	require.NotNil(t, history[2].Transaction.BalanceChanges)
+
+	history, err = client.GetTransactionHistory(ctx, address, suiapi.TransactionHistoryOptions{
+		Descending: true,
+		MaxItems:   2,
+		Options:    &suiapi.TransactionBlockResponseOptions{ShowEffects: true},
+	})
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	require.Equal(t, third, history[0].Digest)
+	require.Equal(t, second, history[1].Digest)
+	require.NotNil(t, history[0].Transaction.Effects)
+
+	// Recipient receives two transfers and sends one event-only transaction without balance changes
+	// 接收方收到两笔转账并发送一笔只有事件、没有余额变更的交易
+	history, err = client.GetTransactionHistory(ctx, recipient, suiapi.TransactionHistoryOptions{})
+	require.NoError(t, err)
+	require.Len(t, history, 3)
+	require.False(t, history[0].Sent)
+	require.Equal(t, big.NewInt(30_000_000), history[0].BalanceDeltas[suirpctest.SuiCoinType])
+	require.Equal(t, emitted, history[1].Digest)
+	require.True(t, history[1].Sent)
+	require.False(t, history[1].Received)
+	require.Empty(t, history[1].BalanceDeltas)
+	require.Equal(t, big.NewInt(1_000_000), history[2].BalanceDeltas[suirpctest.SuiCoinType])
+
+	history, err = client.GetTransactionHistory(ctx, "0x0000000000000000000000000000000000000000000000000000000000000bad", suiapi.TransactionHistoryOptions{})
+	require.NoError(t, err)
+	require.Empty(t, history)
+}
@@ -0,0 +1,367 @@
+package suiapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/yyle88/erero"
+)
+
+// UID represents Move UID field, rendered by fullnodes as {"id": "0x.."}
+// Plain string fields take UID as well, holding the ID
+//
+// UID 表示 Move 的 UID 字段，全节点将其渲染为 {"id": "0x.."}
+// 普通字符串字段同样可以接收 UID，保存其中的 ID
+type UID struct {
+	Id string `json:"id"` // Object ID // 对象 ID
+}
+
+// DecodeMoveObject decodes content fields of Move object into T
+// See DecodeMoveValue to learn how Move values map onto Go types
+//
+// DecodeMoveObject 将 Move 对象的内容字段解码为 T
+// Move 值与 Go 类型的对应关系参见 DecodeMoveValue
+func DecodeMoveObject[T any](object *ObjectData) (*T, error) {
+	if object == nil || object.Content == nil {
+		return nil, erero.New("object has no content, ask for it with ShowContent")
+	}
+	if object.Content.DataType != DataTypeMoveObject {
+		return nil, erero.Errorf("object %s holds %s, not Move object", object.ObjectId, object.Content.DataType)
+	}
+	return DecodeMoveValue[T](object.Content.Fields)
+}
+
+// DecodeMoveValue decodes Move value in fullnode JSON form into T, fields match by json tag
+// Nested structs come as {"type": .., "fields": {..}} and get unwrapped, top-level value is taken as fields
+// u64, u128 and u256 come as decimal strings and fill integer fields or big.Int
+// Option<T> fills pointer fields, nil when none, both null and {"vec": [..]} forms work
+// vector<T> fills slices, VecMap fills maps, UID fills UID or string fields
+//
+// DecodeMoveValue 将全节点 JSON 形式的 Move 值解码为 T，字段按 json 标签匹配
+// 嵌套结构体以 {"type": .., "fields": {..}} 形式出现并会被展开，顶层值直接视为字段
+// u64、u128 和 u256 以十进制字符串出现，可填充整数字段或 big.Int
+// Option<T> 填充指针字段，为 none 时为 nil，支持 null 和 {"vec": [..]} 两种形式
+// vector<T> 填充切片，VecMap 填充映射，UID 填充 UID 或字符串字段
+func DecodeMoveValue[T any](data json.RawMessage) (*T, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, erero.Wro(err)
+	}
+	var result T
+	if err := decodeMove(value, reflect.ValueOf(&result).Elem(), "$"); err != nil {
+		return nil, erero.Wro(err)
+	}
+	return &result, nil
+}
+
+var (
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+	bigIntType     = reflect.TypeOf(big.Int{})
+)
+
+// decodeMove sets target from generic JSON value, path names the spot in errors
+//
+// decodeMove 根据通用 JSON 值设置目标，path 在错误中指明位置
+func decodeMove(value any, target reflect.Value, path string) error {
+	switch target.Type() {
+	case rawMessageType:
+		data, err := json.Marshal(value)
+		if err != nil {
+			return erero.Wro(err)
+		}
+		target.SetBytes(data)
+		return nil
+	case bigIntType:
+		text, ok := numberText(value)
+		if !ok {
+			return mismatch(path, "integer", value)
+		}
+		if _, ok := target.Addr().Interface().(*big.Int).SetString(text, 10); !ok {
+			return erero.Errorf("%s: invalid integer %q", path, text)
+		}
+		return nil
+	}
+
+	switch target.Kind() {
+	case reflect.Pointer:
+		value = unwrapOption(value)
+		if value == nil {
+			target.SetZero()
+			return nil
+		}
+		element := reflect.New(target.Type().Elem())
+		if err := decodeMove(value, element.Elem(), path); err != nil {
+			return err
+		}
+		target.Set(element)
+		return nil
+	case reflect.Interface:
+		// Only any takes arbitrary JSON, interfaces with methods cannot hold it
+		// 只有 any 能接收任意 JSON，带方法的接口无法保存它
+		if target.Type().NumMethod() != 0 {
+			return erero.Errorf("%s: unsupported Go type %s", path, target.Type())
+		}
+		if value != nil {
+			target.Set(reflect.ValueOf(value))
+		}
+		return nil
+	case reflect.Struct:
+		if id, ok := value.(string); ok && target.Type() == reflect.TypeOf(UID{}) {
+			target.Set(reflect.ValueOf(UID{Id: id}))
+			return nil
+		}
+		fields, ok := value.(map[string]any)
+		if !ok {
+			return mismatch(path, "struct", value)
+		}
+		return decodeStruct(fields, target, path)
+	case reflect.String:
+		switch value := value.(type) {
+		case string:
+			target.SetString(value)
+		case json.Number:
+			target.SetString(value.String())
+		case map[string]any:
+			// UID and ID alike, {"id": "0x.."}
+			// UID 和 ID 类似，{"id": "0x.."}
+			id, ok := value["id"].(string)
+			if !ok || len(value) != 1 {
+				return mismatch(path, "string", value)
+			}
+			target.SetString(id)
+		default:
+			return mismatch(path, "string", value)
+		}
+		return nil
+	case reflect.Bool:
+		flag, ok := value.(bool)
+		if !ok {
+			return mismatch(path, "bool", value)
+		}
+		target.SetBool(flag)
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		text, ok := numberText(value)
+		if !ok {
+			return mismatch(path, "integer", value)
+		}
+		number, err := strconv.ParseInt(text, 10, target.Type().Bits())
+		if err != nil {
+			return erero.WithMessagef(err, "%s", path)
+		}
+		target.SetInt(number)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		text, ok := numberText(value)
+		if !ok {
+			return mismatch(path, "integer", value)
+		}
+		number, err := strconv.ParseUint(text, 10, target.Type().Bits())
+		if err != nil {
+			return erero.WithMessagef(err, "%s", path)
+		}
+		target.SetUint(number)
+		return nil
+	case reflect.Float32, reflect.Float64:
+		text, ok := numberText(value)
+		if !ok {
+			return mismatch(path, "number", value)
+		}
+		number, err := strconv.ParseFloat(text, target.Type().Bits())
+		if err != nil {
+			return erero.WithMessagef(err, "%s", path)
+		}
+		target.SetFloat(number)
+		return nil
+	case reflect.Slice:
+		if value == nil {
+			target.SetZero()
+			return nil
+		}
+		items, ok := value.([]any)
+		if !ok {
+			return mismatch(path, "vector", value)
+		}
+		slice := reflect.MakeSlice(target.Type(), len(items), len(items))
+		for idx, item := range items {
+			if err := decodeMove(unwrapStruct(item), slice.Index(idx), path+"["+strconv.Itoa(idx)+"]"); err != nil {
+				return err
+			}
+		}
+		target.Set(slice)
+		return nil
+	case reflect.Array:
+		items, ok := value.([]any)
+		if !ok || len(items) != target.Len() {
+			return mismatch(path, "vector of "+strconv.Itoa(target.Len()), value)
+		}
+		for idx, item := range items {
+			if err := decodeMove(unwrapStruct(item), target.Index(idx), path+"["+strconv.Itoa(idx)+"]"); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Map:
+		return decodeMap(value, target, path)
+	default:
+		return erero.Errorf("%s: unsupported Go type %s", path, target.Type())
+	}
+}
+
+// decodeStruct sets exported fields of target from Move struct fields
+// Embedded structs without tag take fields from the same Move struct
+//
+// decodeStruct 根据 Move 结构体字段设置目标的导出字段
+// 无标签的嵌入结构体从同一个 Move 结构体中获取字段
+func decodeStruct(fields map[string]any, target reflect.Value, path string) error {
+	structType := target.Type()
+	for idx := range structType.NumField() {
+		field := structType.Field(idx)
+		if !field.IsExported() {
+			continue
+		}
+		tag := field.Tag.Get("json")
+		if field.Anonymous && tag == "" && field.Type.Kind() == reflect.Struct {
+			if err := decodeStruct(fields, target.Field(idx), path); err != nil {
+				return err
+			}
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		value, ok := fields[name]
+		if !ok {
+			continue
+		}
+		if err := decodeMove(unwrapStruct(value), target.Field(idx), path+"."+name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decodeMap sets map target from JSON object or from VecMap, keys decode like values
+//
+// decodeMap 根据 JSON 对象或 VecMap 设置映射目标，键按值的方式解码
+func decodeMap(value any, target reflect.Value, path string) error {
+	if value == nil {
+		target.SetZero()
+		return nil
+	}
+	object, ok := value.(map[string]any)
+	if !ok {
+		return mismatch(path, "map", value)
+	}
+	mapType := target.Type()
+	result := reflect.MakeMapWithSize(mapType, len(object))
+	put := func(key any, item any, itemPath string) error {
+		mapKey := reflect.New(mapType.Key()).Elem()
+		if err := decodeMove(unwrapStruct(key), mapKey, itemPath); err != nil {
+			return err
+		}
+		mapValue := reflect.New(mapType.Elem()).Elem()
+		if err := decodeMove(unwrapStruct(item), mapValue, itemPath); err != nil {
+			return err
+		}
+		result.SetMapIndex(mapKey, mapValue)
+		return nil
+	}
+	// VecMap renders as {"contents": [{"key": .., "value": ..}]}
+	// VecMap 渲染为 {"contents": [{"key": .., "value": ..}]}
+	if contents, ok := object["contents"].([]any); ok && len(object) == 1 {
+		for idx, entry := range contents {
+			pair, ok := unwrapStruct(entry).(map[string]any)
+			if !ok {
+				return mismatch(path+".contents["+strconv.Itoa(idx)+"]", "entry", entry)
+			}
+			if err := put(pair["key"], pair["value"], path+".contents["+strconv.Itoa(idx)+"]"); err != nil {
+				return err
+			}
+		}
+	} else {
+		for key, item := range object {
+			if err := put(key, item, path+"."+key); err != nil {
+				return err
+			}
+		}
+	}
+	target.Set(result)
+	return nil
+}
+
+// unwrapStruct returns fields of nested struct form {"type": .., "fields": {..}}, other values stay
+// Applies to nested values only, type must be Move struct tag so structs with own type and fields keys stay
+//
+// unwrapStruct 返回嵌套结构体形式 {"type": .., "fields": {..}} 的字段，其他值保持不变
+// 仅用于嵌套值，type 必须是 Move 结构体标签，因此自带 type 和 fields 键的结构体保持不变
+func unwrapStruct(value any) any {
+	object, ok := value.(map[string]any)
+	if !ok || len(object) != 2 {
+		return value
+	}
+	if tag, ok := object["type"].(string); !ok || !isStructTag(tag) {
+		return value
+	}
+	if fields, ok := object["fields"].(map[string]any); ok {
+		return fields
+	}
+	return value
+}
+
+// isStructTag checks if text is Move struct tag such as 0x2::coin::Coin<0x2::sui::SUI>
+//
+// isStructTag 检查文本是否为 Move 结构体标签，例如 0x2::coin::Coin<0x2::sui::SUI>
+func isStructTag(text string) bool {
+	_, err := parseStructTag(text)
+	return err == nil
+}
+
+// unwrapOption returns inner value of Option in {"vec": [..]} form, nil when none
+//
+// unwrapOption 返回 {"vec": [..]} 形式的 Option 的内部值，为 none 时为 nil
+func unwrapOption(value any) any {
+	object, ok := value.(map[string]any)
+	if !ok || len(object) != 1 {
+		return value
+	}
+	items, ok := object["vec"].([]any)
+	if !ok || len(items) > 1 {
+		return value
+	}
+	if len(items) == 0 {
+		return nil
+	}
+	return unwrapStruct(items[0])
+}
+
+// numberText returns decimal text of JSON number or numeric string
+//
+// numberText 返回 JSON 数字或数字字符串的十进制文本
+func numberText(value any) (string, bool) {
+	switch value := value.(type) {
+	case json.Number:
+		return value.String(), true
+	case string:
+		return value, true
+	default:
+		return "", false
+	}
+}
+
+// mismatch returns error telling expected Move value kind and the JSON met
+//
+// mismatch 返回说明期望的 Move 值种类与实际 JSON 的错误
+func mismatch(path string, want string, value any) error {
+	return erero.Errorf("%s: want %s, got %T", path, want, value)
+}
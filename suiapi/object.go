@@ -0,0 +1,249 @@
+package suiapi
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/go-xlan/sui-go-guide/suirpc"
+	"github.com/yyle88/erero"
+)
+
+// ObjectDataOptions represents sections returned along with object data
+//
+// ObjectDataOptions 表示随对象数据一起返回的部分
+type ObjectDataOptions struct {
+	ShowType                bool `json:"showType,omitempty"`                // Include Move type // 包含 Move 类型
+	ShowOwner               bool `json:"showOwner,omitempty"`               // Include owner // 包含所有者
+	ShowPreviousTransaction bool `json:"showPreviousTransaction,omitempty"` // Include last transaction digest // 包含最后交易摘要
+	ShowDisplay             bool `json:"showDisplay,omitempty"`             // Include display metadata // 包含展示元数据
+	ShowContent             bool `json:"showContent,omitempty"`             // Include parsed Move content // 包含解析后的 Move 内容
+	ShowBcs                 bool `json:"showBcs,omitempty"`                 // Include BCS bytes // 包含 BCS 字节
+	ShowStorageRebate       bool `json:"showStorageRebate,omitempty"`       // Include storage rebate // 包含存储返还
+}
+
+// ObjectResponseQuery represents owned object query with filter and data options
+// Filter is JSON object such as {"StructType": "0x2::coin::Coin<0x2::sui::SUI>"}, nil matches each object
+//
+// ObjectResponseQuery 表示带过滤条件和数据选项的拥有对象查询
+// Filter 为 JSON 对象，例如 {"StructType": "0x2::coin::Coin<0x2::sui::SUI>"}，nil 匹配每个对象
+type ObjectResponseQuery struct {
+	Filter  any                `json:"filter,omitempty"`  // Object filter // 对象过滤条件
+	Options *ObjectDataOptions `json:"options,omitempty"` // Data options // 数据选项
+}
+
+// FullObjectDataOptions returns options showing each section of object data
+//
+// FullObjectDataOptions 返回展示对象数据每个部分的选项
+func FullObjectDataOptions() *ObjectDataOptions {
+	return &ObjectDataOptions{
+		ShowType:                true,
+		ShowOwner:               true,
+		ShowPreviousTransaction: true,
+		ShowDisplay:             true,
+		ShowContent:             true,
+		ShowBcs:                 true,
+		ShowStorageRebate:       true,
+	}
+}
+
+// SuiObjectResponse represents object lookup result, either Data or Error is set
+//
+// SuiObjectResponse 表示对象查询结果，Data 与 Error 二者之一有值
+type SuiObjectResponse struct {
+	Data  *ObjectData          `json:"data,omitempty"`  // Object data // 对象数据
+	Error *ObjectResponseError `json:"error,omitempty"` // Lookup failure // 查询失败
+}
+
+// Object returns object data, or error matching suirpc.ErrObjectNotFound when object is missing or deleted
+//
+// Object 返回对象数据，对象不存在或已删除时返回匹配 suirpc.ErrObjectNotFound 的错误
+func (response *SuiObjectResponse) Object() (*ObjectData, error) {
+	if response.Data != nil {
+		return response.Data, nil
+	}
+	if response.Error == nil {
+		return nil, erero.New("object response has neither data nor error")
+	}
+	switch response.Error.Code {
+	case "notExists", "deleted", "dynamicFieldNotFound":
+		return nil, erero.WithMessagef(suirpc.ErrObjectNotFound, "object %s: %s", response.Error.ObjectId, response.Error.Code)
+	default:
+		return nil, erero.Errorf("object %s: %s %s", response.Error.ObjectId, response.Error.Code, response.Error.Error)
+	}
+}
+
+// ObjectData represents object with sections asked for in data options
+//
+// ObjectData 表示带有数据选项所请求部分的对象
+type ObjectData struct {
+	ObjectId            string                 `json:"objectId"`                      // Object ID // 对象 ID
+	Version             string                 `json:"version"`                       // Object version // 对象版本
+	Digest              string                 `json:"digest"`                        // Object digest // 对象摘要
+	Type                string                 `json:"type,omitempty"`                // Move type // Move 类型
+	Owner               *ObjectOwner           `json:"owner,omitempty"`               // Owner // 所有者
+	PreviousTransaction string                 `json:"previousTransaction,omitempty"` // Last transaction digest // 最后交易摘要
+	StorageRebate       string                 `json:"storageRebate,omitempty"`       // Storage rebate in MIST // 存储返还，单位 MIST
+	Display             *DisplayFieldsResponse `json:"display,omitempty"`             // Display metadata // 展示元数据
+	Content             *MoveContent           `json:"content,omitempty"`             // Parsed Move content // 解析后的 Move 内容
+	Bcs                 *RawObjectData         `json:"bcs,omitempty"`                 // BCS bytes // BCS 字节
+}
+
+// Move content data types
+//
+// Move 内容的数据类型
+const (
+	DataTypeMoveObject = "moveObject" // Move object // Move 对象
+	DataTypePackage    = "package"    // Move package // Move 包
+)
+
+// MoveContent represents parsed content of object, DataType tells Move object from package
+// Move objects fill Type, HasPublicTransfer and Fields, packages fill Disassembled
+//
+// MoveContent 表示对象的解析内容，DataType 区分 Move 对象与包
+// Move 对象填充 Type、HasPublicTransfer 和 Fields，包填充 Disassembled
+type MoveContent struct {
+	DataType          string            `json:"dataType"`                    // moveObject or package // moveObject 或 package
+	Type              string            `json:"type,omitempty"`              // Move struct type // Move 结构体类型
+	HasPublicTransfer bool              `json:"hasPublicTransfer,omitempty"` // Type has store ability // 类型具有 store 能力
+	Fields            json.RawMessage   `json:"fields,omitempty"`            // Struct fields, decode with DecodeMoveObject // 结构体字段，使用 DecodeMoveObject 解码
+	Disassembled      map[string]string `json:"disassembled,omitempty"`      // Disassembled modules of package // 包中反汇编的模块
+}
+
+// RawObjectData represents BCS bytes of object, DataType tells Move object from package
+//
+// RawObjectData 表示对象的 BCS 字节，DataType 区分 Move 对象与包
+type RawObjectData struct {
+	DataType          string            `json:"dataType"`                    // moveObject or package // moveObject 或 package
+	Type              string            `json:"type,omitempty"`              // Move struct type // Move 结构体类型
+	HasPublicTransfer bool              `json:"hasPublicTransfer,omitempty"` // Type has store ability // 类型具有 store 能力
+	Version           uint64            `json:"version"`                     // Object version // 对象版本
+	BcsBytes          string            `json:"bcsBytes,omitempty"`          // BCS bytes of Move object in Base64 // Base64 编码的 Move 对象 BCS 字节
+	Id                string            `json:"id,omitempty"`                // Package ID // 包 ID
+	ModuleMap         map[string]string `json:"moduleMap,omitempty"`         // Module bytes of package in Base64 // Base64 编码的包模块字节
+	TypeOriginTable   json.RawMessage   `json:"typeOriginTable,omitempty"`   // Package defining each type // 定义每个类型的包
+	LinkageTable      json.RawMessage   `json:"linkageTable,omitempty"`      // Dependency versions of package // 包的依赖版本
+}
+
+// DisplayFieldsResponse represents rendered display template of object
+//
+// DisplayFieldsResponse 表示对象渲染后的展示模板
+type DisplayFieldsResponse struct {
+	Data  map[string]string `json:"data"`  // Rendered fields // 渲染后的字段
+	Error json.RawMessage   `json:"error"` // Render failure // 渲染失败
+}
+
+// ObjectResponseError represents reason object lookup failed
+// Code is notExists, dynamicFieldNotFound, deleted, unknown or displayError
+//
+// ObjectResponseError 表示对象查询失败的原因
+// Code 为 notExists、dynamicFieldNotFound、deleted、unknown 或 displayError
+type ObjectResponseError struct {
+	Code           string `json:"code"`                       // Error code // 错误码
+	ObjectId       string `json:"object_id,omitempty"`        // Object ID looked up // 查询的对象 ID
+	ParentObjectId string `json:"parent_object_id,omitempty"` // Parent of missing dynamic field // 缺失动态字段的父对象
+	Version        string `json:"version,omitempty"`          // Version of deletion // 删除时的版本
+	Digest         string `json:"digest,omitempty"`           // Digest of deleted object // 已删除对象的摘要
+	Error          string `json:"error,omitempty"`            // Display failure text // 展示失败的描述
+}
+
+// Past object statuses
+//
+// 历史对象的状态
+const (
+	PastObjectVersionFound    = "VersionFound"    // Details hold object data // Details 为对象数据
+	PastObjectNotExists       = "ObjectNotExists" // Details hold object ID // Details 为对象 ID
+	PastObjectDeleted         = "ObjectDeleted"   // Details hold object reference // Details 为对象引用
+	PastObjectVersionNotFound = "VersionNotFound" // Details hold [object ID, version] // Details 为 [对象 ID, 版本]
+	PastObjectVersionTooHigh  = "VersionTooHigh"  // Details hold asked and latest versions // Details 为请求的版本和最新版本
+)
+
+// PastObjectResponse represents object lookup at given version, Status tells the shape of Details
+//
+// PastObjectResponse 表示按给定版本查询对象的结果，Status 说明 Details 的形式
+type PastObjectResponse struct {
+	Status  string          `json:"status"`  // Lookup status // 查询状态
+	Details json.RawMessage `json:"details"` // Status details // 状态详情
+}
+
+// Object returns object data when version was found
+// Returns error matching suirpc.ErrObjectNotFound otherwise
+//
+// Object 在找到版本时返回对象数据
+// 否则返回匹配 suirpc.ErrObjectNotFound 的错误
+func (response *PastObjectResponse) Object() (*ObjectData, error) {
+	if response.Status != PastObjectVersionFound {
+		return nil, erero.WithMessagef(suirpc.ErrObjectNotFound, "past object %s: %s", response.Status, response.Details)
+	}
+	var object ObjectData
+	if err := json.Unmarshal(response.Details, &object); err != nil {
+		return nil, erero.Wro(err)
+	}
+	return &object, nil
+}
+
+// PastObjectRequest represents object ID and version to look up
+//
+// PastObjectRequest 表示要查询的对象 ID 和版本
+type PastObjectRequest struct {
+	ObjectId string `json:"objectId"`       // Object ID // 对象 ID
+	Version  uint64 `json:"version,string"` // Object version // 对象版本
+}
+
+// GetObject returns object with sections asked for in options, nil options return reference only
+// Missing and deleted objects come back with Error set, see SuiObjectResponse.Object
+//
+// GetObject 返回带有选项所请求部分的对象，options 为 nil 时仅返回引用
+// 不存在和已删除的对象以设置了 Error 的结果返回，参见 SuiObjectResponse.Object
+func (c *Client) GetObject(ctx context.Context, objectId string, options *ObjectDataOptions) (*SuiObjectResponse, error) {
+	return call[SuiObjectResponse](ctx, c, "sui_getObject", objectId, options)
+}
+
+// MultiGetObjects returns objects of given IDs in one call, in the same order
+//
+// MultiGetObjects 在一次调用中按相同顺序返回给定 ID 的对象
+func (c *Client) MultiGetObjects(ctx context.Context, objectIds []string, options *ObjectDataOptions) ([]*SuiObjectResponse, error) {
+	return callValue[[]*SuiObjectResponse](ctx, c, "sui_multiGetObjects", objectIds, options)
+}
+
+// TryGetPastObject returns object at given version, pruned versions may come back as VersionNotFound
+//
+// TryGetPastObject 返回对象在给定版本的状态，已裁剪的版本可能以 VersionNotFound 返回
+func (c *Client) TryGetPastObject(ctx context.Context, objectId string, version uint64, options *ObjectDataOptions) (*PastObjectResponse, error) {
+	return call[PastObjectResponse](ctx, c, "sui_tryGetPastObject", objectId, version, options)
+}
+
+// TryMultiGetPastObjects returns objects at given versions in one call, in the same order
+//
+// TryMultiGetPastObjects 在一次调用中按相同顺序返回对象在给定版本的状态
+func (c *Client) TryMultiGetPastObjects(ctx context.Context, requests []*PastObjectRequest, options *ObjectDataOptions) ([]*PastObjectResponse, error) {
+	return callValue[[]*PastObjectResponse](ctx, c, "sui_tryMultiGetPastObjects", requests, options)
+}
+
+// GetOwnedObjects returns page of objects owned by address, nil query returns object references only
+//
+// GetOwnedObjects 返回地址拥有的一页对象，query 为 nil 时仅返回对象引用
+func (c *Client) GetOwnedObjects(ctx context.Context, owner string, query *ObjectResponseQuery, cursor *string, limit int) (*Page[SuiObjectResponse, string], error) {
+	return call[Page[SuiObjectResponse, string]](ctx, c, "suix_getOwnedObjects", owner, query, cursor, optional(limit))
+}
+
+// PaginateOwnedObjects walks each object owned by address across pages
+//
+// PaginateOwnedObjects 跨分页遍历地址拥有的每个对象
+func (c *Client) PaginateOwnedObjects(owner string, query *ObjectResponseQuery, options PageOptions[string]) *Paginator[SuiObjectResponse, string] {
+	fetch := func(ctx context.Context, cursor *string, limit int, _ bool) (*Page[SuiObjectResponse, string], error) {
+		return c.GetOwnedObjects(ctx, owner, query, cursor, limit)
+	}
+	return NewPaginator(fetch, objectCursor, options)
+}
+
+// objectCursor returns cursor pointing at object, owned object queries page by object ID
+// Error items carry no object ID, so they report no cursor
+//
+// objectCursor 返回指向对象的游标，拥有对象查询按对象 ID 分页
+// 错误条目不带对象 ID，因此不报告游标
+func objectCursor(object *SuiObjectResponse) (string, bool) {
+	if object.Data == nil || object.Data.ObjectId == "" {
+		return "", false
+	}
+	return object.Data.ObjectId, true
+}
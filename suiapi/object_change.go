@@ -0,0 +1,211 @@
+package suiapi
+
+import (
+	"encoding/json"
+
+	"github.com/yyle88/erero"
+)
+
+// Object change kinds, value of "type" field
+//
+// 对象变更种类，即 "type" 字段的值
+const (
+	ObjectChangePublished   = "published"   // Package published // 包已发布
+	ObjectChangeTransferred = "transferred" // Object sent to recipient // 对象已发送给接收方
+	ObjectChangeMutated     = "mutated"     // Object mutated // 对象已修改
+	ObjectChangeDeleted     = "deleted"     // Object deleted // 对象已删除
+	ObjectChangeWrapped     = "wrapped"     // Object put into other object // 对象已放入其他对象
+	ObjectChangeCreated     = "created"     // Object created // 对象已创建
+)
+
+// ObjectChange represents object change of transaction as tagged union
+// Type names the change kind and exactly the matching variant is set
+//
+// ObjectChange 以标签联合表示交易的对象变更
+// Type 指明变更种类，并且恰好设置对应的变体
+type ObjectChange struct {
+	Type        string             // Change kind // 变更种类
+	Published   *PublishedObject   // Set when Type is published // Type 为 published 时设置
+	Transferred *TransferredObject // Set when Type is transferred // Type 为 transferred 时设置
+	Mutated     *MutatedObject     // Set when Type is mutated // Type 为 mutated 时设置
+	Deleted     *DeletedObject     // Set when Type is deleted // Type 为 deleted 时设置
+	Wrapped     *DeletedObject     // Set when Type is wrapped // Type 为 wrapped 时设置
+	Created     *CreatedObject     // Set when Type is created // Type 为 created 时设置
+}
+
+// PublishedObject represents package published by transaction
+//
+// PublishedObject 表示交易发布的包
+type PublishedObject struct {
+	PackageId string   `json:"packageId"` // Package ID // 包 ID
+	Version   string   `json:"version"`   // Package version // 包版本
+	Digest    string   `json:"digest"`    // Package digest // 包摘要
+	Modules   []string `json:"modules"`   // Module names // 模块名称
+}
+
+// TransferredObject represents object sent to recipient as a whole
+//
+// TransferredObject 表示整体发送给接收方的对象
+type TransferredObject struct {
+	Sender     string      `json:"sender"`     // Transaction sender // 交易发送方
+	Recipient  ObjectOwner `json:"recipient"`  // New owner // 新所有者
+	ObjectType string      `json:"objectType"` // Move object type // Move 对象类型
+	ObjectId   string      `json:"objectId"`   // Object ID // 对象 ID
+	Version    string      `json:"version"`    // Version after change // 变更后的版本
+	Digest     string      `json:"digest"`     // Digest after change // 变更后的摘要
+}
+
+// MutatedObject represents object mutated by transaction
+//
+// MutatedObject 表示交易修改的对象
+type MutatedObject struct {
+	Sender          string      `json:"sender"`          // Transaction sender // 交易发送方
+	Owner           ObjectOwner `json:"owner"`           // Owner after change // 变更后的所有者
+	ObjectType      string      `json:"objectType"`      // Move object type // Move 对象类型
+	ObjectId        string      `json:"objectId"`        // Object ID // 对象 ID
+	Version         string      `json:"version"`         // Version after change // 变更后的版本
+	PreviousVersion string      `json:"previousVersion"` // Version before change // 变更前的版本
+	Digest          string      `json:"digest"`          // Digest after change // 变更后的摘要
+}
+
+// DeletedObject represents object deleted or wrapped by transaction, both have no digest left
+//
+// DeletedObject 表示交易删除或包装的对象，两者都不再有摘要
+type DeletedObject struct {
+	Sender     string `json:"sender"`     // Transaction sender // 交易发送方
+	ObjectType string `json:"objectType"` // Move object type // Move 对象类型
+	ObjectId   string `json:"objectId"`   // Object ID // 对象 ID
+	Version    string `json:"version"`    // Version at change // 变更时的版本
+}
+
+// CreatedObject represents object created by transaction
+//
+// CreatedObject 表示交易创建的对象
+type CreatedObject struct {
+	Sender     string      `json:"sender"`     // Transaction sender // 交易发送方
+	Owner      ObjectOwner `json:"owner"`      // Owner of new object // 新对象的所有者
+	ObjectType string      `json:"objectType"` // Move object type // Move 对象类型
+	ObjectId   string      `json:"objectId"`   // Object ID // 对象 ID
+	Version    string      `json:"version"`    // Object version // 对象版本
+	Digest     string      `json:"digest"`     // Object digest // 对象摘要
+}
+
+// ObjectId returns ID of changed object, package ID when published
+//
+// ObjectId 返回变更对象的 ID，发布时为包 ID
+func (change *ObjectChange) ObjectId() string {
+	switch {
+	case change.Published != nil:
+		return change.Published.PackageId
+	case change.Transferred != nil:
+		return change.Transferred.ObjectId
+	case change.Mutated != nil:
+		return change.Mutated.ObjectId
+	case change.Deleted != nil:
+		return change.Deleted.ObjectId
+	case change.Wrapped != nil:
+		return change.Wrapped.ObjectId
+	case change.Created != nil:
+		return change.Created.ObjectId
+	default:
+		return ""
+	}
+}
+
+// variant returns pointer to variant of change Type, allocating it when asked
+//
+// variant 返回 Type 对应变体的指针，按需分配
+func (change *ObjectChange) variant(allocate bool) (any, error) {
+	switch change.Type {
+	case ObjectChangePublished:
+		if allocate {
+			change.Published = &PublishedObject{}
+		}
+		return change.Published, nil
+	case ObjectChangeTransferred:
+		if allocate {
+			change.Transferred = &TransferredObject{}
+		}
+		return change.Transferred, nil
+	case ObjectChangeMutated:
+		if allocate {
+			change.Mutated = &MutatedObject{}
+		}
+		return change.Mutated, nil
+	case ObjectChangeDeleted:
+		if allocate {
+			change.Deleted = &DeletedObject{}
+		}
+		return change.Deleted, nil
+	case ObjectChangeWrapped:
+		if allocate {
+			change.Wrapped = &DeletedObject{}
+		}
+		return change.Wrapped, nil
+	case ObjectChangeCreated:
+		if allocate {
+			change.Created = &CreatedObject{}
+		}
+		return change.Created, nil
+	default:
+		return nil, erero.Errorf("unknown object change type %q", change.Type)
+	}
+}
+
+// MarshalJSON encodes change as variant fields along with "type"
+//
+// MarshalJSON 将变更编码为变体字段并附带 "type"
+func (change ObjectChange) MarshalJSON() ([]byte, error) {
+	variant, err := change.variant(false)
+	if err != nil {
+		return nil, erero.Wro(err)
+	}
+	fields, err := json.Marshal(variant)
+	if err != nil {
+		return nil, erero.Wro(err)
+	}
+	if string(fields) == "null" {
+		return nil, erero.Errorf("object change type %q has no variant set", change.Type)
+	}
+	kind, err := json.Marshal(change.Type)
+	if err != nil {
+		return nil, erero.Wro(err)
+	}
+	data := append([]byte(`{"type":`), kind...)
+	if len(fields) > 2 {
+		data = append(append(data, ','), fields[1:]...)
+	} else {
+		data = append(data, '}')
+	}
+	return data, nil
+}
+
+// UnmarshalJSON decodes change into variant named by "type"
+//
+// UnmarshalJSON 将变更解码为 "type" 指明的变体
+func (change *ObjectChange) UnmarshalJSON(data []byte) error {
+	var tag struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(data, &tag); err != nil {
+		return erero.Wro(err)
+	}
+	*change = ObjectChange{Type: tag.Type}
+	variant, err := change.variant(true)
+	if err != nil {
+		return erero.Wro(err)
+	}
+	if err := json.Unmarshal(data, variant); err != nil {
+		return erero.Wro(err)
+	}
+	return nil
+}
+
+// BalanceChange represents coin balance change of one owner
+//
+// BalanceChange 表示单个所有者的代币余额变更
+type BalanceChange struct {
+	Owner    ObjectOwner `json:"owner"`    // Owner of balance // 余额的所有者
+	CoinType string      `json:"coinType"` // Coin type // 代币类型
+	Amount   string      `json:"amount"`   // Signed amount in minimal units // 以最小单位表示的带符号金额
+}
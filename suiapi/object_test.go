@@ -0,0 +1,280 @@
+package suiapi_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-xlan/sui-go-guide/suiapi"
+	"github.com/go-xlan/sui-go-guide/suirpc"
+	"github.com/stretchr/testify/require"
+)
+
+// poolFields is content.fields of Move object in fullnode form
+//
+// poolFields 是全节点形式的 Move 对象 content.fields
+const poolFields = `{
+	"id": {"id": "0x23278eef11e2556767914beaef093b6945178510363fe871d79a97bfd2666fea"},
+	"admin": "0x353a47f8fedca2d8cd1352222300f06b1f36789a55fffdecc6fe414ee1998969",
+	"fee_rate": 25,
+	"paused": false,
+	"name": "USDC-SUI",
+	"reserve_x": "1500000000000",
+	"liquidity": "340282366920938463463374607431768211455",
+	"sqrt_price": "18446744073709551616",
+	"balance_y": {"type": "0x2::balance::Balance<0x2::sui::SUI>", "fields": {"value": "402553018"}},
+	"owner_cap": null,
+	"reward": {"vec": [{"type": "0xabc::pool::Reward", "fields": {"amount": "7", "coin_type": "0x2::sui::SUI"}}]},
+	"reward_hint": {"vec": []},
+	"ticks": [
+		{"type": "0xabc::pool::Tick", "fields": {"index": 4294967294, "liquidity_net": "100"}},
+		{"type": "0xabc::pool::Tick", "fields": {"index": 12, "liquidity_net": "200"}}
+	],
+	"tags": ["stable", "hot"],
+	"limits": {"type": "0x2::vec_map::VecMap<u8, u64>", "fields": {"contents": [
+		{"type": "0x2::vec_map::Entry<u8, u64>", "fields": {"key": 1, "value": "1000"}},
+		{"type": "0x2::vec_map::Entry<u8, u64>", "fields": {"key": 2, "value": "2000"}}
+	]}},
+	"extra": {"type": "0xabc::pool::Extra", "fields": {"note": "kept raw"}}
+}`
+
+// Tick represents nested Move struct
+//
+// Tick 表示嵌套的 Move 结构体
+type Tick struct {
+	Index        uint32 `json:"index"`
+	LiquidityNet string `json:"liquidity_net"`
+}
+
+// Reward represents Move struct held in Option
+//
+// Reward 表示保存在 Option 中的 Move 结构体
+type Reward struct {
+	Amount   uint64 `json:"amount"`
+	CoinType string `json:"coin_type"`
+}
+
+// PoolHeader represents fields shared across pool kinds, embedded into Pool
+//
+// PoolHeader 表示各类池共有的字段，嵌入到 Pool 中
+type PoolHeader struct {
+	Id    suiapi.UID `json:"id"`
+	Admin string     `json:"admin"`
+}
+
+// Pool represents Move object decoded from poolFields
+//
+// Pool 表示从 poolFields 解码的 Move 对象
+type Pool struct {
+	PoolHeader
+	FeeRate   uint8  `json:"fee_rate"`
+	Paused    bool   `json:"paused"`
+	Name      string `json:"name"`
+	ReserveX  uint64 `json:"reserve_x"`
+	Liquidity *big.Int
+	SqrtPrice big.Int `json:"sqrt_price"`
+	BalanceY  struct {
+		Value uint64 `json:"value"`
+	} `json:"balance_y"`
+	OwnerCap   *string          `json:"owner_cap"`
+	Reward     *Reward          `json:"reward"`
+	RewardHint *Reward          `json:"reward_hint"`
+	Ticks      []Tick           `json:"ticks"`
+	Tags       []string         `json:"tags"`
+	Limits     map[uint8]uint64 `json:"limits"`
+	Extra      json.RawMessage  `json:"extra"`
+	Ignored    string           `json:"-"`
+}
+
+// TestDecodeMoveValue tests Move content maps onto tagged Go struct
+//
+// TestDecodeMoveValue 测试 Move 内容映射到带标签的 Go 结构体
+func TestDecodeMoveValue(t *testing.T) {
+	object := &suiapi.ObjectData{
+		ObjectId: "0x23278eef11e2556767914beaef093b6945178510363fe871d79a97bfd2666fea",
+		Content:  &suiapi.MoveContent{DataType: suiapi.DataTypeMoveObject, Type: "0xabc::pool::Pool", Fields: json.RawMessage(poolFields)},
+	}
+	pool, err := suiapi.DecodeMoveObject[Pool](object)
+	require.NoError(t, err)
+	require.Equal(t, object.ObjectId, pool.Id.Id)
+	require.Equal(t, address, pool.Admin)
+	require.Equal(t, uint8(25), pool.FeeRate)
+	require.False(t, pool.Paused)
+	require.Equal(t, "USDC-SUI", pool.Name)
+	require.Equal(t, uint64(1_500_000_000_000), pool.ReserveX)
+	require.Nil(t, pool.Liquidity, "field without tag matches by Go name only")
+	require.Equal(t, "18446744073709551616", pool.SqrtPrice.String())
+	require.Equal(t, uint64(402553018), pool.BalanceY.Value)
+	require.Nil(t, pool.OwnerCap)
+	require.Equal(t, &Reward{Amount: 7, CoinType: "0x2::sui::SUI"}, pool.Reward)
+	require.Nil(t, pool.RewardHint)
+	require.Equal(t, []Tick{{Index: 4294967294, LiquidityNet: "100"}, {Index: 12, LiquidityNet: "200"}}, pool.Ticks)
+	require.Equal(t, []string{"stable", "hot"}, pool.Tags)
+	require.Equal(t, map[uint8]uint64{1: 1000, 2: 2000}, pool.Limits)
+	require.JSONEq(t, `{"note": "kept raw"}`, string(pool.Extra))
+
+	type Liquidity struct {
+		Liquidity *big.Int `json:"liquidity"`
+		Id        string   `json:"id"`
+	}
+	liquidity, err := suiapi.DecodeMoveValue[Liquidity](json.RawMessage(poolFields))
+	require.NoError(t, err)
+	require.Equal(t, "340282366920938463463374607431768211455", liquidity.Liquidity.String())
+	require.Equal(t, object.ObjectId, liquidity.Id)
+
+	// Wrong shapes and overflows report the field path
+	// 形式错误和溢出会报告字段路径
+	_, err = suiapi.DecodeMoveValue[struct {
+		ReserveX uint32 `json:"reserve_x"`
+	}](json.RawMessage(poolFields))
+	require.ErrorContains(t, err, "$.reserve_x")
+	_, err = suiapi.DecodeMoveValue[struct {
+		Ticks []uint64 `json:"ticks"`
+	}](json.RawMessage(poolFields))
+	require.ErrorContains(t, err, "$.ticks[0]")
+
+	// Interfaces with methods cannot hold JSON, any takes it as is
+	// 带方法的接口无法保存 JSON，any 按原样接收
+	_, err = suiapi.DecodeMoveValue[struct {
+		Name fmt.Stringer `json:"name"`
+	}](json.RawMessage(poolFields))
+	require.ErrorContains(t, err, "$.name: unsupported Go type fmt.Stringer")
+	anything, err := suiapi.DecodeMoveValue[struct {
+		Name any `json:"name"`
+	}](json.RawMessage(poolFields))
+	require.NoError(t, err)
+	require.Equal(t, "USDC-SUI", anything.Name)
+
+	// Structs with own type and fields keys stay when top-level or when type is no struct tag
+	// 自带 type 和 fields 键的结构体在顶层或 type 不是结构体标签时保持不变
+	type Shape struct {
+		Type   string            `json:"type"`
+		Fields map[string]string `json:"fields"`
+	}
+	shape, err := suiapi.DecodeMoveValue[Shape](json.RawMessage(`{"type": "0x2::sui::SUI", "fields": {"a": "b"}}`))
+	require.NoError(t, err)
+	require.Equal(t, Shape{Type: "0x2::sui::SUI", Fields: map[string]string{"a": "b"}}, *shape)
+	holder, err := suiapi.DecodeMoveValue[struct {
+		Shape Shape `json:"shape"`
+	}](json.RawMessage(`{"shape": {"type": "swap", "fields": {"a": "b"}}}`))
+	require.NoError(t, err)
+	require.Equal(t, Shape{Type: "swap", Fields: map[string]string{"a": "b"}}, holder.Shape)
+
+	_, err = suiapi.DecodeMoveObject[Pool](&suiapi.ObjectData{ObjectId: object.ObjectId})
+	require.Error(t, err)
+	_, err = suiapi.DecodeMoveObject[Pool](&suiapi.ObjectData{ObjectId: object.ObjectId, Content: &suiapi.MoveContent{DataType: suiapi.DataTypePackage}})
+	require.Error(t, err)
+}
+
+// TestClient_Objects tests object reads, past versions and owned object pages against the simulator
+//
+// TestClient_Objects 针对模拟器测试对象读取、历史版本和拥有对象分页
+func TestClient_Objects(t *testing.T) {
+	const coinType = "0x5d4b302506645c37ff133b98c4b50a5ae14841659738d6d733d59d0d217a93bf::coin::COIN"
+
+	server := newSimulator(t)
+	client := suiapi.NewClient(server.Client())
+	ctx := context.Background()
+
+	coin := server.Mint(address, 100_000_000)
+	other := server.Mint(address, 5_000)
+	server.MintCoin(address, coinType, 7)
+
+	response, err := client.GetObject(ctx, coin.CoinObjectId, suiapi.FullObjectDataOptions())
+	require.NoError(t, err)
+	object, err := response.Object()
+	require.NoError(t, err)
+	require.Equal(t, "0x2::coin::Coin<0x2::sui::SUI>", object.Type)
+	require.Equal(t, address, object.Owner.AddressOwner)
+	require.Equal(t, coin.PreviousTransaction, object.PreviousTransaction)
+	require.NotEmpty(t, object.Bcs.BcsBytes)
+
+	type Coin struct {
+		Id      suiapi.UID `json:"id"`
+		Balance uint64     `json:"balance"`
+	}
+	decoded, err := suiapi.DecodeMoveObject[Coin](object)
+	require.NoError(t, err)
+	require.Equal(t, Coin{Id: suiapi.UID{Id: coin.CoinObjectId}, Balance: 100_000_000}, *decoded)
+
+	// Bare lookup returns reference only
+	// 不带选项的查询仅返回引用
+	response, err = client.GetObject(ctx, coin.CoinObjectId, nil)
+	require.NoError(t, err)
+	require.Nil(t, response.Data.Content)
+	require.Nil(t, response.Data.Owner)
+
+	// Merge deletes the second coin and bumps the first one
+	// 合并会删除第二个代币并提升第一个代币的版本
+	built, err := client.BuildMergeCoins(ctx, address, suiapi.SuiCoinType, 10_000_000)
+	require.NoError(t, err)
+	execute(t, server.Client(), built.TxBytes)
+
+	responses, err := client.MultiGetObjects(ctx, []string{coin.CoinObjectId, other.CoinObjectId, "0x1234"}, &suiapi.ObjectDataOptions{ShowContent: true})
+	require.NoError(t, err)
+	require.Len(t, responses, 3)
+	require.Equal(t, "2", responses[0].Data.Version)
+	require.Equal(t, "deleted", responses[1].Error.Code)
+	_, err = responses[2].Object()
+	require.ErrorIs(t, err, suirpc.ErrObjectNotFound)
+
+	past, err := client.TryGetPastObject(ctx, coin.CoinObjectId, 1, &suiapi.ObjectDataOptions{ShowContent: true})
+	require.NoError(t, err)
+	require.Equal(t, suiapi.PastObjectVersionFound, past.Status)
+	pastObject, err := past.Object()
+	require.NoError(t, err)
+	decoded, err = suiapi.DecodeMoveObject[Coin](pastObject)
+	require.NoError(t, err)
+	require.Equal(t, uint64(100_000_000), decoded.Balance)
+
+	pasts, err := client.TryMultiGetPastObjects(ctx, []*suiapi.PastObjectRequest{
+		{ObjectId: coin.CoinObjectId, Version: 9},
+		{ObjectId: other.CoinObjectId, Version: 2},
+		{ObjectId: "0x1234", Version: 1},
+	}, nil)
+	require.NoError(t, err)
+	require.Equal(t, suiapi.PastObjectVersionTooHigh, pasts[0].Status)
+	require.Equal(t, suiapi.PastObjectDeleted, pasts[1].Status)
+	require.Equal(t, suiapi.PastObjectNotExists, pasts[2].Status)
+	_, err = pasts[1].Object()
+	require.ErrorIs(t, err, suirpc.ErrObjectNotFound)
+
+	page, err := client.GetOwnedObjects(ctx, address, &suiapi.ObjectResponseQuery{
+		Filter:  map[string]string{"StructType": "0x2::coin::Coin<" + coinType + ">"},
+		Options: &suiapi.ObjectDataOptions{ShowType: true, ShowOwner: true},
+	}, nil, 0)
+	require.NoError(t, err)
+	require.Len(t, page.Data, 1)
+	require.Equal(t, suiapi.AddressOwnerOf(address), *page.Data[0].Data.Owner)
+}
+
+// TestClient_PaginateOwnedObjects_ErrorItem tests error items keep cursor of the object before
+//
+// TestClient_PaginateOwnedObjects_ErrorItem 测试错误条目沿用前一个对象的游标
+func TestClient_PaginateOwnedObjects_ErrorItem(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Id json.RawMessage `json:"id"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"jsonrpc": "2.0", "id": ` + string(request.Id) + `, "result": {
+			"data": [{"data": {"objectId": "0xa", "version": "1", "digest": "d"}}, {"error": {"code": "displayError", "error": "bad display"}}],
+			"nextCursor": "0xa",
+			"hasNextPage": true
+		}}`))
+	}))
+	t.Cleanup(server.Close)
+	client := suiapi.NewClient(suirpc.NewClient(server.URL))
+
+	paginator := client.PaginateOwnedObjects(address, nil, suiapi.PageOptions[string]{MaxItems: 2})
+	objects, err := paginator.All(context.Background())
+	require.NoError(t, err)
+	require.Len(t, objects, 2)
+	require.Equal(t, "displayError", objects[1].Error.Code)
+	require.Equal(t, "0xa", *paginator.Cursor())
+}
@@ -0,0 +1,130 @@
+package suiapi
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/yyle88/erero"
+)
+
+// ObjectOwner represents owner of object or balance, exactly one variant is set
+// JSON forms: {"AddressOwner": "0x.."}, {"ObjectOwner": "0x.."}, {"Shared": {...}}, "Immutable"
+// and {"ConsensusAddressOwner": {...}} of newer protocol versions
+//
+// ObjectOwner 表示对象或余额的所有者，恰好设置一个变体
+// JSON 形式：{"AddressOwner": "0x.."}、{"ObjectOwner": "0x.."}、{"Shared": {...}}、"Immutable"
+// 以及较新协议版本的 {"ConsensusAddressOwner": {...}}
+type ObjectOwner struct {
+	AddressOwner          string                 // Owned by address // 归地址所有
+	ObjectOwner           string                 // Owned by parent object, such as dynamic field // 归父对象所有，例如动态字段
+	Shared                *SharedOwner           // Shared object // 共享对象
+	Immutable             bool                   // Frozen object such as package // 冻结的对象，例如包
+	ConsensusAddressOwner *ConsensusAddressOwner // Address-owned object sequenced by consensus // 由共识排序的地址所有对象
+}
+
+// SharedOwner represents shared object ownership
+//
+// SharedOwner 表示共享对象的所有权
+type SharedOwner struct {
+	InitialSharedVersion uint64 `json:"initial_shared_version"` // Version when object became shared // 对象变为共享时的版本
+}
+
+// ConsensusAddressOwner represents address ownership sequenced by consensus
+//
+// ConsensusAddressOwner 表示由共识排序的地址所有权
+type ConsensusAddressOwner struct {
+	StartVersion uint64 `json:"start_version"` // Version when ownership started // 所有权开始时的版本
+	Owner        string `json:"owner"`         // Owner address // 所有者地址
+}
+
+// AddressOwnerOf returns owner variant of address
+//
+// AddressOwnerOf 返回地址所有者变体
+func AddressOwnerOf(address string) ObjectOwner {
+	return ObjectOwner{AddressOwner: address}
+}
+
+// Owner returns address or parent object ID owning the object, empty when shared or immutable
+//
+// Owner 返回拥有对象的地址或父对象 ID，共享或不可变时为空
+func (owner ObjectOwner) Owner() string {
+	switch {
+	case owner.AddressOwner != "":
+		return owner.AddressOwner
+	case owner.ConsensusAddressOwner != nil:
+		return owner.ConsensusAddressOwner.Owner
+	default:
+		return owner.ObjectOwner
+	}
+}
+
+// IsZero checks if no variant is set, such as owner missing from response
+//
+// IsZero 检查是否未设置任何变体，例如响应中缺少所有者
+func (owner ObjectOwner) IsZero() bool {
+	return owner.AddressOwner == "" && owner.ObjectOwner == "" && owner.Shared == nil && !owner.Immutable && owner.ConsensusAddressOwner == nil
+}
+
+// MarshalJSON encodes owner in fullnode form
+//
+// MarshalJSON 以全节点形式编码所有者
+func (owner ObjectOwner) MarshalJSON() ([]byte, error) {
+	switch {
+	case owner.Immutable:
+		return []byte(`"Immutable"`), nil
+	case owner.Shared != nil:
+		return json.Marshal(map[string]*SharedOwner{"Shared": owner.Shared})
+	case owner.ConsensusAddressOwner != nil:
+		return json.Marshal(map[string]*ConsensusAddressOwner{"ConsensusAddressOwner": owner.ConsensusAddressOwner})
+	case owner.ObjectOwner != "":
+		return json.Marshal(map[string]string{"ObjectOwner": owner.ObjectOwner})
+	case owner.AddressOwner != "":
+		return json.Marshal(map[string]string{"AddressOwner": owner.AddressOwner})
+	default:
+		return []byte("null"), nil
+	}
+}
+
+// UnmarshalJSON decodes owner from fullnode form
+//
+// UnmarshalJSON 从全节点形式解码所有者
+func (owner *ObjectOwner) UnmarshalJSON(data []byte) error {
+	*owner = ObjectOwner{}
+	data = bytes.TrimSpace(data)
+	if string(data) == "null" {
+		return nil
+	}
+	if data[0] == '"' {
+		var name string
+		if err := json.Unmarshal(data, &name); err != nil {
+			return erero.Wro(err)
+		}
+		if name != "Immutable" {
+			return erero.Errorf("unknown owner %q", name)
+		}
+		owner.Immutable = true
+		return nil
+	}
+	var variant struct {
+		AddressOwner          *string                `json:"AddressOwner"`
+		ObjectOwner           *string                `json:"ObjectOwner"`
+		Shared                *SharedOwner           `json:"Shared"`
+		ConsensusAddressOwner *ConsensusAddressOwner `json:"ConsensusAddressOwner"`
+	}
+	if err := json.Unmarshal(data, &variant); err != nil {
+		return erero.Wro(err)
+	}
+	switch {
+	case variant.AddressOwner != nil:
+		owner.AddressOwner = *variant.AddressOwner
+	case variant.ObjectOwner != nil:
+		owner.ObjectOwner = *variant.ObjectOwner
+	case variant.Shared != nil:
+		owner.Shared = variant.Shared
+	case variant.ConsensusAddressOwner != nil:
+		owner.ConsensusAddressOwner = variant.ConsensusAddressOwner
+	default:
+		return erero.Errorf("unknown owner %s", data)
+	}
+	return nil
+}
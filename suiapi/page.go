@@ -0,0 +1,178 @@
+package suiapi
+
+import (
+	"context"
+	"errors"
+
+	"github.com/yyle88/erero"
+)
+
+// MaxPageSize is the largest page fullnodes accept, bigger limits get rejected
+//
+// MaxPageSize 是全节点接受的最大分页大小，更大的数量上限会被拒绝
+const MaxPageSize = 50
+
+// ErrStopPaging stops ForEach without error when returned by the callback
+//
+// ErrStopPaging 由回调返回时使 ForEach 无错误地停止
+var ErrStopPaging = errors.New("stop paging")
+
+// Page represents one page of cursor-paginated query result
+// NextCursor resumes the query at the next page when HasNextPage is true
+//
+// Page 表示游标分页查询结果的一页
+// HasNextPage 为 true 时 NextCursor 用于从下一页继续查询
+type Page[ITEM any, CURSOR any] struct {
+	Data        []*ITEM `json:"data"`        // Items in current page // 当前页的条目
+	NextCursor  *CURSOR `json:"nextCursor"`  // Cursor of last item, nil when page is empty // 最后一项的游标，页为空时为 nil
+	HasNextPage bool    `json:"hasNextPage"` // More items follow // 后面还有条目
+}
+
+// PageFunc fetches one page after cursor, nil cursor means first page
+//
+// PageFunc 获取游标之后的一页，nil 游标表示第一页
+type PageFunc[ITEM any, CURSOR any] func(ctx context.Context, cursor *CURSOR, limit int, descending bool) (*Page[ITEM, CURSOR], error)
+
+// PageOptions represents paging settings shared by each paginator
+//
+// PageOptions 表示各分页器共用的分页配置
+type PageOptions[CURSOR any] struct {
+	PageSize   int     // Items per call, zero lets node pick, capped at MaxPageSize // 每次调用的条目数，零表示由节点决定，上限为 MaxPageSize
+	Descending bool    // Newest first, honored by query methods only // 最新的在前，仅查询类方法支持
+	Cursor     *CURSOR // Saved cursor to resume after, nil starts at the beginning // 用于继续的已保存游标，nil 表示从头开始
+	MaxItems   int     // Stop after this many items, zero means no cap // 达到该条目数后停止，零表示不限
+}
+
+// Paginator walks cursor-paginated results item by item, fetching pages on demand
+// Use Next/Item/Err as iterator, or ForEach and All
+// Cursor gives the point to resume at after stopping early
+//
+// Paginator 逐条遍历游标分页结果，按需获取分页
+// 可将 Next/Item/Err 作为迭代器使用，也可使用 ForEach 和 All
+// 提前停止后 Cursor 给出继续的位置
+type Paginator[ITEM any, CURSOR any] struct {
+	fetch      PageFunc[ITEM, CURSOR]          // Page fetch // 分页获取函数
+	cursorOf   func(item *ITEM) (CURSOR, bool) // Cursor pointing at item, false when item has none // 指向条目的游标，条目没有游标时为 false
+	options    PageOptions[CURSOR]             // Paging settings // 分页配置
+	pageCursor *CURSOR                         // Cursor of next page fetch // 下一次分页获取的游标
+	cursor     *CURSOR                         // Cursor after last consumed item // 最后消费条目之后的游标
+	items      []*ITEM                         // Rest of current page // 当前页剩余的条目
+	item       *ITEM                           // Current item // 当前条目
+	count      int                             // Items consumed // 已消费的条目数
+	hasNext    bool                            // Node has more pages // 节点还有更多分页
+	err        error                           // Failure stopping the walk // 终止遍历的失败
+}
+
+// NewPaginator creates paginator over fetch, cursorOf returns cursor pointing at given item
+// Items where cursorOf reports false, such as error entries, keep cursor of the item before
+//
+// NewPaginator 基于 fetch 创建分页器，cursorOf 返回指向给定条目的游标
+// cursorOf 返回 false 的条目（例如错误条目）沿用前一个条目的游标
+func NewPaginator[ITEM any, CURSOR any](fetch PageFunc[ITEM, CURSOR], cursorOf func(item *ITEM) (CURSOR, bool), options PageOptions[CURSOR]) *Paginator[ITEM, CURSOR] {
+	return &Paginator[ITEM, CURSOR]{
+		fetch:      fetch,
+		cursorOf:   cursorOf,
+		options:    options,
+		pageCursor: options.Cursor,
+		cursor:     options.Cursor,
+		hasNext:    true,
+	}
+}
+
+// Next moves to next item, fetching next page when current one runs out
+// Returns false at the end, at MaxItems, on failure or when ctx is done, check Err after
+//
+// Next 移动到下一个条目，当前页用完时获取下一页
+// 到达末尾、达到 MaxItems、失败或 ctx 结束时返回 false，之后检查 Err
+func (p *Paginator[ITEM, CURSOR]) Next(ctx context.Context) bool {
+	p.item = nil
+	if p.err != nil || (p.options.MaxItems > 0 && p.count >= p.options.MaxItems) {
+		return false
+	}
+	if err := ctx.Err(); err != nil {
+		p.err = erero.Wro(err)
+		return false
+	}
+	for len(p.items) == 0 {
+		if !p.hasNext {
+			return false
+		}
+		page, err := p.fetch(ctx, p.pageCursor, p.limit(), p.options.Descending)
+		if err != nil {
+			p.err = erero.Wro(err)
+			return false
+		}
+		p.items = page.Data
+		p.pageCursor = page.NextCursor
+		// Empty page or missing cursor ends the walk instead of asking the same page again
+		// 空页或缺少游标时结束遍历，避免重复请求同一页
+		p.hasNext = page.HasNextPage && page.NextCursor != nil && len(page.Data) > 0
+	}
+	p.item, p.items = p.items[0], p.items[1:]
+	p.count++
+	if cursor, ok := p.cursorOf(p.item); ok {
+		p.cursor = &cursor
+	}
+	return true
+}
+
+// limit returns page size of next fetch, shrunk to items left before MaxItems
+//
+// limit 返回下一次获取的分页大小，按 MaxItems 前剩余的条目数缩小
+func (p *Paginator[ITEM, CURSOR]) limit() int {
+	limit := min(max(p.options.PageSize, 0), MaxPageSize)
+	if p.options.MaxItems > 0 {
+		remaining := min(p.options.MaxItems-p.count, MaxPageSize)
+		if limit == 0 || limit > remaining {
+			limit = remaining
+		}
+	}
+	return limit
+}
+
+// Item returns current item, valid after Next returns true
+//
+// Item 返回当前条目，在 Next 返回 true 后有效
+func (p *Paginator[ITEM, CURSOR]) Item() *ITEM {
+	return p.item
+}
+
+// Err returns failure stopping the walk, nil when it ended normally
+//
+// Err 返回终止遍历的失败，正常结束时为 nil
+func (p *Paginator[ITEM, CURSOR]) Err() error {
+	return p.err
+}
+
+// Cursor returns cursor after last consumed item, save it as PageOptions.Cursor to resume
+//
+// Cursor 返回最后消费条目之后的游标，保存为 PageOptions.Cursor 即可继续
+func (p *Paginator[ITEM, CURSOR]) Cursor() *CURSOR {
+	return p.cursor
+}
+
+// ForEach calls fn on each item until the end, fn returning ErrStopPaging stops without error
+//
+// ForEach 对每个条目调用 fn 直到末尾，fn 返回 ErrStopPaging 时无错误地停止
+func (p *Paginator[ITEM, CURSOR]) ForEach(ctx context.Context, fn func(item *ITEM) error) error {
+	for p.Next(ctx) {
+		if err := fn(p.item); err != nil {
+			if errors.Is(err, ErrStopPaging) {
+				return nil
+			}
+			return erero.Wro(err)
+		}
+	}
+	return p.err
+}
+
+// All collects the remaining items, returns items gathered so far along with failure
+//
+// All 收集剩余的条目，失败时同时返回已收集的条目
+func (p *Paginator[ITEM, CURSOR]) All(ctx context.Context) ([]*ITEM, error) {
+	var items []*ITEM
+	for p.Next(ctx) {
+		items = append(items, p.item)
+	}
+	return items, p.err
+}
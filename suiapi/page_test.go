@@ -0,0 +1,162 @@
+package suiapi_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-xlan/sui-go-guide/suiapi"
+	"github.com/stretchr/testify/require"
+)
+
+// numberPages returns page func over numbers 1..total in either order, counting calls and limits
+//
+// numberPages 返回按任一顺序遍历 1..total 的分页函数，并记录调用次数和数量上限
+func numberPages(total int, limits *[]int) suiapi.PageFunc[int, int] {
+	return func(ctx context.Context, cursor *int, limit int, descending bool) (*suiapi.Page[int, int], error) {
+		*limits = append(*limits, limit)
+		if limit == 0 {
+			limit = 3
+		}
+		var data []*int
+		for idx := range total {
+			number := idx + 1
+			if descending {
+				number = total - idx
+			}
+			if cursor != nil && ((!descending && number <= *cursor) || (descending && number >= *cursor)) {
+				continue
+			}
+			if len(data) == limit {
+				return &suiapi.Page[int, int]{Data: data, NextCursor: data[len(data)-1], HasNextPage: true}, nil
+			}
+			data = append(data, &number)
+		}
+		page := &suiapi.Page[int, int]{Data: data}
+		if len(data) > 0 {
+			page.NextCursor = data[len(data)-1]
+		}
+		return page, nil
+	}
+}
+
+// numberCursor returns number as its own cursor
+//
+// numberCursor 返回数字本身作为游标
+func numberCursor(number *int) (int, bool) {
+	return *number, true
+}
+
+// TestPaginator tests page size, order, max items, resume and stop handling
+//
+// TestPaginator 测试分页大小、顺序、最大条目数、继续和停止处理
+func TestPaginator(t *testing.T) {
+	ctx := context.Background()
+
+	var limits []int
+	numbers, err := suiapi.NewPaginator(numberPages(10, &limits), numberCursor, suiapi.PageOptions[int]{PageSize: 4}).All(ctx)
+	require.NoError(t, err)
+	require.Len(t, numbers, 10)
+	require.Equal(t, 10, *numbers[9])
+	require.Equal(t, []int{4, 4, 4}, limits)
+
+	// Max items shrinks the last page and leaves cursor to resume at
+	// 最大条目数会缩小最后一页，并留下继续所用的游标
+	limits = nil
+	paginator := suiapi.NewPaginator(numberPages(10, &limits), numberCursor, suiapi.PageOptions[int]{PageSize: 4, Descending: true, MaxItems: 6})
+	numbers, err = paginator.All(ctx)
+	require.NoError(t, err)
+	require.Len(t, numbers, 6)
+	require.Equal(t, 10, *numbers[0])
+	require.Equal(t, []int{4, 2}, limits)
+	require.Equal(t, 5, *paginator.Cursor())
+
+	numbers, err = suiapi.NewPaginator(numberPages(10, &limits), numberCursor, suiapi.PageOptions[int]{Descending: true, Cursor: paginator.Cursor()}).All(ctx)
+	require.NoError(t, err)
+	require.Len(t, numbers, 4)
+	require.Equal(t, 4, *numbers[0])
+
+	// Callback stops in the middle of page, cursor points at last item handled
+	// 回调在页中间停止，游标指向最后处理的条目
+	paginator = suiapi.NewPaginator(numberPages(10, &limits), numberCursor, suiapi.PageOptions[int]{})
+	var seen []int
+	require.NoError(t, paginator.ForEach(ctx, func(number *int) error {
+		seen = append(seen, *number)
+		if *number == 5 {
+			return suiapi.ErrStopPaging
+		}
+		return nil
+	}))
+	require.Equal(t, []int{1, 2, 3, 4, 5}, seen)
+	require.Equal(t, 5, *paginator.Cursor())
+
+	failure := errors.New("boom")
+	err = suiapi.NewPaginator(numberPages(10, &limits), numberCursor, suiapi.PageOptions[int]{}).ForEach(ctx, func(number *int) error {
+		return failure
+	})
+	require.ErrorIs(t, err, failure)
+
+	// Canceled context stops the walk with context error
+	// 取消的上下文以上下文错误停止遍历
+	cancelCtx, cancel := context.WithCancel(ctx)
+	paginator = suiapi.NewPaginator(numberPages(10, &limits), numberCursor, suiapi.PageOptions[int]{})
+	require.True(t, paginator.Next(cancelCtx))
+	cancel()
+	require.False(t, paginator.Next(cancelCtx))
+	require.ErrorIs(t, paginator.Err(), context.Canceled)
+	require.Equal(t, 1, *paginator.Cursor())
+
+	// Items without cursor keep cursor of the item before
+	// 没有游标的条目沿用前一个条目的游标
+	evenless := func(number *int) (int, bool) {
+		return *number, *number%2 == 1
+	}
+	paginator = suiapi.NewPaginator(numberPages(10, &limits), evenless, suiapi.PageOptions[int]{MaxItems: 4})
+	numbers, err = paginator.All(ctx)
+	require.NoError(t, err)
+	require.Len(t, numbers, 4)
+	require.Equal(t, 3, *paginator.Cursor())
+
+	paginator = suiapi.NewPaginator(numberPages(10, &limits), evenless, suiapi.PageOptions[int]{Cursor: paginator.Cursor()})
+	require.True(t, paginator.Next(ctx))
+	require.Equal(t, 4, *paginator.Item())
+	require.Equal(t, 3, *paginator.Cursor())
+}
+
+// TestClient_PaginateCoins tests coin paginator walks wallets bigger than one page
+//
+// TestClient_PaginateCoins 测试代币分页器遍历超过一页的钱包
+func TestClient_PaginateCoins(t *testing.T) {
+	server := newSimulator(t)
+	client := suiapi.NewClient(server.Client())
+	ctx := context.Background()
+
+	for range 120 {
+		server.Mint(address, 10)
+	}
+	server.MintCoin(address, "0x5d4b302506645c37ff133b98c4b50a5ae14841659738d6d733d59d0d217a93bf::coin::COIN", 7)
+
+	page, err := client.GetCoins(ctx, address, "", nil, 0)
+	require.NoError(t, err)
+	require.Len(t, page.Data, 50)
+	require.True(t, page.HasNextPage)
+
+	coins, err := client.PaginateCoins(address, "", suiapi.PageOptions[string]{}).All(ctx)
+	require.NoError(t, err)
+	require.Len(t, coins, 120)
+	unique := map[string]bool{}
+	for _, coin := range coins {
+		unique[coin.CoinObjectId] = true
+	}
+	require.Len(t, unique, 120)
+
+	paginator := client.PaginateAllCoins(address, suiapi.PageOptions[string]{PageSize: 20, MaxItems: 70})
+	coins, err = paginator.All(ctx)
+	require.NoError(t, err)
+	require.Len(t, coins, 70)
+
+	rest, err := client.PaginateAllCoins(address, suiapi.PageOptions[string]{Cursor: paginator.Cursor()}).All(ctx)
+	require.NoError(t, err)
+	require.Len(t, rest, 51)
+	require.NotEqual(t, coins[69].CoinObjectId, rest[0].CoinObjectId)
+}
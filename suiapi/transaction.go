@@ -0,0 +1,489 @@
+package suiapi
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/yyle88/erero"
+)
+
+// SuiTransactionBlockResponse represents transaction block returned by get, execute and dry run methods
+// Sections come back only when asked for in response options
+//
+// SuiTransactionBlockResponse 表示查询、执行和模拟执行方法返回的交易区块
+// 各部分仅在响应选项中请求时返回
+type SuiTransactionBlockResponse struct {
+	Digest                  string               `json:"digest"`                            // Transaction digest // 交易摘要
+	Transaction             *SuiTransactionBlock `json:"transaction,omitempty"`             // Transaction data and signatures // 交易数据和签名
+	RawTransaction          string               `json:"rawTransaction,omitempty"`          // BCS bytes of signed transaction in Base64 // 已签名交易的 Base64 BCS 字节
+	Effects                 *TransactionEffects  `json:"effects,omitempty"`                 // Execution effects // 执行效果
+	Events                  []*SuiEvent          `json:"events,omitempty"`                  // Emitted events // 发出的事件
+	ObjectChanges           []*ObjectChange      `json:"objectChanges,omitempty"`           // Object changes // 对象变更
+	BalanceChanges          []*BalanceChange     `json:"balanceChanges,omitempty"`          // Balance changes // 余额变更
+	TimestampMs             uint64               `json:"timestampMs,string,omitempty"`      // Checkpoint time in milliseconds // 检查点时间，单位毫秒
+	Checkpoint              uint64               `json:"checkpoint,string,omitempty"`       // Checkpoint sequence number // 检查点序号
+	ConfirmedLocalExecution *bool                `json:"confirmedLocalExecution,omitempty"` // Local execution confirmed by node // 节点确认已在本地执行
+	Errors                  []string             `json:"errors,omitempty"`                  // Errors met while loading sections // 加载各部分时遇到的错误
+	RawEffects              []int                `json:"rawEffects,omitempty"`              // BCS bytes of effects // 效果的 BCS 字节
+}
+
+// TransactionBlockResponseOptions represents sections returned along with transaction block
+// Shared by execute, get, multi get and query methods, zero value returns digest only
+//
+// TransactionBlockResponseOptions 表示随交易区块一起返回的部分
+// 由执行、查询、批量查询和条件查询方法共用，零值仅返回摘要
+type TransactionBlockResponseOptions struct {
+	ShowInput          bool `json:"showInput,omitempty"`          // Include transaction input data // 包含交易输入数据
+	ShowRawInput       bool `json:"showRawInput,omitempty"`       // Include raw input bytes // 包含原始输入字节
+	ShowEffects        bool `json:"showEffects,omitempty"`        // Include transaction effects // 包含交易效果
+	ShowEvents         bool `json:"showEvents,omitempty"`         // Include emitted events // 包含发出的事件
+	ShowObjectChanges  bool `json:"showObjectChanges,omitempty"`  // Include object changes // 包含对象变更
+	ShowBalanceChanges bool `json:"showBalanceChanges,omitempty"` // Include balance changes // 包含余额变更
+	ShowRawEffects     bool `json:"showRawEffects,omitempty"`     // Include raw effects data // 包含原始效果数据
+}
+
+// ReadResponseOptions returns options showing each decoded section, raw bytes left out
+//
+// ReadResponseOptions 返回展示每个已解码部分的选项，不含原始字节
+func ReadResponseOptions() *TransactionBlockResponseOptions {
+	return &TransactionBlockResponseOptions{
+		ShowInput:          true,
+		ShowEffects:        true,
+		ShowEvents:         true,
+		ShowObjectChanges:  true,
+		ShowBalanceChanges: true,
+	}
+}
+
+// FullResponseOptions returns options showing each section including raw bytes
+//
+// FullResponseOptions 返回展示包括原始字节在内的每个部分的选项
+func FullResponseOptions() *TransactionBlockResponseOptions {
+	options := ReadResponseOptions()
+	options.ShowRawInput = true
+	options.ShowRawEffects = true
+	return options
+}
+
+// RequiresLocalExecution checks if options ask for sections only known after local execution
+// Object and balance changes need it, so WaitForEffectsCert can not be used along with them
+//
+// RequiresLocalExecution 检查选项是否请求了只有本地执行后才知道的部分
+// 对象变更和余额变更需要本地执行，因此不能与 WaitForEffectsCert 一起使用
+func (options *TransactionBlockResponseOptions) RequiresLocalExecution() bool {
+	return options != nil && (options.ShowObjectChanges || options.ShowBalanceChanges)
+}
+
+// TransactionBlockResponseQuery represents transaction query with filter and response options
+// Nil filter matches each transaction
+//
+// TransactionBlockResponseQuery 表示带过滤条件和响应选项的交易查询
+// Filter 为 nil 时匹配每笔交易
+type TransactionBlockResponseQuery struct {
+	Filter  *TransactionFilter               `json:"filter,omitempty"`  // Transaction filter // 交易过滤条件
+	Options *TransactionBlockResponseOptions `json:"options,omitempty"` // Response options // 响应选项
+}
+
+// Succeeded checks if effects report success status
+//
+// Succeeded 检查效果是否报告成功状态
+func (tx *SuiTransactionBlockResponse) Succeeded() bool {
+	return tx.Effects != nil && tx.Effects.Status.Status == "success"
+}
+
+// SuiTransactionBlock represents transaction data along with its signatures
+//
+// SuiTransactionBlock 表示交易数据及其签名
+type SuiTransactionBlock struct {
+	Data         TransactionBlockData `json:"data"`         // Transaction data // 交易数据
+	TxSignatures []string             `json:"txSignatures"` // Serialized signatures in Base64 // Base64 编码的序列化签名
+}
+
+// TransactionBlockData represents sender, gas and kind of transaction
+//
+// TransactionBlockData 表示交易的发送方、gas 和种类
+type TransactionBlockData struct {
+	MessageVersion string               `json:"messageVersion"` // Data format version such as v1 // 数据格式版本，例如 v1
+	Transaction    TransactionBlockKind `json:"transaction"`    // Transaction kind // 交易种类
+	Sender         string               `json:"sender"`         // Sender address // 发送方地址
+	GasData        GasData              `json:"gasData"`        // Gas payment // gas 支付
+}
+
+// GasData represents gas coins, owner, price and budget of transaction
+//
+// GasData 表示交易的 gas 代币、所有者、价格和预算
+type GasData struct {
+	Payment []*ObjectRef `json:"payment"` // Gas coins // gas 代币
+	Owner   string       `json:"owner"`   // Gas owner, sponsor when not sender // gas 所有者，不是发送方时为赞助方
+	Price   string       `json:"price"`   // Gas price in MIST // gas 价格，单位 MIST
+	Budget  string       `json:"budget"`  // Gas budget in MIST // gas 预算，单位 MIST
+}
+
+// ProgrammableTransactionKind is kind of transactions built by users, other kinds come from the system
+//
+// ProgrammableTransactionKind 是用户构建的交易种类，其他种类来自系统
+const ProgrammableTransactionKind = "ProgrammableTransaction"
+
+// TransactionBlockKind represents transaction kind
+// Programmable transactions decode into Inputs and Transactions
+// System kinds such as ChangeEpoch and ConsensusCommitPrologue keep their JSON in Raw
+//
+// TransactionBlockKind 表示交易种类
+// 可编程交易解码为 Inputs 和 Transactions
+// ChangeEpoch 和 ConsensusCommitPrologue 等系统种类将其 JSON 保留在 Raw 中
+type TransactionBlockKind struct {
+	Kind         string          // Kind name // 种类名称
+	Inputs       []*CallArg      // Inputs of programmable transaction // 可编程交易的输入
+	Transactions []*Command      // Commands of programmable transaction // 可编程交易的命令
+	Raw          json.RawMessage // Whole JSON of system kinds // 系统种类的完整 JSON
+}
+
+// programmableTransaction is JSON form of programmable transaction kind
+//
+// programmableTransaction 是可编程交易种类的 JSON 形式
+type programmableTransaction struct {
+	Kind         string     `json:"kind"`
+	Inputs       []*CallArg `json:"inputs"`
+	Transactions []*Command `json:"transactions"`
+}
+
+// MarshalJSON encodes kind in fullnode form
+//
+// MarshalJSON 以全节点形式编码交易种类
+func (kind TransactionBlockKind) MarshalJSON() ([]byte, error) {
+	if kind.Kind != ProgrammableTransactionKind && kind.Raw != nil {
+		return kind.Raw, nil
+	}
+	return json.Marshal(programmableTransaction{Kind: kind.Kind, Inputs: kind.Inputs, Transactions: kind.Transactions})
+}
+
+// UnmarshalJSON decodes kind from fullnode form
+//
+// UnmarshalJSON 从全节点形式解码交易种类
+func (kind *TransactionBlockKind) UnmarshalJSON(data []byte) error {
+	var value programmableTransaction
+	if err := json.Unmarshal(data, &struct {
+		Kind *string `json:"kind"`
+	}{Kind: &value.Kind}); err != nil {
+		return erero.Wro(err)
+	}
+	if value.Kind != ProgrammableTransactionKind {
+		*kind = TransactionBlockKind{Kind: value.Kind, Raw: append(json.RawMessage{}, data...)}
+		return nil
+	}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return erero.Wro(err)
+	}
+	*kind = TransactionBlockKind{Kind: value.Kind, Inputs: value.Inputs, Transactions: value.Transactions}
+	return nil
+}
+
+// CallArg represents input of programmable transaction, Type is "pure" or "object"
+// Pure inputs fill ValueType and Value
+// Object inputs fill ObjectType ("immOrOwnedObject", "sharedObject" or "receiving") and the reference
+//
+// CallArg 表示可编程交易的输入，Type 为 "pure" 或 "object"
+// 纯值输入填充 ValueType 和 Value
+// 对象输入填充 ObjectType（"immOrOwnedObject"、"sharedObject" 或 "receiving"）以及引用
+type CallArg struct {
+	Type                 string          `json:"type"`                           // Input kind // 输入种类
+	ValueType            string          `json:"valueType,omitempty"`            // Move type of pure value // 纯值的 Move 类型
+	Value                json.RawMessage `json:"value,omitempty"`                // Pure value in JSON // JSON 形式的纯值
+	ObjectType           string          `json:"objectType,omitempty"`           // Object input kind // 对象输入种类
+	ObjectId             string          `json:"objectId,omitempty"`             // Object ID // 对象 ID
+	Version              string          `json:"version,omitempty"`              // Object version of owned input // 自有对象输入的版本
+	Digest               string          `json:"digest,omitempty"`               // Object digest of owned input // 自有对象输入的摘要
+	InitialSharedVersion string          `json:"initialSharedVersion,omitempty"` // Version when object became shared // 对象变为共享时的版本
+	Mutable              *bool           `json:"mutable,omitempty"`              // Shared object taken by mutable reference // 以可变引用获取共享对象
+}
+
+// Command represents one command of programmable transaction, exactly one variant is set
+// JSON form is object with the variant name as its only key, such as {"SplitCoins": [...]}
+//
+// Command 表示可编程交易的一条命令，恰好设置一个变体
+// JSON 形式为以变体名称为唯一键的对象，例如 {"SplitCoins": [...]}
+type Command struct {
+	MoveCall        *MoveCallCommand        `json:"MoveCall,omitempty"`        // Call Move function // 调用 Move 函数
+	TransferObjects *TransferObjectsCommand `json:"TransferObjects,omitempty"` // Send objects to address // 将对象发送到地址
+	SplitCoins      *SplitCoinsCommand      `json:"SplitCoins,omitempty"`      // Split amounts off coin // 从代币拆分出金额
+	MergeCoins      *MergeCoinsCommand      `json:"MergeCoins,omitempty"`      // Merge coins into one // 将代币合并为一个
+	Publish         []string                `json:"Publish,omitempty"`         // Publish package with given dependencies // 发布带有给定依赖的包
+	Upgrade         *UpgradeCommand         `json:"Upgrade,omitempty"`         // Upgrade package // 升级包
+	MakeMoveVec     *MakeMoveVecCommand     `json:"MakeMoveVec,omitempty"`     // Build Move vector // 构建 Move 向量
+}
+
+// UnmarshalJSON decodes command and rejects unknown variants
+//
+// UnmarshalJSON 解码命令并拒绝未知变体
+func (command *Command) UnmarshalJSON(data []byte) error {
+	type plain Command
+	var value plain
+	if err := json.Unmarshal(data, &value); err != nil {
+		return erero.Wro(err)
+	}
+	*command = Command(value)
+	if command.Name() == "" {
+		return erero.Errorf("unknown command %s", data)
+	}
+	return nil
+}
+
+// Name returns variant name of command
+//
+// Name 返回命令的变体名称
+func (command *Command) Name() string {
+	switch {
+	case command.MoveCall != nil:
+		return "MoveCall"
+	case command.TransferObjects != nil:
+		return "TransferObjects"
+	case command.SplitCoins != nil:
+		return "SplitCoins"
+	case command.MergeCoins != nil:
+		return "MergeCoins"
+	case command.Publish != nil:
+		return "Publish"
+	case command.Upgrade != nil:
+		return "Upgrade"
+	case command.MakeMoveVec != nil:
+		return "MakeMoveVec"
+	default:
+		return ""
+	}
+}
+
+// MoveCallCommand represents call of Move function
+//
+// MoveCallCommand 表示对 Move 函数的调用
+type MoveCallCommand struct {
+	Package       string      `json:"package"`                  // Package ID // 包 ID
+	Module        string      `json:"module"`                   // Module name // 模块名称
+	Function      string      `json:"function"`                 // Function name // 函数名称
+	TypeArguments []string    `json:"type_arguments,omitempty"` // Type arguments // 类型参数
+	Arguments     []*Argument `json:"arguments,omitempty"`      // Arguments // 参数
+}
+
+// TransferObjectsCommand represents transfer of objects, JSON form is [objects, address]
+//
+// TransferObjectsCommand 表示对象转移，JSON 形式为 [objects, address]
+type TransferObjectsCommand struct {
+	Objects []*Argument // Objects to send // 要发送的对象
+	Address *Argument   // Recipient address // 接收方地址
+}
+
+// MarshalJSON encodes command as tuple
+//
+// MarshalJSON 将命令编码为元组
+func (command TransferObjectsCommand) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]any{command.Objects, command.Address})
+}
+
+// UnmarshalJSON decodes command from tuple
+//
+// UnmarshalJSON 从元组解码命令
+func (command *TransferObjectsCommand) UnmarshalJSON(data []byte) error {
+	return unmarshalTuple(data, &command.Objects, &command.Address)
+}
+
+// SplitCoinsCommand represents split of amounts off coin, JSON form is [coin, amounts]
+//
+// SplitCoinsCommand 表示从代币拆分出金额，JSON 形式为 [coin, amounts]
+type SplitCoinsCommand struct {
+	Coin    *Argument   // Coin to split // 要拆分的代币
+	Amounts []*Argument // Amounts of new coins // 新代币的金额
+}
+
+// MarshalJSON encodes command as tuple
+//
+// MarshalJSON 将命令编码为元组
+func (command SplitCoinsCommand) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]any{command.Coin, command.Amounts})
+}
+
+// UnmarshalJSON decodes command from tuple
+//
+// UnmarshalJSON 从元组解码命令
+func (command *SplitCoinsCommand) UnmarshalJSON(data []byte) error {
+	return unmarshalTuple(data, &command.Coin, &command.Amounts)
+}
+
+// MergeCoinsCommand represents merge of coins into destination, JSON form is [destination, sources]
+//
+// MergeCoinsCommand 表示将代币合并到目标代币，JSON 形式为 [destination, sources]
+type MergeCoinsCommand struct {
+	Destination *Argument   // Coin receiving the balances // 接收余额的代币
+	Sources     []*Argument // Coins merged and destroyed // 被合并并销毁的代币
+}
+
+// MarshalJSON encodes command as tuple
+//
+// MarshalJSON 将命令编码为元组
+func (command MergeCoinsCommand) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]any{command.Destination, command.Sources})
+}
+
+// UnmarshalJSON decodes command from tuple
+//
+// UnmarshalJSON 从元组解码命令
+func (command *MergeCoinsCommand) UnmarshalJSON(data []byte) error {
+	return unmarshalTuple(data, &command.Destination, &command.Sources)
+}
+
+// UpgradeCommand represents package upgrade, JSON form is [dependencies, package, ticket]
+//
+// UpgradeCommand 表示包升级，JSON 形式为 [dependencies, package, ticket]
+type UpgradeCommand struct {
+	Dependencies []string  // Dependency package IDs // 依赖包 ID
+	Package      string    // Package being upgraded // 被升级的包
+	Ticket       *Argument // Upgrade ticket // 升级凭证
+}
+
+// MarshalJSON encodes command as tuple
+//
+// MarshalJSON 将命令编码为元组
+func (command UpgradeCommand) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]any{command.Dependencies, command.Package, command.Ticket})
+}
+
+// UnmarshalJSON decodes command from tuple
+//
+// UnmarshalJSON 从元组解码命令
+func (command *UpgradeCommand) UnmarshalJSON(data []byte) error {
+	return unmarshalTuple(data, &command.Dependencies, &command.Package, &command.Ticket)
+}
+
+// MakeMoveVecCommand represents vector construction, JSON form is [type or null, elements]
+//
+// MakeMoveVecCommand 表示向量构建，JSON 形式为 [type 或 null, elements]
+type MakeMoveVecCommand struct {
+	Type     *string     // Element type, nil when inferred from elements // 元素类型，由元素推断时为 nil
+	Elements []*Argument // Vector elements // 向量元素
+}
+
+// MarshalJSON encodes command as tuple
+//
+// MarshalJSON 将命令编码为元组
+func (command MakeMoveVecCommand) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]any{command.Type, command.Elements})
+}
+
+// UnmarshalJSON decodes command from tuple
+//
+// UnmarshalJSON 从元组解码命令
+func (command *MakeMoveVecCommand) UnmarshalJSON(data []byte) error {
+	return unmarshalTuple(data, &command.Type, &command.Elements)
+}
+
+// unmarshalTuple decodes JSON array into targets one by one, array length must match
+//
+// unmarshalTuple 将 JSON 数组逐项解码到目标中，数组长度必须一致
+func unmarshalTuple(data []byte, targets ...any) error {
+	var items []json.RawMessage
+	if err := json.Unmarshal(data, &items); err != nil {
+		return erero.Wro(err)
+	}
+	if len(items) != len(targets) {
+		return erero.Errorf("want tuple of %d items, got %s", len(targets), data)
+	}
+	for idx, item := range items {
+		if err := json.Unmarshal(item, targets[idx]); err != nil {
+			return erero.Wro(err)
+		}
+	}
+	return nil
+}
+
+// Argument kinds of programmable transaction
+//
+// 可编程交易的参数种类
+const (
+	ArgumentGasCoin      = "GasCoin"      // Gas coin // gas 代币
+	ArgumentInput        = "Input"        // Input at Index // 位于 Index 的输入
+	ArgumentResult       = "Result"       // Result of command at Index // 位于 Index 的命令结果
+	ArgumentNestedResult = "NestedResult" // Result ResultIndex of command at Index // 位于 Index 的命令的第 ResultIndex 个结果
+)
+
+// Argument represents command argument of programmable transaction
+// JSON forms: "GasCoin", {"Input": 0}, {"Result": 0} and {"NestedResult": [0, 1]}
+//
+// Argument 表示可编程交易的命令参数
+// JSON 形式："GasCoin"、{"Input": 0}、{"Result": 0} 和 {"NestedResult": [0, 1]}
+type Argument struct {
+	Kind        string // Argument kind // 参数种类
+	Index       uint16 // Input or command index // 输入或命令序号
+	ResultIndex uint16 // Index in results of command, only with NestedResult // 命令结果中的序号，仅用于 NestedResult
+}
+
+// MarshalJSON encodes argument in fullnode form
+//
+// MarshalJSON 以全节点形式编码参数
+func (argument Argument) MarshalJSON() ([]byte, error) {
+	switch argument.Kind {
+	case ArgumentGasCoin:
+		return json.Marshal(ArgumentGasCoin)
+	case ArgumentInput, ArgumentResult:
+		return json.Marshal(map[string]uint16{argument.Kind: argument.Index})
+	case ArgumentNestedResult:
+		return json.Marshal(map[string][2]uint16{argument.Kind: {argument.Index, argument.ResultIndex}})
+	default:
+		return nil, erero.Errorf("unknown argument kind %q", argument.Kind)
+	}
+}
+
+// UnmarshalJSON decodes argument from fullnode form
+//
+// UnmarshalJSON 从全节点形式解码参数
+func (argument *Argument) UnmarshalJSON(data []byte) error {
+	*argument = Argument{}
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		if name != ArgumentGasCoin {
+			return erero.Errorf("unknown argument %q", name)
+		}
+		argument.Kind = ArgumentGasCoin
+		return nil
+	}
+	var variant struct {
+		Input        *uint16  `json:"Input"`
+		Result       *uint16  `json:"Result"`
+		NestedResult []uint16 `json:"NestedResult"`
+	}
+	if err := json.Unmarshal(data, &variant); err != nil {
+		return erero.Wro(err)
+	}
+	switch {
+	case variant.Input != nil:
+		argument.Kind, argument.Index = ArgumentInput, *variant.Input
+	case variant.Result != nil:
+		argument.Kind, argument.Index = ArgumentResult, *variant.Result
+	case len(variant.NestedResult) == 2:
+		argument.Kind, argument.Index, argument.ResultIndex = ArgumentNestedResult, variant.NestedResult[0], variant.NestedResult[1]
+	default:
+		return erero.Errorf("unknown argument %s", data)
+	}
+	return nil
+}
+
+// QueryTransactionBlocks returns page of transaction blocks matching query after cursor
+// Nil query matches each transaction and returns digests only
+//
+// QueryTransactionBlocks 返回游标之后与查询匹配的一页交易区块
+// query 为 nil 时匹配每笔交易且仅返回摘要
+func (c *Client) QueryTransactionBlocks(ctx context.Context, query *TransactionBlockResponseQuery, cursor *string, limit int, descending bool) (*Page[SuiTransactionBlockResponse, string], error) {
+	if query == nil {
+		query = &TransactionBlockResponseQuery{}
+	}
+	return call[Page[SuiTransactionBlockResponse, string]](ctx, c, "suix_queryTransactionBlocks", query, cursor, optional(limit), descending)
+}
+
+// PaginateTransactionBlocks walks each transaction block matching query across pages
+// Descending option walks newest first
+//
+// PaginateTransactionBlocks 跨分页遍历与查询匹配的每个交易区块
+// Descending 选项从最新的开始遍历
+func (c *Client) PaginateTransactionBlocks(query *TransactionBlockResponseQuery, options PageOptions[string]) *Paginator[SuiTransactionBlockResponse, string] {
+	fetch := func(ctx context.Context, cursor *string, limit int, descending bool) (*Page[SuiTransactionBlockResponse, string], error) {
+		return c.QueryTransactionBlocks(ctx, query, cursor, limit, descending)
+	}
+	return NewPaginator(fetch, func(tx *SuiTransactionBlockResponse) (string, bool) { return tx.Digest, true }, options)
+}
@@ -0,0 +1,184 @@
+package suiapi
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/yyle88/erero"
+)
+
+// TransactionFilter represents suix_queryTransactionBlocks filter, exactly one variant is set
+// Build it with TransactionFromAddress, TransactionToAddress and the other Transaction* functions
+//
+// TransactionFilter 表示 suix_queryTransactionBlocks 的过滤条件，恰好设置一个变体
+// 使用 TransactionFromAddress、TransactionToAddress 等 Transaction* 函数构建
+type TransactionFilter struct {
+	FromAddress      string            // Transactions sent by address // 由地址发送的交易
+	ToAddress        string            // Transactions changing objects owned by address // 修改地址所拥有对象的交易
+	FromAndToAddress *FromAndToAddress // Transactions sent by one address to another // 由一个地址发往另一个地址的交易
+	InputObject      string            // Transactions taking object as input // 以对象为输入的交易
+	ChangedObject    string            // Transactions creating, changing or deleting object // 创建、修改或删除对象的交易
+	MoveFunction     *MoveFunctionRef  // Transactions calling Move function // 调用 Move 函数的交易
+	Checkpoint       *uint64           // Transactions of checkpoint // 检查点中的交易
+	TransactionKind  string            // Transactions of kind such as ProgrammableTransaction // 某种类的交易，例如 ProgrammableTransaction
+}
+
+// FromAndToAddress represents sender and recipient pair
+//
+// FromAndToAddress 表示发送方和接收方地址对
+type FromAndToAddress struct {
+	From string `json:"from"` // Sender address // 发送方地址
+	To   string `json:"to"`   // Recipient address // 接收方地址
+}
+
+// MoveFunctionRef represents Move function, empty module or function matches each of them
+//
+// MoveFunctionRef 表示 Move 函数，模块或函数为空时匹配任意模块或函数
+type MoveFunctionRef struct {
+	Package  string `json:"package"`            // Package ID // 包 ID
+	Module   string `json:"module,omitempty"`   // Module name // 模块名
+	Function string `json:"function,omitempty"` // Function name // 函数名
+}
+
+// TransactionFromAddress returns filter matching transactions sent by address
+//
+// TransactionFromAddress 返回匹配由地址发送的交易的过滤条件
+func TransactionFromAddress(address string) *TransactionFilter {
+	return &TransactionFilter{FromAddress: address}
+}
+
+// TransactionToAddress returns filter matching transactions changing objects owned by address
+//
+// TransactionToAddress 返回匹配修改地址所拥有对象的交易的过滤条件
+func TransactionToAddress(address string) *TransactionFilter {
+	return &TransactionFilter{ToAddress: address}
+}
+
+// TransactionFromAndToAddress returns filter matching transactions sent by from to recipient to
+//
+// TransactionFromAndToAddress 返回匹配由 from 发往接收方 to 的交易的过滤条件
+func TransactionFromAndToAddress(from string, to string) *TransactionFilter {
+	return &TransactionFilter{FromAndToAddress: &FromAndToAddress{From: from, To: to}}
+}
+
+// TransactionInputObject returns filter matching transactions taking object as input
+//
+// TransactionInputObject 返回匹配以对象为输入的交易的过滤条件
+func TransactionInputObject(objectId string) *TransactionFilter {
+	return &TransactionFilter{InputObject: objectId}
+}
+
+// TransactionChangedObject returns filter matching transactions creating, changing or deleting object
+//
+// TransactionChangedObject 返回匹配创建、修改或删除对象的交易的过滤条件
+func TransactionChangedObject(objectId string) *TransactionFilter {
+	return &TransactionFilter{ChangedObject: objectId}
+}
+
+// TransactionMoveFunction returns filter matching transactions calling Move function
+// Empty module matches each module of package, empty function matches each function of module
+//
+// TransactionMoveFunction 返回匹配调用 Move 函数的交易的过滤条件
+// 模块为空时匹配包的每个模块，函数为空时匹配模块的每个函数
+func TransactionMoveFunction(packageId string, module string, function string) *TransactionFilter {
+	return &TransactionFilter{MoveFunction: &MoveFunctionRef{Package: packageId, Module: module, Function: function}}
+}
+
+// TransactionCheckpoint returns filter matching transactions of checkpoint
+//
+// TransactionCheckpoint 返回匹配检查点中交易的过滤条件
+func TransactionCheckpoint(sequenceNumber uint64) *TransactionFilter {
+	return &TransactionFilter{Checkpoint: &sequenceNumber}
+}
+
+// TransactionOfKind returns filter matching transactions of kind such as ProgrammableTransactionKind
+//
+// TransactionOfKind 返回匹配某种类交易的过滤条件，例如 ProgrammableTransactionKind
+func TransactionOfKind(kind string) *TransactionFilter {
+	return &TransactionFilter{TransactionKind: kind}
+}
+
+// MarshalJSON encodes filter in fullnode form such as {"FromAddress": "0x.."} or {"Checkpoint": "12"}
+//
+// MarshalJSON 以全节点形式编码过滤条件，例如 {"FromAddress": "0x.."} 或 {"Checkpoint": "12"}
+func (filter TransactionFilter) MarshalJSON() ([]byte, error) {
+	variants := map[string]any{}
+	if filter.FromAddress != "" {
+		variants["FromAddress"] = filter.FromAddress
+	}
+	if filter.ToAddress != "" {
+		variants["ToAddress"] = filter.ToAddress
+	}
+	if filter.FromAndToAddress != nil {
+		variants["FromAndToAddress"] = filter.FromAndToAddress
+	}
+	if filter.InputObject != "" {
+		variants["InputObject"] = filter.InputObject
+	}
+	if filter.ChangedObject != "" {
+		variants["ChangedObject"] = filter.ChangedObject
+	}
+	if filter.MoveFunction != nil {
+		variants["MoveFunction"] = filter.MoveFunction
+	}
+	if filter.Checkpoint != nil {
+		variants["Checkpoint"] = strconv.FormatUint(*filter.Checkpoint, 10)
+	}
+	if filter.TransactionKind != "" {
+		variants["TransactionKind"] = filter.TransactionKind
+	}
+	if len(variants) != 1 {
+		return nil, erero.Errorf("transaction filter needs exactly one variant, got %d", len(variants))
+	}
+	return json.Marshal(variants)
+}
+
+// UnmarshalJSON decodes filter from fullnode form
+//
+// UnmarshalJSON 从全节点形式解码过滤条件
+func (filter *TransactionFilter) UnmarshalJSON(data []byte) error {
+	*filter = TransactionFilter{}
+	var variants map[string]json.RawMessage
+	if err := json.Unmarshal(data, &variants); err != nil {
+		return erero.Wro(err)
+	}
+	if len(variants) != 1 {
+		return erero.Errorf("transaction filter needs exactly one variant, got %s", data)
+	}
+	for name, value := range variants {
+		var target any
+		switch name {
+		case "FromAddress":
+			target = &filter.FromAddress
+		case "ToAddress":
+			target = &filter.ToAddress
+		case "FromAndToAddress":
+			target = &filter.FromAndToAddress
+		case "InputObject":
+			target = &filter.InputObject
+		case "ChangedObject":
+			target = &filter.ChangedObject
+		case "MoveFunction":
+			target = &filter.MoveFunction
+		case "Checkpoint":
+			var text string
+			if err := json.Unmarshal(value, &text); err != nil {
+				return erero.WithMessagef(err, "transaction filter %s", name)
+			}
+			sequenceNumber, err := strconv.ParseUint(text, 10, 64)
+			if err != nil {
+				return erero.WithMessagef(err, "transaction filter %s", name)
+			}
+			filter.Checkpoint = &sequenceNumber
+			continue
+		case "TransactionKind":
+			target = &filter.TransactionKind
+		default:
+			return erero.Errorf("unknown transaction filter %q", name)
+		}
+		if err := json.Unmarshal(value, target); err != nil {
+			return erero.WithMessagef(err, "transaction filter %s", name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package suiapi_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/go-xlan/sui-go-guide/suiapi"
+	"github.com/go-xlan/sui-go-guide/suirpc"
+	"github.com/go-xlan/sui-go-guide/suirpctest"
+	"github.com/go-xlan/sui-go-guide/suisigntx"
+	"github.com/go-xlan/sui-go-guide/suiwallet"
+	"github.com/stretchr/testify/require"
+)
+
+// friendKeyHex is private key of second wallet sending SUI to address
+//
+// friendKeyHex 是向 address 发送 SUI 的第二个钱包的私钥
+const friendKeyHex = "1111111111111111111111111111111111111111111111111111111111111111"
+
+// friendAddress returns address of friendKeyHex
+//
+// friendAddress 返回 friendKeyHex 对应的地址
+func friendAddress(t *testing.T) string {
+	wallet, err := suiwallet.NewWalletV2(friendKeyHex)
+	require.NoError(t, err)
+	return wallet.Address()
+}
+
+// transferFromFriend sends SUI from friend wallet to address on simulator and returns transaction digest
+//
+// transferFromFriend 在模拟器上从 friend 钱包向 address 发送 SUI 并返回交易摘要
+func transferFromFriend(t *testing.T, client *suirpc.Client, from string, coinObjectId string, amount string) string {
+	ctx := context.Background()
+	built, err := suirpc.Call[suiapi.TxBytesMessage](ctx, client, &suirpc.RpcRequest{
+		Jsonrpc: "2.0",
+		Method:  "unsafe_transferSui",
+		Params:  []any{from, coinObjectId, gasBudget, address, amount},
+	})
+	require.NoError(t, err)
+	signatures, err := suisigntx.Sign(friendKeyHex, built.Result.TxBytes)
+	require.NoError(t, err)
+	res, err := suiapi.ExecuteTransactionBlock[suiapi.DigestMessage](ctx, client, built.Result.TxBytes, signatures)
+	require.NoError(t, err)
+	return res.Digest
+}
+
+// TestTransactionFilter_JSON tests filters encode in fullnode form and decode back
+//
+// TestTransactionFilter_JSON 测试过滤条件以全节点形式编码并能解码回来
+func TestTransactionFilter_JSON(t *testing.T) {
+	cases := []struct {
+		filter *suiapi.TransactionFilter
+		want   string
+	}{
+		{suiapi.TransactionFromAddress(address), `{"FromAddress": "` + address + `"}`},
+		{suiapi.TransactionToAddress(recipient), `{"ToAddress": "` + recipient + `"}`},
+		{suiapi.TransactionFromAndToAddress(address, recipient), `{"FromAndToAddress": {"from": "` + address + `", "to": "` + recipient + `"}}`},
+		{suiapi.TransactionInputObject("0x5"), `{"InputObject": "0x5"}`},
+		{suiapi.TransactionChangedObject("0x6"), `{"ChangedObject": "0x6"}`},
+		{suiapi.TransactionMoveFunction(mathPackage, "math", "add"), `{"MoveFunction": {"package": "` + mathPackage + `", "module": "math", "function": "add"}}`},
+		{suiapi.TransactionMoveFunction(mathPackage, "", ""), `{"MoveFunction": {"package": "` + mathPackage + `"}}`},
+		{suiapi.TransactionCheckpoint(0), `{"Checkpoint": "0"}`},
+		{suiapi.TransactionOfKind(suiapi.ProgrammableTransactionKind), `{"TransactionKind": "ProgrammableTransaction"}`},
+	}
+	for _, tc := range cases {
+		data, err := json.Marshal(tc.filter)
+		require.NoError(t, err)
+		require.JSONEq(t, tc.want, string(data))
+
+		var decoded suiapi.TransactionFilter
+		require.NoError(t, json.Unmarshal(data, &decoded))
+		require.Equal(t, *tc.filter, decoded)
+	}
+
+	_, err := json.Marshal(&suiapi.TransactionFilter{})
+	require.Error(t, err)
+	_, err = json.Marshal(&suiapi.TransactionFilter{FromAddress: address, ToAddress: recipient})
+	require.Error(t, err)
+	var decoded suiapi.TransactionFilter
+	require.Error(t, json.Unmarshal([]byte(`{"FromOrToAddress": {"addr": "0x1"}}`), &decoded))
+	require.Error(t, json.Unmarshal([]byte(`{"Checkpoint": "-1"}`), &decoded))
+}
+
+// TestClient_QueryTransactionBlocks tests each filter variant and paging in both orders against the simulator
+//
+// TestClient_QueryTransactionBlocks 针对模拟器测试每种过滤条件变体以及双向分页
+func TestClient_QueryTransactionBlocks(t *testing.T) {
+	server := newSimulator(t)
+	client := suiapi.NewClient(server.Client())
+	ctx := context.Background()
+	friend := friendAddress(t)
+
+	coin := server.Mint(address, 100_000_000)
+	friendCoin := server.Mint(friend, 50_000_000)
+	first := transferSui(t, client.RpcClient(), coin.CoinObjectId, "30000000")
+	second := transferFromFriend(t, client.RpcClient(), friend, friendCoin.CoinObjectId, "5000000")
+	third := server.EmitEvents(recipient, suirpctest.Event{Type: mathPackage + "::math::Added", ParsedJson: map[string]any{"a": "1", "b": "2", "sum": "3"}})
+	fourth := transferSui(t, client.RpcClient(), coin.CoinObjectId, "1000000")
+
+	digestsOf := func(filter *suiapi.TransactionFilter, descending bool) []string {
+		txs, err := client.PaginateTransactionBlocks(&suiapi.TransactionBlockResponseQuery{Filter: filter}, suiapi.PageOptions[string]{PageSize: 1, Descending: descending}).All(ctx)
+		require.NoError(t, err)
+		digests := make([]string, 0, len(txs))
+		for _, tx := range txs {
+			digests = append(digests, tx.Digest)
+		}
+		return digests
+	}
+
+	page, err := client.QueryTransactionBlocks(ctx, &suiapi.TransactionBlockResponseQuery{
+		Filter:  suiapi.TransactionFromAddress(address),
+		Options: &suiapi.TransactionBlockResponseOptions{ShowEffects: true},
+	}, nil, 1, false)
+	require.NoError(t, err)
+	require.Len(t, page.Data, 1)
+	require.True(t, page.HasNextPage)
+	require.Equal(t, first, page.Data[0].Digest)
+	require.True(t, page.Data[0].Succeeded())
+
+	require.Equal(t, []string{fourth, first}, digestsOf(suiapi.TransactionFromAddress(address), true))
+	require.Equal(t, []string{first, fourth}, digestsOf(suiapi.TransactionToAddress(recipient), false))
+	require.Equal(t, []string{second}, digestsOf(suiapi.TransactionFromAndToAddress(friend, address), false))
+	require.Empty(t, digestsOf(suiapi.TransactionFromAndToAddress(address, friend), false))
+	require.Equal(t, []string{second}, digestsOf(suiapi.TransactionInputObject(friendCoin.CoinObjectId), false))
+	require.Equal(t, []string{first, fourth}, digestsOf(suiapi.TransactionChangedObject(coin.CoinObjectId), false))
+	require.Equal(t, []string{second}, digestsOf(suiapi.TransactionCheckpoint(2), false))
+	require.Equal(t, []string{third}, digestsOf(suiapi.TransactionMoveFunction(mathPackage, "math", ""), false))
+	require.Empty(t, digestsOf(suiapi.TransactionMoveFunction(mathPackage, "coin", ""), false))
+	require.Equal(t, []string{fourth, third, second, first}, digestsOf(suiapi.TransactionOfKind(suiapi.ProgrammableTransactionKind), true))
+	require.Equal(t, []string{first, second, third, fourth}, digestsOf(nil, false))
+}
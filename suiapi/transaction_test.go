@@ -0,0 +1,208 @@
+package suiapi_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-xlan/sui-go-guide/suiapi"
+	"github.com/stretchr/testify/require"
+)
+
+// loadTransaction reads recorded transaction block fixture and decodes it
+//
+// loadTransaction 读取录制的交易区块样例并解码
+func loadTransaction(t *testing.T, name string) ([]byte, *suiapi.SuiTransactionBlockResponse) {
+	data, err := os.ReadFile(filepath.Join("testdata", "transaction", name+".json"))
+	require.NoError(t, err)
+	var tx suiapi.SuiTransactionBlockResponse
+	require.NoError(t, json.Unmarshal(data, &tx))
+	return data, &tx
+}
+
+// prune decodes JSON into generic value without nulls, empty arrays and empty objects
+// Fields dropped by omitempty compare equal to their absent form this way
+//
+// prune 将 JSON 解码为去除 null、空数组和空对象的通用值
+// 这样被 omitempty 省略的字段与其缺失形式比较时相等
+func prune(t *testing.T, data []byte) any {
+	var value any
+	require.NoError(t, json.Unmarshal(data, &value))
+	return pruneValue(value)
+}
+
+// pruneValue drops nulls, empty arrays and empty objects recursively, keeps array items in place
+//
+// pruneValue 递归去除 null、空数组和空对象，数组元素保持原位
+func pruneValue(value any) any {
+	switch value := value.(type) {
+	case map[string]any:
+		for key, item := range value {
+			if item = pruneValue(item); item == nil {
+				delete(value, key)
+			} else {
+				value[key] = item
+			}
+		}
+		if len(value) == 0 {
+			return nil
+		}
+		return value
+	case []any:
+		if len(value) == 0 {
+			return nil
+		}
+		for idx, item := range value {
+			value[idx] = pruneValue(item)
+		}
+		return value
+	default:
+		return value
+	}
+}
+
+// TestSuiTransactionBlockResponse_RoundTrip tests recorded responses decode and encode back without losing fields
+//
+// TestSuiTransactionBlockResponse_RoundTrip 测试录制的响应解码后再编码不丢失字段
+func TestSuiTransactionBlockResponse_RoundTrip(t *testing.T) {
+	for _, name := range []string{"pay_sui", "move_call", "wrap", "publish", "failure", "consensus_commit"} {
+		t.Run(name, func(t *testing.T) {
+			data, tx := loadTransaction(t, name)
+
+			encoded, err := json.Marshal(tx)
+			require.NoError(t, err)
+			require.Equal(t, prune(t, data), prune(t, encoded))
+
+			// Second pass reaches fixed point, encoded bytes stay the same
+			// 第二轮达到不动点，编码后的字节保持不变
+			var again suiapi.SuiTransactionBlockResponse
+			require.NoError(t, json.Unmarshal(encoded, &again))
+			encodedAgain, err := json.Marshal(&again)
+			require.NoError(t, err)
+			require.Equal(t, string(encoded), string(encodedAgain))
+		})
+	}
+}
+
+// TestSuiTransactionBlockResponse_PaySui tests programmable transaction, effects and balance changes of SUI payment
+//
+// TestSuiTransactionBlockResponse_PaySui 测试 SUI 支付的可编程交易、效果和余额变更
+func TestSuiTransactionBlockResponse_PaySui(t *testing.T) {
+	_, tx := loadTransaction(t, "pay_sui")
+	require.True(t, tx.Succeeded())
+	require.Equal(t, uint64(46702371), tx.Checkpoint)
+	require.True(t, *tx.ConfirmedLocalExecution)
+
+	data := tx.Transaction.Data
+	require.Equal(t, address, data.Sender)
+	require.Equal(t, uint64(305438811), data.GasData.Payment[0].Version)
+	require.Equal(t, suiapi.ProgrammableTransactionKind, data.Transaction.Kind)
+	require.Equal(t, "pure", data.Transaction.Inputs[0].Type)
+	require.JSONEq(t, `"30000000"`, string(data.Transaction.Inputs[0].Value))
+
+	commands := data.Transaction.Transactions
+	require.Equal(t, "SplitCoins", commands[0].Name())
+	require.Equal(t, suiapi.ArgumentGasCoin, commands[0].SplitCoins.Coin.Kind)
+	require.Equal(t, suiapi.Argument{Kind: suiapi.ArgumentInput}, *commands[0].SplitCoins.Amounts[0])
+	require.Equal(t, "TransferObjects", commands[1].Name())
+	require.Equal(t, suiapi.Argument{Kind: suiapi.ArgumentInput, Index: 1}, *commands[1].TransferObjects.Address)
+
+	effects := tx.Effects
+	require.Equal(t, uint64(512), effects.ExecutedEpoch)
+	require.Equal(t, uint64(750000), effects.GasUsed.ComputationCost)
+	require.Equal(t, recipient, effects.Created[0].Owner.Owner())
+	require.Equal(t, effects.Mutated[0], effects.GasObject)
+	require.Len(t, effects.Dependencies, 1)
+
+	require.Equal(t, suiapi.ObjectChangeMutated, tx.ObjectChanges[0].Type)
+	require.Equal(t, "305438811", tx.ObjectChanges[0].Mutated.PreviousVersion)
+	require.Equal(t, suiapi.ObjectChangeCreated, tx.ObjectChanges[1].Type)
+	require.Equal(t, effects.Created[0].Reference.ObjectId, tx.ObjectChanges[1].ObjectId())
+
+	require.Equal(t, suiapi.AddressOwnerOf(address), tx.BalanceChanges[0].Owner)
+	require.Equal(t, "-31747880", tx.BalanceChanges[0].Amount)
+}
+
+// TestSuiTransactionBlockResponse_Variants tests shared inputs, events, owner variants and each object change kind
+//
+// TestSuiTransactionBlockResponse_Variants 测试共享输入、事件、所有者变体和每种对象变更
+func TestSuiTransactionBlockResponse_Variants(t *testing.T) {
+	_, tx := loadTransaction(t, "move_call")
+	kind := tx.Transaction.Data.Transaction
+	require.Equal(t, "373684", kind.Inputs[0].InitialSharedVersion)
+	require.True(t, *kind.Inputs[0].Mutable)
+	require.False(t, *kind.Inputs[2].Mutable)
+	require.Nil(t, kind.Transactions[0].MakeMoveVec.Type)
+	require.Empty(t, kind.Transactions[1].MakeMoveVec.Elements)
+	require.Len(t, kind.Transactions[2].MoveCall.TypeArguments, 2)
+	require.Equal(t, suiapi.Argument{Kind: suiapi.ArgumentNestedResult, Index: 2, ResultIndex: 1}, *kind.Transactions[4].MoveCall.Arguments[0])
+	require.Empty(t, kind.Transactions[5].MoveCall.Arguments)
+
+	require.Len(t, tx.Effects.SharedObjects, 2)
+	require.Equal(t, uint64(373684), tx.Effects.Mutated[1].Owner.Shared.InitialSharedVersion)
+	require.Equal(t, tx.Effects.Mutated[1].Reference.ObjectId, tx.Effects.Created[0].Owner.ObjectOwner)
+	require.Len(t, tx.Effects.Deleted, 1)
+	require.Equal(t, "0", tx.Events[0].Id.EventSeq)
+	require.Equal(t, "base64", tx.Events[0].BcsEncoding)
+	var swap struct {
+		AmountOut string `json:"amount_out"`
+	}
+	require.NoError(t, json.Unmarshal(tx.Events[0].ParsedJson, &swap))
+	require.Equal(t, "402553018", swap.AmountOut)
+	require.Equal(t, suiapi.ObjectChangeDeleted, tx.ObjectChanges[3].Type)
+	require.Equal(t, "445567", tx.ObjectChanges[3].Deleted.Version)
+
+	_, tx = loadTransaction(t, "wrap")
+	require.Len(t, tx.Transaction.TxSignatures, 2)
+	require.Equal(t, recipient, tx.Transaction.Data.GasData.Owner)
+	require.Equal(t, "receiving", tx.Transaction.Data.Transaction.Inputs[1].ObjectType)
+	require.True(t, tx.Effects.Created[0].Owner.Immutable)
+	require.Equal(t, recipient, tx.Effects.Created[1].Owner.Owner())
+	require.Len(t, tx.Effects.Unwrapped, 1)
+	require.Len(t, tx.Effects.Wrapped, 1)
+	require.Len(t, tx.Effects.UnwrappedThenDeleted, 1)
+	require.Equal(t, recipient, tx.ObjectChanges[1].Transferred.Recipient.AddressOwner)
+	require.Equal(t, tx.Effects.Wrapped[0].ObjectId, tx.ObjectChanges[2].Wrapped.ObjectId)
+	require.Nil(t, tx.Events)
+
+	_, tx = loadTransaction(t, "publish")
+	require.Len(t, tx.Transaction.Data.Transaction.Transactions[0].Publish, 2)
+	require.Equal(t, []string{"vault", "vault_events"}, tx.ObjectChanges[1].Published.Modules)
+	require.Equal(t, tx.ObjectChanges[1].Published.PackageId, tx.ObjectChanges[1].ObjectId())
+	require.NotEmpty(t, tx.RawTransaction)
+	require.NotEmpty(t, tx.RawEffects)
+
+	_, tx = loadTransaction(t, "failure")
+	require.False(t, tx.Succeeded())
+	require.Equal(t, "failure", tx.Effects.Status.Status)
+	require.Contains(t, tx.Effects.Status.Error, "MoveAbort")
+	upgrade := tx.Transaction.Data.Transaction.Transactions[1].Upgrade
+	require.Len(t, upgrade.Dependencies, 2)
+	require.Equal(t, suiapi.Argument{Kind: suiapi.ArgumentResult}, *upgrade.Ticket)
+
+	_, tx = loadTransaction(t, "consensus_commit")
+	require.Equal(t, "ConsensusCommitPrologueV3", tx.Transaction.Data.Transaction.Kind)
+	require.Nil(t, tx.Transaction.Data.Transaction.Transactions)
+	require.NotEmpty(t, tx.Transaction.Data.Transaction.Raw)
+}
+
+// TestSuiTransactionBlockResponse_Unknown tests unknown union variants come back as errors
+//
+// TestSuiTransactionBlockResponse_Unknown 测试未知的联合变体以错误返回
+func TestSuiTransactionBlockResponse_Unknown(t *testing.T) {
+	var change suiapi.ObjectChange
+	require.Error(t, json.Unmarshal([]byte(`{"type":"teleported","objectId":"0x1"}`), &change))
+
+	var owner suiapi.ObjectOwner
+	require.Error(t, json.Unmarshal([]byte(`"Borrowed"`), &owner))
+	require.NoError(t, json.Unmarshal([]byte(`null`), &owner))
+	require.True(t, owner.IsZero())
+
+	var command suiapi.Command
+	require.Error(t, json.Unmarshal([]byte(`{"Teleport":[]}`), &command))
+
+	var argument suiapi.Argument
+	require.Error(t, json.Unmarshal([]byte(`"Sender"`), &argument))
+	require.Error(t, json.Unmarshal([]byte(`{"NestedResult":[1]}`), &argument))
+}
@@ -0,0 +1,196 @@
+package suirpc
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/yyle88/erero"
+	"github.com/yyle88/neatjson/neatjsons"
+)
+
+// DefaultBatchLimit is the default count of requests sent in one batch array
+// Larger batches are split into several HTTP calls
+//
+// DefaultBatchLimit 是单个批量数组中发送请求的默认数量
+// 更大的批量会被拆分为多次 HTTP 调用
+const DefaultBatchLimit = 50
+
+// BatchResult represents outcome of one request inside batch call
+// Contains the sent request, typed result, and RPC error if this request failed
+// Results keep the same order as the input requests
+//
+// BatchResult 表示批量调用中单个请求的结果
+// 包含已发送的请求、类型化结果，以及该请求失败时的 RPC 错误
+// 结果与输入请求保持相同顺序
+type BatchResult[RES any] struct {
+	Request *RpcRequest // Request sent with assigned ID // 分配 ID 后发送的请求
+	Result  RES         // Typed result when call succeeded // 调用成功时的类型化结果
+	Error   *RpcError   // Error object when call failed // 调用失败时的错误对象
+}
+
+// SetBatchLimit sets max count of requests sent in one batch array
+// Values below one fall back to DefaultBatchLimit
+//
+// SetBatchLimit 设置单个批量数组中发送请求的最大数量
+// 小于 1 的值回退到 DefaultBatchLimit
+func (c *Client) SetBatchLimit(limit int) *Client {
+	c.batchLimit = limit
+	return c
+}
+
+// SendBatch sends requests as JSON-RPC batch and returns raw results
+// Requests without ID get unique ID and responses are matched back by ID
+//
+// SendBatch 以 JSON-RPC 批量方式发送请求并返回原始结果
+// 未设置 ID 的请求分配唯一 ID，响应按 ID 匹配回请求
+func (c *Client) SendBatch(ctx context.Context, requests []*RpcRequest) ([]*BatchResult[json.RawMessage], error) {
+	return CallBatch[json.RawMessage](ctx, c, requests)
+}
+
+// CallBatch sends requests as JSON-RPC batch and deserializes each result into generic type
+// Splits oversized batches into chunks limited by client batch limit
+// Returns per-request results in input order, RPC errors are kept per request
+// Returns error when transport fails or response misses a request
+// Rejects duplicate IDs across the whole batch before sending any chunk
+// Verifies chain identifier first when client expects one
+//
+// CallBatch 以 JSON-RPC 批量方式发送请求并将每个结果反序列化为通用类型
+// 按客户端批量限制将过大的批量拆分为多个分块
+// 按输入顺序返回每个请求的结果，RPC 错误按请求保留
+// 传输失败或响应缺少某个请求时返回错误
+// 发送任何分块前拒绝整个批量中的重复 ID
+// 客户端设置了预期链标识符时先进行校验
+func CallBatch[RES any](ctx context.Context, client *Client, requests []*RpcRequest) ([]*BatchResult[RES], error) {
+	if err := client.VerifyChain(ctx); err != nil {
+		return nil, erero.Wro(err)
+	}
+
+	// Copy requests and assign IDs where empty so caller requests stay unchanged
+	// 复制请求并为空 ID 分配 ID，保持调用方请求不变
+	batch := make([]*RpcRequest, 0, len(requests))
+	requestKeys := make(map[string]bool, len(requests))
+	for _, request := range requests {
+		clone := *withID(request, client.nextRequestID)
+		key := idKey(clone.ID)
+		if requestKeys[key] {
+			return nil, erero.WithMessagef(ErrDuplicateID, "id=%s method=%s", key, clone.Method)
+		}
+		requestKeys[key] = true
+		batch = append(batch, &clone)
+	}
+
+	results := make([]*BatchResult[RES], 0, len(batch))
+	for start := 0; start < len(batch); start += client.getBatchLimit() {
+		end := min(start+client.getBatchLimit(), len(batch))
+		chunk, err := callBatchChunk[RES](ctx, client, batch[start:end])
+		if err != nil {
+			return nil, erero.Wro(err)
+		}
+		results = append(results, chunk...)
+	}
+	return results, nil
+}
+
+// SendBatchRpc sends requests as JSON-RPC batch using the default client
+// Accepts server URL and requests, returns per-request typed results
+//
+// SendBatchRpc 使用默认客户端以 JSON-RPC 批量方式发送请求
+// 接受服务器 URL 和请求，返回每个请求的类型化结果
+func SendBatchRpc[RES any](ctx context.Context, serverUrl string, requests []*RpcRequest) ([]*BatchResult[RES], error) {
+	return CallBatch[RES](ctx, defaultClient.WithServerUrl(serverUrl), requests)
+}
+
+// callBatchChunk sends one batch array of requests with IDs and matches responses by ID
+// Null-ID error item means node failed to read a request, it fails the chunk with that error
+//
+// callBatchChunk 发送单个带 ID 请求的批量数组并按 ID 匹配响应
+// 空 ID 的错误项表示节点未能读取某个请求，此时以该错误使分块失败
+func callBatchChunk[RES any](ctx context.Context, client *Client, batch []*RpcRequest) ([]*BatchResult[RES], error) {
+	var body []byte
+	if err := client.withRetry(ctx, client.newCall(nil, batch).Methods(), func(ctx context.Context) (err error) {
+		call := client.newCall(nil, batch)
+		body, err = client.roundTrip(ctx, call)
+		batch = call.Batch
+		return err
+	}); err != nil {
+		return nil, erero.Wro(err)
+	}
+
+	// Collect IDs of batch that went on the wire, middlewares may have replaced it
+	// 收集实际发出的批量的 ID，中间件可能已替换该批量
+	requestKeys := make(map[string]bool, len(batch))
+	for _, request := range batch {
+		requestKeys[idKey(request.ID)] = true
+	}
+
+	var items []json.RawMessage
+	if err := json.Unmarshal(body, &items); err != nil {
+		// Node rejects whole batch with single error object
+		// 节点使用单个错误对象拒绝整个批量
+		var single RpcResponse[json.RawMessage]
+		if json.Unmarshal(body, &single) == nil && single.Error != nil {
+			return nil, erero.Wro(single.Error)
+		}
+		return nil, erero.WithMessagef(ErrMalformedResponse, "%v", err)
+	}
+
+	// Match responses by ID, rejecting bad envelopes, unknown and repeated IDs
+	// 按 ID 匹配响应，拒绝错误封装、未知 ID 和重复 ID
+	responseMap := make(map[string]*RpcResponse[json.RawMessage], len(items))
+	for _, item := range items {
+		var head rpcHead
+		if err := json.Unmarshal(item, &head); err != nil {
+			return nil, erero.WithMessagef(ErrMalformedResponse, "%v", err)
+		}
+		key := rawIDKey(head.ID)
+		if err := head.check(key); err != nil {
+			return nil, erero.Wro(err)
+		}
+		var response RpcResponse[json.RawMessage]
+		if err := json.Unmarshal(item, &response); err != nil {
+			return nil, erero.WithMessagef(ErrMalformedResponse, "%v", err)
+		}
+		if (key == "" || key == "null") && response.Error != nil {
+			return nil, erero.Wro(response.Error)
+		}
+		if !requestKeys[key] {
+			return nil, erero.WithMessagef(ErrIDMismatch, "unknown id=%s", key)
+		}
+		if _, ok := responseMap[key]; ok {
+			return nil, erero.WithMessagef(ErrDuplicateID, "response id=%s", key)
+		}
+		responseMap[key] = &response
+	}
+
+	results := make([]*BatchResult[RES], 0, len(batch))
+	for _, request := range batch {
+		response, ok := responseMap[idKey(request.ID)]
+		if !ok {
+			return nil, erero.WithMessagef(ErrIDMismatch, "batch response missing id=%s method=%s", idKey(request.ID), request.Method)
+		}
+		result := &BatchResult[RES]{Request: request, Error: response.Error}
+		if response.Error == nil && len(response.Result) > 0 {
+			if err := json.Unmarshal(response.Result, &result.Result); err != nil {
+				return nil, erero.Wro(err)
+			}
+		}
+		results = append(results, result)
+	}
+
+	// Log parsed results in debug mode
+	// 在调试模式下记录解析的结果
+	if client.debugMode {
+		client.debugLog().Debugln("Batch Msg:", neatjsons.S(results))
+	}
+	return results, nil
+}
+
+// getBatchLimit returns effective batch limit of the client
+//
+// getBatchLimit 返回客户端生效的批量限制
+func (c *Client) getBatchLimit() int {
+	if c.batchLimit < 1 {
+		return DefaultBatchLimit
+	}
+	return c.batchLimit
+}
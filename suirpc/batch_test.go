@@ -0,0 +1,202 @@
+package suirpc_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync/atomic"
+	"testing"
+
+	"github.com/go-xlan/sui-go-guide/suirpc"
+	"github.com/stretchr/testify/require"
+)
+
+// newBatchServer starts local server answering batch arrays in reversed order
+// Method "bad_method" gets error object, "null_id" gets error object with null ID
+// Method "twice" gets answered twice, others echo first param
+// Counts HTTP calls so tests can check batch splitting
+//
+// newBatchServer 启动本地服务器，以倒序应答批量数组
+// 方法 "bad_method" 返回错误对象，"null_id" 返回空 ID 的错误对象
+// 方法 "twice" 被应答两次，其它方法回显第一个参数
+// 统计 HTTP 调用次数以便测试检查批量拆分
+func newBatchServer(t *testing.T, calls *atomic.Int64) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		var requests []*suirpc.RpcRequest
+		if err := json.NewDecoder(r.Body).Decode(&requests); err != nil {
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":null,"error":{"code":-32600,"message":"Invalid Request"}}`))
+			return
+		}
+		responses := make([]map[string]any, 0, len(requests))
+		for _, request := range requests {
+			if request.Method == "bad_method" {
+				responses = append(responses, map[string]any{"jsonrpc": "2.0", "id": request.ID, "error": map[string]any{"code": -32601, "message": "Method not found", "data": "bad_method"}})
+				continue
+			}
+			if request.Method == "null_id" {
+				responses = append(responses, map[string]any{"jsonrpc": "2.0", "id": nil, "error": map[string]any{"code": -32600, "message": "Invalid Request"}})
+				continue
+			}
+			if request.Method == "twice" {
+				responses = append(responses, map[string]any{"jsonrpc": "2.0", "id": request.ID, "result": request.Params[0]})
+			}
+			responses = append(responses, map[string]any{"jsonrpc": "2.0", "id": request.ID, "result": request.Params[0]})
+		}
+		slices.Reverse(responses)
+		_ = json.NewEncoder(w).Encode(responses)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// TestCallBatch tests batch results are matched by ID regardless of response order
+// Verifies per-request RPC errors and automatic splitting by batch limit
+//
+// TestCallBatch 测试批量结果按 ID 匹配而不受响应顺序影响
+// 验证按请求的 RPC 错误以及按批量限制自动拆分
+func TestCallBatch(t *testing.T) {
+	var calls atomic.Int64
+	server := newBatchServer(t, &calls)
+
+	client := suirpc.NewClient(server.URL).SetBatchLimit(2)
+
+	requests := []*suirpc.RpcRequest{
+		{Jsonrpc: "2.0", Method: "sui_getObject", Params: []any{"0x1"}},
+		{Jsonrpc: "2.0", Method: "bad_method", Params: []any{"0x2"}},
+		{Jsonrpc: "2.0", Method: "sui_getObject", Params: []any{"0x3"}},
+		{Jsonrpc: "2.0", Method: "sui_getObject", Params: []any{"0x4"}},
+		{Jsonrpc: "2.0", Method: "sui_getObject", Params: []any{"0x5"}},
+	}
+
+	results, err := suirpc.CallBatch[string](context.Background(), client, requests)
+	require.NoError(t, err)
+	require.Len(t, results, len(requests))
+	require.Equal(t, int64(3), calls.Load())
+
+	require.Equal(t, "0x1", results[0].Result)
+	require.NotNil(t, results[1].Error)
+	require.Equal(t, -32601, results[1].Error.Code)
+	require.Equal(t, "bad_method", results[1].Error.Data)
+	require.Equal(t, "0x3", results[2].Result)
+	require.Equal(t, "0x4", results[3].Result)
+	require.Equal(t, "0x5", results[4].Result)
+
+	// IDs are unique and caller requests stay unchanged
+	// ID 唯一且调用方请求保持不变
+	ids := map[any]bool{}
+	for idx, result := range results {
+		ids[result.Request.ID] = true
+		require.Nil(t, requests[idx].ID)
+	}
+	require.Len(t, ids, len(requests))
+}
+
+// TestSendBatchRpc tests package-level batch function with raw results
+//
+// TestSendBatchRpc 测试返回原始结果的包级批量函数
+func TestSendBatchRpc(t *testing.T) {
+	var calls atomic.Int64
+	server := newBatchServer(t, &calls)
+
+	results, err := suirpc.SendBatchRpc[json.RawMessage](context.Background(), server.URL, []*suirpc.RpcRequest{
+		{Jsonrpc: "2.0", Method: "sui_getTransactionBlock", Params: []any{"digest-1"}},
+		{Jsonrpc: "2.0", Method: "sui_getTransactionBlock", Params: []any{"digest-2"}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), calls.Load())
+	require.JSONEq(t, `"digest-1"`, string(results[0].Result))
+	require.JSONEq(t, `"digest-2"`, string(results[1].Result))
+}
+
+// TestCallBatch_CallerID tests caller IDs are kept and duplicates are rejected
+//
+// TestCallBatch_CallerID 测试保留调用方 ID 并拒绝重复 ID
+func TestCallBatch_CallerID(t *testing.T) {
+	var calls atomic.Int64
+	server := newBatchServer(t, &calls)
+	client := suirpc.NewClient(server.URL)
+
+	results, err := suirpc.CallBatch[string](context.Background(), client, []*suirpc.RpcRequest{
+		{Jsonrpc: "2.0", Method: "sui_getObject", Params: []any{"0x1"}, ID: "a"},
+		{Jsonrpc: "2.0", Method: "sui_getObject", Params: []any{"0x2"}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "a", results[0].Request.ID)
+	require.Equal(t, "0x1", results[0].Result)
+	require.Equal(t, "0x2", results[1].Result)
+
+	_, err = suirpc.CallBatch[string](context.Background(), client, []*suirpc.RpcRequest{
+		{Jsonrpc: "2.0", Method: "sui_getObject", Params: []any{"0x1"}, ID: "a"},
+		{Jsonrpc: "2.0", Method: "sui_getObject", Params: []any{"0x2"}, ID: "a"},
+	})
+	require.ErrorIs(t, err, suirpc.ErrDuplicateID)
+	require.Equal(t, int64(1), calls.Load())
+
+	// Duplicates in different chunks are rejected before sending
+	// 不同分块中的重复 ID 在发送前被拒绝
+	_, err = suirpc.CallBatch[string](context.Background(), client.SetBatchLimit(1), []*suirpc.RpcRequest{
+		{Jsonrpc: "2.0", Method: "sui_getObject", Params: []any{"0x1"}, ID: "a"},
+		{Jsonrpc: "2.0", Method: "sui_getObject", Params: []any{"0x2"}, ID: "a"},
+	})
+	require.ErrorIs(t, err, suirpc.ErrDuplicateID)
+	require.Equal(t, int64(1), calls.Load())
+}
+
+// TestCallBatch_RewrittenBatch tests responses are matched against batch that middlewares put on the wire
+//
+// TestCallBatch_RewrittenBatch 测试响应按中间件实际发出的批量进行匹配
+func TestCallBatch_RewrittenBatch(t *testing.T) {
+	var calls atomic.Int64
+	server := newBatchServer(t, &calls)
+	client := suirpc.NewClient(server.URL).Use(func(next suirpc.Handler) suirpc.Handler {
+		return func(ctx context.Context, call *suirpc.RpcCall) ([]byte, error) {
+			batch := make([]*suirpc.RpcRequest, 0, len(call.Batch))
+			for idx, request := range call.Batch {
+				clone := *request
+				clone.ID = fmt.Sprintf("rewritten-%d", idx)
+				batch = append(batch, &clone)
+			}
+			call.Batch = batch
+			return next(ctx, call)
+		}
+	})
+
+	results, err := suirpc.CallBatch[string](context.Background(), client, []*suirpc.RpcRequest{
+		{Jsonrpc: "2.0", Method: "sui_getObject", Params: []any{"0x1"}},
+		{Jsonrpc: "2.0", Method: "sui_getObject", Params: []any{"0x2"}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), calls.Load())
+	require.Equal(t, "rewritten-0", results[0].Request.ID)
+	require.Equal(t, "0x1", results[0].Result)
+	require.Equal(t, "rewritten-1", results[1].Request.ID)
+	require.Equal(t, "0x2", results[1].Result)
+}
+
+// TestCallBatch_BadResponse tests repeated response IDs and null-ID error items fail the batch
+//
+// TestCallBatch_BadResponse 测试重复的响应 ID 和空 ID 错误项使批量失败
+func TestCallBatch_BadResponse(t *testing.T) {
+	var calls atomic.Int64
+	server := newBatchServer(t, &calls)
+	client := suirpc.NewClient(server.URL)
+
+	_, err := suirpc.CallBatch[string](context.Background(), client, []*suirpc.RpcRequest{
+		{Jsonrpc: "2.0", Method: "twice", Params: []any{"0x1"}},
+		{Jsonrpc: "2.0", Method: "sui_getObject", Params: []any{"0x2"}},
+	})
+	require.ErrorIs(t, err, suirpc.ErrDuplicateID)
+
+	_, err = suirpc.CallBatch[string](context.Background(), client, []*suirpc.RpcRequest{
+		{Jsonrpc: "2.0", Method: "null_id", Params: []any{"0x1"}},
+		{Jsonrpc: "2.0", Method: "sui_getObject", Params: []any{"0x2"}},
+	})
+	var rpcError *suirpc.RpcError
+	require.ErrorAs(t, err, &rpcError)
+	require.Equal(t, -32600, rpcError.Code)
+	require.NotErrorIs(t, err, suirpc.ErrIDMismatch)
+}
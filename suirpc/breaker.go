@@ -0,0 +1,112 @@
+package suirpc
+
+import (
+	"time"
+)
+
+// BreakerState represents circuit breaker state of one pool endpoint
+//
+// BreakerState 表示池中单个端点的熔断器状态
+type BreakerState string
+
+const (
+	BreakerClosed   BreakerState = "closed"    // Calls flow normally // 调用正常通过
+	BreakerOpen     BreakerState = "open"      // Calls skip the endpoint // 调用跳过该端点
+	BreakerHalfOpen BreakerState = "half_open" // Few probe calls test recovery // 少量探测调用检验是否恢复
+)
+
+// BreakerPolicy represents circuit breaker settings applied to each pool endpoint
+// Consecutive endpoint failures open the breaker, calls then skip the endpoint
+// After OpenDuration the breaker turns half-open and lets probe calls through
+// Probe success closes it, probe failure opens it again
+//
+// BreakerPolicy 表示应用于池中每个端点的熔断器配置
+// 端点连续失败会打开熔断器，之后调用跳过该端点
+// 经过 OpenDuration 后熔断器变为半开，允许探测调用通过
+// 探测成功则关闭，探测失败则再次打开
+type BreakerPolicy struct {
+	FailureThreshold int           // Consecutive failures opening the breaker // 打开熔断器的连续失败次数
+	OpenDuration     time.Duration // Time open before turning half-open // 打开后转为半开前的时长
+	HalfOpenProbes   int           // Concurrent probe calls allowed when half-open // 半开时允许的并发探测调用数
+}
+
+// DefaultBreakerPolicy returns policy opening after five failures for thirty seconds
+//
+// DefaultBreakerPolicy 返回五次失败后打开三十秒的策略
+func DefaultBreakerPolicy() *BreakerPolicy {
+	return &BreakerPolicy{
+		FailureThreshold: 5,
+		OpenDuration:     30 * time.Second,
+		HalfOpenProbes:   1,
+	}
+}
+
+// SetBreakerPolicy sets circuit breaker policy of pool endpoints, nil disables breakers
+// Calls fail with ErrCircuitOpen without waiting when each endpoint is open
+//
+// SetBreakerPolicy 设置池中端点的熔断器策略，nil 表示禁用熔断器
+// 所有端点都打开时调用不等待直接以 ErrCircuitOpen 失败
+func (pool *EndpointPool) SetBreakerPolicy(policy *BreakerPolicy) *EndpointPool {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+	pool.breaker = policy
+	for _, endpoint := range pool.endpoints {
+		endpoint.State = BreakerClosed
+		endpoint.probes = 0
+	}
+	return pool
+}
+
+// acquire checks if breaker of endpoint lets a call through and takes a probe slot when half-open
+// Each granted call must be followed by release
+//
+// acquire 检查端点熔断器是否允许调用通过，半开时占用一个探测名额
+// 每个获准的调用之后都必须调用 release
+func (pool *EndpointPool) acquire(serverUrl string) bool {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+	endpoint := pool.find(serverUrl)
+	if endpoint == nil || pool.breaker == nil {
+		return endpoint != nil
+	}
+	if endpoint.State == BreakerOpen && time.Since(endpoint.openedAt) >= pool.breaker.OpenDuration {
+		endpoint.State = BreakerHalfOpen
+	}
+	switch endpoint.State {
+	case BreakerOpen:
+		return false
+	case BreakerHalfOpen:
+		if endpoint.probes >= max(pool.breaker.HalfOpenProbes, 1) {
+			return false
+		}
+		endpoint.probes++
+	}
+	return true
+}
+
+// release frees probe slot taken by acquire
+//
+// release 释放 acquire 占用的探测名额
+func (pool *EndpointPool) release(serverUrl string) {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+	if endpoint := pool.find(serverUrl); endpoint != nil && endpoint.probes > 0 {
+		endpoint.probes--
+	}
+}
+
+// tripBreaker moves breaker of endpoint after call outcome, caller holds the mutex
+//
+// tripBreaker 根据调用结果切换端点熔断器，调用方需持有互斥锁
+func (pool *EndpointPool) tripBreaker(endpoint *Endpoint, success bool) {
+	if pool.breaker == nil {
+		return
+	}
+	switch {
+	case success:
+		endpoint.State = BreakerClosed
+	case endpoint.State == BreakerHalfOpen || endpoint.Failures >= max(pool.breaker.FailureThreshold, 1):
+		endpoint.State = BreakerOpen
+		endpoint.openedAt = time.Now()
+	}
+}
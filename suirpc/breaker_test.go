@@ -0,0 +1,78 @@
+package suirpc_test
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-xlan/sui-go-guide/suirpc"
+	"github.com/stretchr/testify/require"
+)
+
+// TestEndpointPool_Breaker tests breaker opens after failures, skips endpoint, then closes after probe
+//
+// TestEndpointPool_Breaker 测试熔断器在失败后打开并跳过端点，探测成功后关闭
+func TestEndpointPool_Breaker(t *testing.T) {
+	var status, calls atomic.Int64
+	status.Store(http.StatusBadGateway)
+	node := newStandInNode(t, "node", "35834a8a", &status, &calls)
+
+	pool := suirpc.NewEndpointPool(node.URL).SetBreakerPolicy(&suirpc.BreakerPolicy{
+		FailureThreshold: 2,
+		OpenDuration:     50 * time.Millisecond,
+		HalfOpenProbes:   1,
+	})
+	client := suirpc.NewClient("").SetEndpointPool(pool)
+	request := &suirpc.RpcRequest{Jsonrpc: "2.0", Method: "sui_getTotalTransactionBlocks", Params: []any{}}
+
+	for range 2 {
+		_, err := client.SendRpc(context.Background(), request)
+		require.ErrorIs(t, err, &suirpc.ErrHTTPStatus{Code: http.StatusBadGateway})
+	}
+	require.Equal(t, suirpc.BreakerOpen, pool.Endpoints()[0].State)
+
+	// Open breaker fails fast without reaching the node
+	// 打开的熔断器快速失败，不会到达节点
+	_, err := client.SendRpc(context.Background(), request)
+	require.ErrorIs(t, err, suirpc.ErrCircuitOpen)
+	require.Equal(t, int64(2), calls.Load())
+
+	// Failed probe opens the breaker again
+	// 探测失败会再次打开熔断器
+	time.Sleep(60 * time.Millisecond)
+	_, err = client.SendRpc(context.Background(), request)
+	require.ErrorIs(t, err, &suirpc.ErrHTTPStatus{Code: http.StatusBadGateway})
+	require.Equal(t, suirpc.BreakerOpen, pool.Endpoints()[0].State)
+
+	status.Store(http.StatusOK)
+	time.Sleep(60 * time.Millisecond)
+	_, err = client.SendRpc(context.Background(), request)
+	require.NoError(t, err)
+	require.Equal(t, suirpc.BreakerClosed, pool.Endpoints()[0].State)
+	require.Equal(t, int64(4), calls.Load())
+}
+
+// TestEndpointPool_BreakerFailover tests calls skip open endpoint and go to the healthy one
+//
+// TestEndpointPool_BreakerFailover 测试调用跳过打开的端点并发往健康端点
+func TestEndpointPool_BreakerFailover(t *testing.T) {
+	var status1, status2, calls1, calls2 atomic.Int64
+	status1.Store(http.StatusServiceUnavailable)
+	status2.Store(http.StatusOK)
+	node1 := newStandInNode(t, "node1", "35834a8a", &status1, &calls1)
+	node2 := newStandInNode(t, "node2", "35834a8a", &status2, &calls2)
+
+	pool := suirpc.NewEndpointPool(node1.URL, node2.URL).SetBreakerPolicy(&suirpc.BreakerPolicy{FailureThreshold: 1, OpenDuration: time.Minute})
+	client := suirpc.NewClient("").SetEndpointPool(pool)
+
+	request := &suirpc.RpcRequest{Jsonrpc: "2.0", Method: "sui_getTotalTransactionBlocks", Params: []any{}}
+	for range 4 {
+		res, err := suirpc.Call[string](context.Background(), client, request)
+		require.NoError(t, err)
+		require.Equal(t, "node2", res.Result)
+	}
+	require.Equal(t, int64(1), calls1.Load())
+	require.Equal(t, int64(4), calls2.Load())
+}
@@ -0,0 +1,317 @@
+package suirpc
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/yyle88/erero"
+	"github.com/yyle88/zaplog"
+	"go.uber.org/zap"
+)
+
+// CacheStore represents storage backend of cached RPC results
+// Zero TTL keeps value until evicted
+//
+// CacheStore 表示已缓存 RPC 结果的存储后端
+// TTL 为零时值一直保留直到被淘汰
+type CacheStore interface {
+	// Get returns cached value of key, false when missing or expired
+	// Get 返回键的缓存值，缺失或过期时返回 false
+	Get(key string) ([]byte, bool)
+	// Set stores value of key with given TTL
+	// Set 以给定 TTL 存储键的值
+	Set(key string, value []byte, ttl time.Duration) error
+}
+
+// CachePolicy represents allowlist of cacheable methods with TTL of each
+// Zero TTL suits immutable data, positive TTL suits data that changes
+// Methods missing from the policy skip the cache
+//
+// CachePolicy 表示可缓存方法的白名单及其 TTL
+// TTL 为零适用于不可变数据，正 TTL 适用于会变化的数据
+// 策略中没有的方法不经过缓存
+type CachePolicy map[string]time.Duration
+
+// DefaultCachePolicy returns policy caching final data forever and volatile data briefly
+// Final data: checkpoints, executed transactions, past objects, normalized Move modules
+// Volatile data: latest checkpoint number, reference gas price and balances
+//
+// DefaultCachePolicy 返回永久缓存最终数据、短暂缓存易变数据的策略
+// 最终数据：检查点、已执行交易、历史对象、规范化 Move 模块
+// 易变数据：最新检查点序号、参考 gas 价格和余额
+func DefaultCachePolicy() CachePolicy {
+	return CachePolicy{
+		"sui_getCheckpoint":                     0,
+		"sui_getTransactionBlock":               0,
+		"sui_multiGetTransactionBlocks":         0,
+		"sui_tryGetPastObject":                  0,
+		"sui_tryMultiGetPastObjects":            0,
+		"sui_getNormalizedMoveModule":           0,
+		"sui_getNormalizedMoveModulesByPackage": 0,
+		"sui_getNormalizedMoveFunction":         0,
+		"sui_getNormalizedMoveStruct":           0,
+		"sui_getLatestCheckpointSequenceNumber": time.Second,
+		"suix_getReferenceGasPrice":             time.Minute,
+		"suix_getBalance":                       5 * time.Second,
+		"suix_getAllBalances":                   5 * time.Second,
+		"suix_getCoinMetadata":                  time.Hour,
+	}
+}
+
+// CacheMiddleware returns middleware answering allowlisted calls from store
+// Keys calls by endpoint, method and compact params JSON, caches results without error only
+// Pooled calls use the pool name as endpoint, pool endpoints serve one chain
+// Batch calls, health checks and chain checks pass through without caching
+//
+// CacheMiddleware 返回从存储中应答白名单调用的中间件
+// 按端点、方法和紧凑参数 JSON 作为键，仅缓存无错误的结果
+// 经端点池的调用以端点池名称作为端点，池中端点服务同一条链
+// 批量调用、健康检查和链校验直接透传，不做缓存
+func CacheMiddleware(store CacheStore, policy CachePolicy) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, call *RpcCall) ([]byte, error) {
+			if call.Request == nil || skipsCache(ctx) {
+				return next(ctx, call)
+			}
+			ttl, ok := policy[call.Request.Method]
+			if !ok {
+				return next(ctx, call)
+			}
+			key, err := matchKey(call.Request.Method, call.Request.Params)
+			if err != nil {
+				return nil, erero.Wro(err)
+			}
+			key = call.destination() + " " + key
+
+			// Answer from cache with ID of this request
+			// 使用本次请求的 ID 从缓存应答
+			if result, ok := store.Get(key); ok {
+				data, err := json.Marshal(map[string]any{"jsonrpc": "2.0", "id": call.Request.ID, "result": json.RawMessage(result)})
+				if err != nil {
+					return nil, erero.Wro(err)
+				}
+				return data, nil
+			}
+
+			data, err := next(ctx, call)
+			if err != nil {
+				return nil, erero.Wro(err)
+			}
+			var response RpcResponse[json.RawMessage]
+			if err := json.Unmarshal(data, &response); err != nil || response.Error != nil || len(response.Result) == 0 || string(response.Result) == "null" {
+				return data, nil
+			}
+			if err := store.Set(key, response.Result, ttl); err != nil {
+				zaplog.LOG.Warn("rpc cache set", zap.String("method", call.Request.Method), zap.Error(err))
+			}
+			return data, nil
+		}
+	}
+}
+
+// noCacheKey represents context key marking calls that must reach the node
+//
+// noCacheKey 表示标记必须访问节点的调用的上下文键
+type noCacheKey struct{}
+
+// withoutCache returns context making CacheMiddleware pass calls through
+//
+// withoutCache 返回使 CacheMiddleware 透传调用的上下文
+func withoutCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noCacheKey{}, true)
+}
+
+// skipsCache checks if context asks calls to skip the cache
+//
+// skipsCache 检查上下文是否要求调用跳过缓存
+func skipsCache(ctx context.Context) bool {
+	skip, _ := ctx.Value(noCacheKey{}).(bool)
+	return skip
+}
+
+// memoryEntry represents one value kept in MemoryCache
+//
+// memoryEntry 表示 MemoryCache 中保存的单个值
+type memoryEntry struct {
+	key       string    // Cache key // 缓存键
+	value     []byte    // Cached value // 缓存值
+	expiresAt time.Time // Expiry time, zero means never // 过期时间，零值表示永不过期
+}
+
+// MemoryCache represents in-memory LRU cache store
+// Evicts least recently used entries once capacity is reached
+// Safe to use across goroutines
+//
+// MemoryCache 表示内存 LRU 缓存存储
+// 达到容量后淘汰最近最少使用的条目
+// 可在多个 goroutine 间安全使用
+type MemoryCache struct {
+	mutex    sync.Mutex               // Guards fields below // 保护以下字段
+	capacity int                      // Max entries kept // 最多保留的条目数
+	order    *list.List               // Entries from most to least recently used // 按最近使用顺序排列的条目
+	entries  map[string]*list.Element // Entries by key // 按键索引的条目
+}
+
+// NewMemoryCache creates LRU cache keeping at most capacity entries
+//
+// NewMemoryCache 创建最多保留 capacity 个条目的 LRU 缓存
+func NewMemoryCache(capacity int) *MemoryCache {
+	return &MemoryCache{
+		capacity: max(capacity, 1),
+		order:    list.New(),
+		entries:  map[string]*list.Element{},
+	}
+}
+
+// Get returns cached value of key and marks it recently used
+//
+// Get 返回键的缓存值并将其标记为最近使用
+func (cache *MemoryCache) Get(key string) ([]byte, bool) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	element, ok := cache.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := element.Value.(*memoryEntry)
+	if !entry.expiresAt.IsZero() && time.Now().After(entry.expiresAt) {
+		cache.order.Remove(element)
+		delete(cache.entries, key)
+		return nil, false
+	}
+	cache.order.MoveToFront(element)
+	return entry.value, true
+}
+
+// Set stores value of key and evicts least recently used entries over capacity
+//
+// Set 存储键的值并淘汰超出容量的最近最少使用条目
+func (cache *MemoryCache) Set(key string, value []byte, ttl time.Duration) error {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	entry := &memoryEntry{key: key, value: value, expiresAt: expiryTime(ttl)}
+	if element, ok := cache.entries[key]; ok {
+		element.Value = entry
+		cache.order.MoveToFront(element)
+		return nil
+	}
+	cache.entries[key] = cache.order.PushFront(entry)
+	for cache.order.Len() > cache.capacity {
+		oldest := cache.order.Back()
+		cache.order.Remove(oldest)
+		delete(cache.entries, oldest.Value.(*memoryEntry).key)
+	}
+	return nil
+}
+
+// Len returns number of entries kept in cache
+//
+// Len 返回缓存中保留的条目数
+func (cache *MemoryCache) Len() int {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	return cache.order.Len()
+}
+
+// DiskCache represents cache store keeping one JSON file per key in a directory
+// Survives restarts, suits tools fetching same final data run after run
+//
+// DiskCache 表示在目录中每个键保存一个 JSON 文件的缓存存储
+// 重启后仍然保留，适合每次运行都获取相同最终数据的工具
+type DiskCache struct {
+	root string // Cache directory // 缓存目录
+}
+
+// diskEntry represents content of one DiskCache file
+//
+// diskEntry 表示单个 DiskCache 文件的内容
+type diskEntry struct {
+	Key       string          `json:"key"`       // Cache key // 缓存键
+	ExpiresAt time.Time       `json:"expiresAt"` // Expiry time, zero means never // 过期时间，零值表示永不过期
+	Value     json.RawMessage `json:"value"`     // Cached value // 缓存值
+}
+
+// NewDiskCache creates disk cache in given directory, creating it when missing
+//
+// NewDiskCache 在给定目录创建磁盘缓存，目录缺失时创建
+func NewDiskCache(root string) (*DiskCache, error) {
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, erero.Wro(err)
+	}
+	return &DiskCache{root: root}, nil
+}
+
+// Get returns cached value of key, removing the file once expired
+//
+// Get 返回键的缓存值，过期后删除文件
+func (cache *DiskCache) Get(key string) ([]byte, bool) {
+	path := cache.path(key)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	var entry diskEntry
+	if err := json.Unmarshal(data, &entry); err != nil || entry.Key != key {
+		return nil, false
+	}
+	if !entry.ExpiresAt.IsZero() && time.Now().After(entry.ExpiresAt) {
+		_ = os.Remove(path)
+		return nil, false
+	}
+	return entry.Value, true
+}
+
+// Set writes value of key through temp file and rename so readers never see partial files
+// Value must be valid JSON, as RPC results are
+//
+// Set 通过临时文件和重命名写入键的值，使读取方不会看到不完整的文件
+// 值必须是合法 JSON，RPC 结果即是如此
+func (cache *DiskCache) Set(key string, value []byte, ttl time.Duration) error {
+	data, err := json.Marshal(&diskEntry{Key: key, ExpiresAt: expiryTime(ttl), Value: value})
+	if err != nil {
+		return erero.Wro(err)
+	}
+	temp, err := os.CreateTemp(cache.root, "tmp-*")
+	if err != nil {
+		return erero.Wro(err)
+	}
+	defer func() { _ = os.Remove(temp.Name()) }()
+	if _, err := temp.Write(data); err != nil {
+		_ = temp.Close()
+		return erero.Wro(err)
+	}
+	if err := temp.Close(); err != nil {
+		return erero.Wro(err)
+	}
+	if err := os.Rename(temp.Name(), cache.path(key)); err != nil {
+		return erero.Wro(err)
+	}
+	return nil
+}
+
+// path returns file path of key, named by SHA-256 of the key
+//
+// path 返回键对应的文件路径，以键的 SHA-256 命名
+func (cache *DiskCache) path(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return filepath.Join(cache.root, hex.EncodeToString(hash[:])+".json")
+}
+
+// expiryTime returns expiry time of TTL, zero time when TTL is zero
+//
+// expiryTime 返回 TTL 对应的过期时间，TTL 为零时返回零值时间
+func expiryTime(ttl time.Duration) time.Time {
+	if ttl <= 0 {
+		return time.Time{}
+	}
+	return time.Now().Add(ttl)
+}
@@ -0,0 +1,128 @@
+package suirpc_test
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-xlan/sui-go-guide/suirpc"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCacheMiddleware tests allowlisted calls hit the node once and others each time
+// Cached answers carry ID of the current request
+//
+// TestCacheMiddleware 测试白名单调用只访问节点一次，其它调用每次都访问
+// 缓存应答携带当前请求的 ID
+func TestCacheMiddleware(t *testing.T) {
+	var status, calls atomic.Int64
+	status.Store(http.StatusOK)
+	node := newStandInNode(t, "node", "35834a8a", &status, &calls)
+
+	store := suirpc.NewMemoryCache(16)
+	client := suirpc.NewClient(node.URL).Use(suirpc.CacheMiddleware(store, suirpc.DefaultCachePolicy()))
+	for idx := range 3 {
+		res, err := suirpc.Call[string](context.Background(), client, &suirpc.RpcRequest{Jsonrpc: "2.0", Method: "sui_getCheckpoint", Params: []any{"100"}, ID: idx + 100})
+		require.NoError(t, err)
+		require.Equal(t, "node", res.Result)
+		require.Equal(t, float64(idx+100), res.ID)
+	}
+	require.Equal(t, int64(1), calls.Load())
+
+	// Other params and methods outside the policy reach the node
+	// 其它参数以及策略之外的方法会访问节点
+	_, err := suirpc.Call[string](context.Background(), client, &suirpc.RpcRequest{Jsonrpc: "2.0", Method: "sui_getCheckpoint", Params: []any{"101"}})
+	require.NoError(t, err)
+	for range 2 {
+		_, err := suirpc.Call[string](context.Background(), client, &suirpc.RpcRequest{Jsonrpc: "2.0", Method: "suix_getCoins", Params: []any{"0x1"}})
+		require.NoError(t, err)
+	}
+	require.Equal(t, int64(4), calls.Load())
+	require.Equal(t, 2, store.Len())
+}
+
+// TestCacheMiddleware_Endpoint tests shared store keeps results apart by endpoint
+// Health checks and chain checks reach the node even when answers are cached
+//
+// TestCacheMiddleware_Endpoint 测试共享存储按端点区分结果
+// 即使应答已被缓存，健康检查和链校验仍会访问节点
+func TestCacheMiddleware_Endpoint(t *testing.T) {
+	var status, mainnetCalls, testnetCalls atomic.Int64
+	status.Store(http.StatusOK)
+	mainnet := newStandInNode(t, "mainnet", suirpc.Mainnet.ChainId, &status, &mainnetCalls)
+	testnet := newStandInNode(t, "testnet", suirpc.Testnet.ChainId, &status, &testnetCalls)
+
+	store := suirpc.NewMemoryCache(16)
+	policy := suirpc.CachePolicy{"sui_getChainIdentifier": 0, "sui_getCheckpoint": 0}
+	request := &suirpc.RpcRequest{Jsonrpc: "2.0", Method: "sui_getCheckpoint", Params: []any{"100"}}
+	for range 2 {
+		res, err := suirpc.Call[string](context.Background(), suirpc.NewClient(mainnet.URL).Use(suirpc.CacheMiddleware(store, policy)), request)
+		require.NoError(t, err)
+		require.Equal(t, "mainnet", res.Result)
+		res, err = suirpc.Call[string](context.Background(), suirpc.NewClient(testnet.URL).Use(suirpc.CacheMiddleware(store, policy)), request)
+		require.NoError(t, err)
+		require.Equal(t, "testnet", res.Result)
+	}
+	require.Equal(t, int64(1), mainnetCalls.Load())
+	require.Equal(t, int64(1), testnetCalls.Load())
+
+	// Cached chain identifier does not answer chain checks
+	// 已缓存的链标识符不会应答链校验
+	client := suirpc.NewClient(mainnet.URL).Use(suirpc.CacheMiddleware(store, policy))
+	require.NoError(t, client.Send(context.Background(), &suirpc.RpcRequest{Jsonrpc: "2.0", Method: "sui_getChainIdentifier", Params: []any{}}, nil))
+	require.Equal(t, int64(2), mainnetCalls.Load())
+	require.NoError(t, client.SetChainIdentifier(suirpc.Mainnet.ChainId).VerifyChain(context.Background()))
+	require.Equal(t, int64(3), mainnetCalls.Load())
+
+	pool := suirpc.NewEndpointPool(mainnet.URL)
+	for range 2 {
+		require.NoError(t, pool.CheckHealth(context.Background(), client))
+	}
+	require.Equal(t, int64(5), mainnetCalls.Load())
+}
+
+// TestMemoryCache tests LRU eviction and TTL expiry
+//
+// TestMemoryCache 测试 LRU 淘汰和 TTL 过期
+func TestMemoryCache(t *testing.T) {
+	cache := suirpc.NewMemoryCache(2)
+	require.NoError(t, cache.Set("a", []byte(`1`), 0))
+	require.NoError(t, cache.Set("b", []byte(`2`), 0))
+	_, ok := cache.Get("a")
+	require.True(t, ok)
+	require.NoError(t, cache.Set("c", []byte(`3`), 0))
+	_, ok = cache.Get("b")
+	require.False(t, ok) // Least recently used gets evicted // 最近最少使用的被淘汰
+
+	require.NoError(t, cache.Set("d", []byte(`4`), 10*time.Millisecond))
+	_, ok = cache.Get("d")
+	require.True(t, ok)
+	time.Sleep(20 * time.Millisecond)
+	_, ok = cache.Get("d")
+	require.False(t, ok)
+}
+
+// TestDiskCache tests values survive new cache instance and expire by TTL
+//
+// TestDiskCache 测试值在新缓存实例中仍然存在并按 TTL 过期
+func TestDiskCache(t *testing.T) {
+	root := t.TempDir()
+	cache, err := suirpc.NewDiskCache(root)
+	require.NoError(t, err)
+	require.NoError(t, cache.Set(`sui_getCheckpoint ["100"]`, []byte(`{"digest":"abc"}`), 0))
+	require.NoError(t, cache.Set(`suix_getBalance ["0x1"]`, []byte(`"1"`), 10*time.Millisecond))
+
+	reopened, err := suirpc.NewDiskCache(root)
+	require.NoError(t, err)
+	value, ok := reopened.Get(`sui_getCheckpoint ["100"]`)
+	require.True(t, ok)
+	require.JSONEq(t, `{"digest":"abc"}`, string(value))
+
+	time.Sleep(20 * time.Millisecond)
+	_, ok = reopened.Get(`suix_getBalance ["0x1"]`)
+	require.False(t, ok)
+	_, ok = reopened.Get(`suix_getBalance ["0x2"]`)
+	require.False(t, ok)
+}
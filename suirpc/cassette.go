@@ -0,0 +1,266 @@
+package suirpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/yyle88/erero"
+)
+
+// ErrCassetteMiss means replay found no recorded interaction matching the request
+//
+// ErrCassetteMiss 表示回放时没有匹配请求的已录制交互
+var ErrCassetteMiss = errors.New("cassette has no interaction matching request")
+
+// CassetteMode represents whether cassette records live calls or replays recorded ones
+//
+// CassetteMode 表示磁带录制实时调用还是回放已录制的调用
+type CassetteMode string
+
+const (
+	CassetteRecord CassetteMode = "record" // Send calls to the node and write them to file // 将调用发送到节点并写入文件
+	CassetteReplay CassetteMode = "replay" // Serve calls from file without network // 不经网络从文件提供调用
+)
+
+// Interaction represents one recorded request and its response
+// Matched on replay by method and params
+//
+// Interaction 表示一次已录制的请求及其响应
+// 回放时按方法和参数匹配
+type Interaction struct {
+	Method string          `json:"method"`           // RPC method name // RPC 方法名
+	Params json.RawMessage `json:"params"`           // Request params as sent // 发送时的请求参数
+	Result json.RawMessage `json:"result,omitempty"` // Recorded result // 已录制的结果
+	Error  *RpcError       `json:"error,omitempty"`  // Recorded error object // 已录制的错误对象
+}
+
+// Cassette represents file of recorded JSON-RPC interactions
+// Record mode passes calls through and appends them to the file
+// Replay mode answers calls from the file and never touches network
+// Same request recorded several times replays in recorded order, last one repeats
+//
+// Cassette 表示已录制 JSON-RPC 交互的文件
+// 录制模式透传调用并将其追加到文件
+// 回放模式从文件应答调用，从不访问网络
+// 同一请求录制多次时按录制顺序回放，最后一次重复使用
+type Cassette struct {
+	path         string         // Cassette file path // 磁带文件路径
+	mode         CassetteMode   // Record or replay // 录制或回放
+	mutex        sync.Mutex     // Guards fields below // 保护以下字段
+	interactions []*Interaction // Interactions in recorded order // 按录制顺序排列的交互
+	played       map[string]int // Replay count by match key // 按匹配键统计的回放次数
+}
+
+// NewCassette creates cassette at given path in given mode
+// Replay mode loads the file and fails when it is missing
+//
+// NewCassette 在给定路径以给定模式创建磁带
+// 回放模式加载文件，文件缺失时失败
+func NewCassette(path string, mode CassetteMode) (*Cassette, error) {
+	cassette := &Cassette{path: path, mode: mode, played: map[string]int{}}
+	if mode != CassetteReplay {
+		return cassette, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, erero.Wro(err)
+	}
+	var content struct {
+		Interactions []*Interaction `json:"interactions"`
+	}
+	if err := json.Unmarshal(data, &content); err != nil {
+		return nil, erero.Wro(err)
+	}
+	cassette.interactions = content.Interactions
+	return cassette, nil
+}
+
+// Interactions returns recorded interactions
+//
+// Interactions 返回已录制的交互
+func (cassette *Cassette) Interactions() []*Interaction {
+	cassette.mutex.Lock()
+	defer cassette.mutex.Unlock()
+	return append([]*Interaction(nil), cassette.interactions...)
+}
+
+// Middleware returns middleware recording or replaying calls per cassette mode
+//
+// Middleware 返回按磁带模式录制或回放调用的中间件
+func (cassette *Cassette) Middleware() Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, call *RpcCall) ([]byte, error) {
+			if cassette.mode == CassetteReplay {
+				return cassette.replay(call)
+			}
+			data, err := next(ctx, call)
+			if err != nil {
+				return nil, erero.Wro(err)
+			}
+			if call.streamed {
+				return data, nil // Streamed body went to the consumer, nothing to record // 流式响应体已交给消费者，无可录制内容
+			}
+			if err := cassette.record(call, data); err != nil {
+				return nil, erero.Wro(err)
+			}
+			return data, nil
+		}
+	}
+}
+
+// replay builds response body of call from recorded interactions
+//
+// replay 根据已录制的交互构建调用的响应体
+func (cassette *Cassette) replay(call *RpcCall) ([]byte, error) {
+	cassette.mutex.Lock()
+	defer cassette.mutex.Unlock()
+
+	if call.Request != nil {
+		response, err := cassette.answer(call.Request)
+		if err != nil {
+			return nil, erero.Wro(err)
+		}
+		data, err := json.Marshal(response)
+		if err != nil {
+			return nil, erero.Wro(err)
+		}
+		return data, nil
+	}
+	responses := make([]map[string]any, 0, len(call.Batch))
+	for _, request := range call.Batch {
+		response, err := cassette.answer(request)
+		if err != nil {
+			return nil, erero.Wro(err)
+		}
+		responses = append(responses, response)
+	}
+	data, err := json.Marshal(responses)
+	if err != nil {
+		return nil, erero.Wro(err)
+	}
+	return data, nil
+}
+
+// answer finds interaction of request and builds response object, caller holds the mutex
+//
+// answer 查找请求对应的交互并构建响应对象，调用方需持有互斥锁
+func (cassette *Cassette) answer(request *RpcRequest) (map[string]any, error) {
+	key, err := matchKey(request.Method, request.Params)
+	if err != nil {
+		return nil, erero.Wro(err)
+	}
+
+	var matches []*Interaction
+	for _, interaction := range cassette.interactions {
+		if interactionKey(interaction) == key {
+			matches = append(matches, interaction)
+		}
+	}
+	if len(matches) == 0 {
+		params, _ := json.Marshal(request.Params)
+		return nil, erero.WithMessagef(ErrCassetteMiss, "cassette=%s method=%s params=%s", cassette.path, request.Method, params)
+	}
+	interaction := matches[min(cassette.played[key], len(matches)-1)]
+	cassette.played[key]++
+
+	response := map[string]any{"jsonrpc": "2.0", "id": request.ID}
+	if interaction.Error != nil {
+		response["error"] = interaction.Error
+	} else {
+		response["result"] = interaction.Result
+	}
+	return response, nil
+}
+
+// record appends interactions of call and writes cassette file
+//
+// record 追加调用的交互并写入磁带文件
+func (cassette *Cassette) record(call *RpcCall, data []byte) error {
+	var interactions []*Interaction
+	if call.Request != nil {
+		var response RpcResponse[json.RawMessage]
+		if err := json.Unmarshal(data, &response); err != nil {
+			return erero.Wro(err)
+		}
+		interaction, err := newInteraction(call.Request, &response)
+		if err != nil {
+			return erero.Wro(err)
+		}
+		interactions = append(interactions, interaction)
+	} else {
+		var responses []*RpcResponse[json.RawMessage]
+		if err := json.Unmarshal(data, &responses); err != nil {
+			return erero.Wro(err)
+		}
+		requestMap := make(map[string]*RpcRequest, len(call.Batch))
+		for _, request := range call.Batch {
+			requestMap[idKey(request.ID)] = request
+		}
+		for _, response := range responses {
+			request, ok := requestMap[idKey(response.ID)]
+			if !ok {
+				continue
+			}
+			interaction, err := newInteraction(request, response)
+			if err != nil {
+				return erero.Wro(err)
+			}
+			interactions = append(interactions, interaction)
+		}
+	}
+
+	cassette.mutex.Lock()
+	defer cassette.mutex.Unlock()
+	cassette.interactions = append(cassette.interactions, interactions...)
+	return cassette.save()
+}
+
+// save writes interactions to cassette file, caller holds the mutex
+//
+// save 将交互写入磁带文件，调用方需持有互斥锁
+func (cassette *Cassette) save() error {
+	data, err := json.MarshalIndent(map[string]any{"interactions": cassette.interactions}, "", "  ")
+	if err != nil {
+		return erero.Wro(err)
+	}
+	if err := os.MkdirAll(filepath.Dir(cassette.path), 0o755); err != nil {
+		return erero.Wro(err)
+	}
+	if err := os.WriteFile(cassette.path, data, 0o644); err != nil {
+		return erero.Wro(err)
+	}
+	return nil
+}
+
+// newInteraction creates interaction from request and its response
+//
+// newInteraction 根据请求及其响应创建交互
+func newInteraction(request *RpcRequest, response *RpcResponse[json.RawMessage]) (*Interaction, error) {
+	params, err := json.Marshal(request.Params)
+	if err != nil {
+		return nil, erero.Wro(err)
+	}
+	return &Interaction{Method: request.Method, Params: params, Result: response.Result, Error: response.Error}, nil
+}
+
+// matchKey returns key matching request by method and compact params JSON
+//
+// matchKey 返回按方法和紧凑参数 JSON 匹配请求的键
+func matchKey(method string, params []any) (string, error) {
+	data, err := json.Marshal(params)
+	if err != nil {
+		return "", erero.Wro(err)
+	}
+	return method + " " + compactJSON(data), nil
+}
+
+// interactionKey returns match key of recorded interaction
+//
+// interactionKey 返回已录制交互的匹配键
+func interactionKey(interaction *Interaction) string {
+	return interaction.Method + " " + compactJSON(interaction.Params)
+}
@@ -0,0 +1,80 @@
+package suirpc_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/go-xlan/sui-go-guide/suirpc"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCassette tests calls recorded against live server replay without network
+// Unmatched request fails with ErrCassetteMiss
+//
+// TestCassette 测试针对实时服务器录制的调用可在无网络时回放
+// 不匹配的请求以 ErrCassetteMiss 失败
+func TestCassette(t *testing.T) {
+	server := newEchoServer(t)
+	path := filepath.Join(t.TempDir(), "cassettes", "echo.json")
+
+	recorder, err := suirpc.NewCassette(path, suirpc.CassetteRecord)
+	require.NoError(t, err)
+	client := suirpc.NewClient(server.URL).SetHeader("X-Network", "testnet").Use(recorder.Middleware())
+	recorded, err := suirpc.Call[map[string]string](context.Background(), client, &suirpc.RpcRequest{Jsonrpc: "2.0", Method: "suix_getBalance", Params: []any{"0xabc"}})
+	require.NoError(t, err)
+	require.Error(t, client.Send(context.Background(), &suirpc.RpcRequest{Jsonrpc: "2.0", Method: "bad_method", Params: []any{}}, nil))
+	require.Len(t, recorder.Interactions(), 2)
+
+	// Replay against unreachable URL proves no network is used
+	// 针对不可达 URL 回放以证明未使用网络
+	player, err := suirpc.NewCassette(path, suirpc.CassetteReplay)
+	require.NoError(t, err)
+	client = suirpc.NewClient("http://127.0.0.1:1").Use(player.Middleware())
+	replayed, err := suirpc.Call[map[string]string](context.Background(), client, &suirpc.RpcRequest{Jsonrpc: "2.0", Method: "suix_getBalance", Params: []any{"0xabc"}})
+	require.NoError(t, err)
+	require.Equal(t, recorded.Result, replayed.Result)
+
+	// Recorded error objects replay as errors
+	// 已录制的错误对象作为错误回放
+	err = client.Send(context.Background(), &suirpc.RpcRequest{Jsonrpc: "2.0", Method: "bad_method", Params: []any{}}, nil)
+	var rpcError *suirpc.RpcError
+	require.True(t, errors.As(err, &rpcError))
+	require.Equal(t, suirpc.CodeMethodNotFound, rpcError.Code)
+
+	err = client.Send(context.Background(), &suirpc.RpcRequest{Jsonrpc: "2.0", Method: "suix_getBalance", Params: []any{"0xdef"}}, nil)
+	require.ErrorIs(t, err, suirpc.ErrCassetteMiss)
+	require.Contains(t, err.Error(), `params=["0xdef"]`)
+}
+
+// TestCassette_Batch tests batch calls record and replay per request
+//
+// TestCassette_Batch 测试批量调用按请求录制和回放
+func TestCassette_Batch(t *testing.T) {
+	var calls atomic.Int64
+	server := newBatchServer(t, &calls)
+	path := filepath.Join(t.TempDir(), "batch.json")
+
+	recorder, err := suirpc.NewCassette(path, suirpc.CassetteRecord)
+	require.NoError(t, err)
+	requests := []*suirpc.RpcRequest{
+		{Jsonrpc: "2.0", Method: "sui_getObject", Params: []any{"0x1"}},
+		{Jsonrpc: "2.0", Method: "sui_getObject", Params: []any{"0x2"}},
+	}
+	_, err = suirpc.CallBatch[string](context.Background(), suirpc.NewClient(server.URL).Use(recorder.Middleware()), requests)
+	require.NoError(t, err)
+
+	player, err := suirpc.NewCassette(path, suirpc.CassetteReplay)
+	require.NoError(t, err)
+	client := suirpc.NewClient("http://127.0.0.1:1").Use(player.Middleware())
+	results, err := suirpc.CallBatch[string](context.Background(), client, requests)
+	require.NoError(t, err)
+	require.Equal(t, "0x1", results[0].Result)
+	require.Equal(t, "0x2", results[1].Result)
+	require.Equal(t, int64(1), calls.Load())
+
+	_, err = suirpc.CallBatch[string](context.Background(), client, []*suirpc.RpcRequest{{Jsonrpc: "2.0", Method: "sui_getObject", Params: []any{"0x3"}}})
+	require.ErrorIs(t, err, suirpc.ErrCassetteMiss)
+}
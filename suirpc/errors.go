@@ -0,0 +1,154 @@
+package suirpc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+var (
+	ErrRateLimited      = errors.New("rate limited")                 // Node rejected call due to rate limit // 节点因限流拒绝调用
+	ErrObjectNotFound   = errors.New("object not found")             // Requested object or transaction does not exist // 请求的对象或交易不存在
+	ErrInvalidParams    = errors.New("invalid params")               // Node rejected call params // 节点拒绝调用参数
+	ErrTransport        = errors.New("transport failure")            // Call failed in network transport // 调用在网络传输中失败
+	ErrExecutionFailed  = errors.New("transaction execution failed") // Transaction ran with failure status // 交易以失败状态执行
+	ErrResponseTooLarge = errors.New("response too large")           // Response body exceeds client size limit // 响应体超过客户端大小限制
+	ErrCircuitOpen      = errors.New("circuit open")                 // Each pool endpoint has open breaker // 池中每个端点的熔断器都已打开
+)
+
+// JSON-RPC error codes used by Sui fullnodes
+//
+// Sui 全节点使用的 JSON-RPC 错误码
+const (
+	CodeInvalidRequest = -32600 // Request is not valid JSON-RPC // 请求不是有效的 JSON-RPC
+	CodeMethodNotFound = -32601 // Method does not exist // 方法不存在
+	CodeInvalidParams  = -32602 // Params are invalid // 参数无效
+	CodeInternalError  = -32603 // Node internal error // 节点内部错误
+)
+
+// ErrHTTPStatus represents non-200 HTTP response from the node
+// Matches ErrRateLimited on 429, and any ErrHTTPStatus of the same code
+// Keeps Retry-After wait to drive retries
+//
+// ErrHTTPStatus 表示节点返回的非 200 HTTP 响应
+// 状态为 429 时匹配 ErrRateLimited，也匹配相同状态码的 ErrHTTPStatus
+// 保留 Retry-After 等待时间以驱动重试
+type ErrHTTPStatus struct {
+	Code       int           // HTTP status code // HTTP 状态码
+	Status     string        // HTTP status text // HTTP 状态文本
+	Body       []byte        // Response body as returned // 原样返回的响应体
+	RetryAfter time.Duration // Wait asked by Retry-After header // Retry-After 响应头要求的等待时间
+}
+
+// Error returns HTTP status text
+//
+// Error 返回 HTTP 状态文本
+func (e *ErrHTTPStatus) Error() string {
+	if e.Status == "" {
+		return fmt.Sprintf("http status %d", e.Code)
+	}
+	return e.Status
+}
+
+// Is matches ErrRateLimited on 429 and ErrHTTPStatus with the same code
+//
+// Is 在 429 时匹配 ErrRateLimited，并匹配相同状态码的 ErrHTTPStatus
+func (e *ErrHTTPStatus) Is(target error) bool {
+	if target == ErrRateLimited {
+		return e.Code == http.StatusTooManyRequests
+	}
+	var other *ErrHTTPStatus
+	if errors.As(target, &other) {
+		return e.Code == other.Code
+	}
+	return false
+}
+
+// Is matches RPC error with sentinel errors by code and message
+// Data payload stays on the RpcError, reach it with errors.As
+//
+// Is 按错误码和消息将 RPC 错误与哨兵错误匹配
+// 数据载荷保留在 RpcError 上，可通过 errors.As 获取
+func (rpcError *RpcError) Is(target error) bool {
+	message := strings.ToLower(rpcError.Message)
+	switch target {
+	case ErrInvalidParams:
+		return rpcError.Code == CodeInvalidParams
+	case ErrObjectNotFound:
+		if rpcError.Code == CodeMethodNotFound {
+			return false
+		}
+		return strings.Contains(message, "not found") ||
+			strings.Contains(message, "could not find") ||
+			strings.Contains(message, "notexists") ||
+			strings.Contains(message, "does not exist")
+	case ErrRateLimited:
+		return rpcError.Code == http.StatusTooManyRequests ||
+			strings.Contains(message, "rate limit") ||
+			strings.Contains(message, "too many requests")
+	default:
+		return false
+	}
+}
+
+// ExecutionError represents transaction that ran but ended with failure status
+// Matches ErrExecutionFailed and keeps the raw response to inspect effects
+//
+// ExecutionError 表示已执行但以失败状态结束的交易
+// 匹配 ErrExecutionFailed，并保留原始响应以便检查效果
+type ExecutionError struct {
+	Digest  string          // Transaction digest, empty in dry run // 交易摘要，模拟执行时为空
+	Message string          // Failure text such as MoveAbort details // 失败描述，例如 MoveAbort 详情
+	Raw     json.RawMessage // Raw response result // 原始响应结果
+}
+
+// Error returns failure text with digest
+//
+// Error 返回带摘要的失败描述
+func (e *ExecutionError) Error() string {
+	if e.Digest == "" {
+		return fmt.Sprintf("execution failed: %s", e.Message)
+	}
+	return fmt.Sprintf("execution failed: digest=%s %s", e.Digest, e.Message)
+}
+
+// Is matches ErrExecutionFailed
+//
+// Is 匹配 ErrExecutionFailed
+func (e *ExecutionError) Is(target error) bool {
+	return target == ErrExecutionFailed
+}
+
+// CheckExecution returns ExecutionError when transaction result has failure status
+// Accepts raw result of execute or dry run calls
+//
+// CheckExecution 在交易结果为失败状态时返回 ExecutionError
+// 接受执行或模拟执行调用的原始结果
+func CheckExecution(raw json.RawMessage) error {
+	var result struct {
+		Digest  string `json:"digest"`
+		Effects *struct {
+			Status struct {
+				Status string `json:"status"`
+				Error  string `json:"error"`
+			} `json:"status"`
+		} `json:"effects"`
+	}
+	if err := json.Unmarshal(raw, &result); err != nil {
+		return nil // Result of other shape, nothing to check // 其它形状的结果，无需检查
+	}
+	if result.Effects == nil || result.Effects.Status.Status != "failure" {
+		return nil
+	}
+	return &ExecutionError{Digest: result.Digest, Message: result.Effects.Status.Error, Raw: raw}
+}
+
+// transportError marks error coming from network transport with ErrTransport
+//
+// transportError 使用 ErrTransport 标记来自网络传输的错误
+func transportError(err error) error {
+	return fmt.Errorf("%w: %w", ErrTransport, err)
+}
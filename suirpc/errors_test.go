@@ -0,0 +1,96 @@
+package suirpc_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-xlan/sui-go-guide/suirpc"
+	"github.com/stretchr/testify/require"
+)
+
+// TestErrHTTPStatus tests HTTP 429 matches ErrRateLimited and keeps status code
+//
+// TestErrHTTPStatus 测试 HTTP 429 匹配 ErrRateLimited 并保留状态码
+func TestErrHTTPStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte("slow down"))
+	}))
+	defer server.Close()
+
+	client := suirpc.NewClient(server.URL)
+	_, err := client.SendRpc(context.Background(), &suirpc.RpcRequest{Jsonrpc: "2.0", Method: "sui_getChainIdentifier", Params: []any{}})
+	require.ErrorIs(t, err, suirpc.ErrRateLimited)
+	require.ErrorIs(t, err, &suirpc.ErrHTTPStatus{Code: http.StatusTooManyRequests})
+	require.NotErrorIs(t, err, suirpc.ErrTransport)
+
+	var statusErr *suirpc.ErrHTTPStatus
+	require.True(t, errors.As(err, &statusErr))
+	require.Equal(t, http.StatusTooManyRequests, statusErr.Code)
+	require.Equal(t, "slow down", string(statusErr.Body))
+}
+
+// TestRpcError_Is tests RPC errors match sentinels and keep data payload
+//
+// TestRpcError_Is 测试 RPC 错误匹配哨兵错误并保留数据载荷
+func TestRpcError_Is(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request suirpc.RpcRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		rpcError := map[string]any{"code": -32602, "message": "Invalid params", "data": map[string]any{"field": "digest"}}
+		if request.Method == "sui_getTransactionBlock" {
+			rpcError = map[string]any{"code": -32602, "message": "Could not find the referenced transaction [TransactionDigest(abc)]."}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": request.ID, "error": rpcError})
+	}))
+	defer server.Close()
+	client := suirpc.NewClient(server.URL)
+
+	_, err := client.SendRpc(context.Background(), &suirpc.RpcRequest{Jsonrpc: "2.0", Method: "sui_getObject", Params: []any{"bad"}})
+	require.ErrorIs(t, err, suirpc.ErrInvalidParams)
+	require.NotErrorIs(t, err, suirpc.ErrObjectNotFound)
+	var rpcError *suirpc.RpcError
+	require.True(t, errors.As(err, &rpcError))
+	require.Equal(t, map[string]any{"field": "digest"}, rpcError.Data)
+
+	_, err = client.SendRpc(context.Background(), &suirpc.RpcRequest{Jsonrpc: "2.0", Method: "sui_getTransactionBlock", Params: []any{"abc"}})
+	require.ErrorIs(t, err, suirpc.ErrObjectNotFound)
+}
+
+// TestErrTransport tests unreachable node gives ErrTransport
+//
+// TestErrTransport 测试无法访问的节点返回 ErrTransport
+func TestErrTransport(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	serverUrl := server.URL
+	server.Close()
+
+	_, err := suirpc.NewClient(serverUrl).SendRpc(context.Background(), &suirpc.RpcRequest{Jsonrpc: "2.0", Method: "sui_getChainIdentifier", Params: []any{}})
+	require.ErrorIs(t, err, suirpc.ErrTransport)
+	var statusErr *suirpc.ErrHTTPStatus
+	require.False(t, errors.As(err, &statusErr))
+}
+
+// TestCheckExecution tests failure status gives ExecutionError with digest and raw result
+//
+// TestCheckExecution 测试失败状态返回带摘要和原始结果的 ExecutionError
+func TestCheckExecution(t *testing.T) {
+	raw := json.RawMessage(`{"digest":"5gk9","effects":{"status":{"status":"failure","error":"MoveAbort(MoveLocation { module: ModuleId { address: 0x2, name: Identifier(\"balance\") }, function: 4, instruction: 9, function_name: Some(\"split\") }, 2) in command 0"}}}`)
+	err := suirpc.CheckExecution(raw)
+	require.ErrorIs(t, err, suirpc.ErrExecutionFailed)
+
+	var executionError *suirpc.ExecutionError
+	require.True(t, errors.As(err, &executionError))
+	require.Equal(t, "5gk9", executionError.Digest)
+	require.Contains(t, executionError.Message, "MoveAbort")
+	require.JSONEq(t, string(raw), string(executionError.Raw))
+
+	require.NoError(t, suirpc.CheckExecution(json.RawMessage(`{"digest":"5gk9","effects":{"status":{"status":"success"}}}`)))
+}
@@ -0,0 +1,153 @@
+package suirpc
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/yyle88/erero"
+)
+
+// HedgePolicy represents hedged read settings of endpoint pool
+// Read call waiting longer than the latency percentile sends a copy to the next endpoint
+// First answer wins and the others get canceled
+// Writes and streamed calls are never hedged
+//
+// HedgePolicy 表示端点池的对冲读配置
+// 读调用等待超过延迟分位数时，向下一个端点发送副本
+// 最先返回的应答胜出，其余调用被取消
+// 写调用和流式调用从不对冲
+type HedgePolicy struct {
+	Percentile float64       // Latency percentile (0-1) of recent successful calls // 近期成功调用的延迟分位数（0-1）
+	MinDelay   time.Duration // Lower bound of hedge delay // 对冲延迟下限
+	MaxDelay   time.Duration // Upper bound of hedge delay, used before enough samples // 对冲延迟上限，样本不足时使用
+	MaxHedges  int           // Extra copies sent at most // 最多发送的额外副本数
+}
+
+// DefaultHedgePolicy returns policy hedging once after p95 latency, between 50ms and 2s
+//
+// DefaultHedgePolicy 返回在 p95 延迟后对冲一次、延迟介于 50ms 和 2s 的策略
+func DefaultHedgePolicy() *HedgePolicy {
+	return &HedgePolicy{
+		Percentile: 0.95,
+		MinDelay:   50 * time.Millisecond,
+		MaxDelay:   2 * time.Second,
+		MaxHedges:  1,
+	}
+}
+
+// SetHedgePolicy sets hedged read policy of the pool, nil disables hedging
+//
+// SetHedgePolicy 设置端点池的对冲读策略，nil 表示禁用对冲
+func (pool *EndpointPool) SetHedgePolicy(policy *HedgePolicy) *EndpointPool {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+	pool.hedge = policy
+	return pool
+}
+
+// hedgePolicy returns hedge policy when call is worth hedging, nil otherwise
+//
+// hedgePolicy 在调用值得对冲时返回对冲策略，否则返回 nil
+func (pool *EndpointPool) hedgePolicy(call *RpcCall) *HedgePolicy {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+	if pool.hedge == nil || pool.hedge.MaxHedges <= 0 || call.stream != nil || call.IsWrite() {
+		return nil
+	}
+	return pool.hedge
+}
+
+// hedgeDelay returns wait before sending next copy, percentile of recent latencies within bounds
+//
+// hedgeDelay 返回发送下一个副本前的等待时间，为近期延迟的分位数并限制在上下限之间
+func (pool *EndpointPool) hedgeDelay(policy *HedgePolicy) time.Duration {
+	pool.mutex.Lock()
+	samples := slices.Clone(pool.samples)
+	pool.mutex.Unlock()
+
+	if len(samples) < minHedgeSamples {
+		return max(policy.MaxDelay, policy.MinDelay)
+	}
+	slices.Sort(samples)
+	index := int(float64(len(samples)-1) * min(max(policy.Percentile, 0), 1))
+	delay := max(samples[index], policy.MinDelay)
+	if policy.MaxDelay > 0 {
+		delay = min(delay, policy.MaxDelay)
+	}
+	return delay
+}
+
+// hedgeAnswer represents answer of one hedged copy
+//
+// hedgeAnswer 表示单个对冲副本的应答
+type hedgeAnswer struct {
+	serverUrl string // Endpoint answering // 应答的端点
+	data      []byte // Response body // 响应体
+	err       error  // Failure // 失败
+}
+
+// postHedged sends read call to first endpoint and copies to next ones after hedge delay
+// Failed copies fail over at once, first success wins and cancels the rest
+//
+// postHedged 将读调用发送到首个端点，经过对冲延迟后向后续端点发送副本
+// 失败的副本立即故障转移，首个成功的应答胜出并取消其余调用
+func (c *Client) postHedged(ctx context.Context, call *RpcCall, serverUrls []string, policy *HedgePolicy) ([]byte, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	answers := make(chan hedgeAnswer, len(serverUrls))
+	var next, pending, hedges int
+	launch := func() bool {
+		for next < len(serverUrls) {
+			serverUrl := serverUrls[next]
+			next++
+			if !c.pool.acquire(serverUrl) {
+				continue
+			}
+			attempt := *call
+			go func() {
+				data, err := c.postEndpoint(ctx, serverUrl, &attempt)
+				answers <- hedgeAnswer{serverUrl: serverUrl, data: data, err: err}
+			}()
+			pending++
+			return true
+		}
+		return false
+	}
+	if !launch() {
+		return nil, erero.Wro(ErrCircuitOpen)
+	}
+
+	timer := time.NewTimer(c.pool.hedgeDelay(policy))
+	defer timer.Stop()
+	var errs []error
+	for pending > 0 {
+		select {
+		case <-timer.C:
+			if hedges < policy.MaxHedges && launch() {
+				hedges++
+				if c.debugMode {
+					c.debugLog().Debugln("Hedge:", call.Methods(), "copies:", hedges)
+				}
+				timer.Reset(c.pool.hedgeDelay(policy))
+			}
+		case answer := <-answers:
+			pending--
+			if answer.err == nil {
+				call.endpoint = answer.serverUrl
+				return answer.data, nil
+			}
+			errs = append(errs, answer.err)
+			if !failoverable(answer.err) {
+				call.endpoint = answer.serverUrl
+				return nil, erero.Wro(answer.err)
+			}
+			if ctx.Err() == nil {
+				launch()
+			}
+		}
+	}
+	return nil, erero.Wro(errors.Join(errs...))
+}
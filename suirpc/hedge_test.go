@@ -0,0 +1,57 @@
+package suirpc_test
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-xlan/sui-go-guide/suirpc"
+	"github.com/stretchr/testify/require"
+)
+
+// TestEndpointPool_Hedge tests slow read gets hedged to second endpoint and writes never do
+//
+// TestEndpointPool_Hedge 测试慢速读调用被对冲到第二个端点，而写调用从不对冲
+func TestEndpointPool_Hedge(t *testing.T) {
+	var status, slowCalls, fastCalls atomic.Int64
+	status.Store(http.StatusOK)
+	slow := newStandInNode(t, "slow", "35834a8a", &status, &slowCalls, withDelay(2*time.Second))
+	fast := newStandInNode(t, "fast", "35834a8a", &status, &fastCalls)
+
+	policy := &suirpc.HedgePolicy{
+		Percentile: 0.95,
+		MinDelay:   10 * time.Millisecond,
+		MaxDelay:   30 * time.Millisecond,
+		MaxHedges:  1,
+	}
+	pool := suirpc.NewEndpointPool(slow.URL, fast.URL).SetHedgePolicy(policy)
+	client := suirpc.NewClient("").SetEndpointPool(pool)
+
+	// Round-robin starts at the slow endpoint
+	// 轮询从慢速端点开始
+	startTime := time.Now()
+	res, err := suirpc.Call[string](context.Background(), client, &suirpc.RpcRequest{Jsonrpc: "2.0", Method: "sui_getTotalTransactionBlocks", Params: []any{}})
+	require.NoError(t, err)
+	require.Equal(t, "fast", res.Result)
+	require.Less(t, time.Since(startTime), time.Second)
+	require.Equal(t, int64(1), slowCalls.Load())
+	require.Equal(t, int64(1), fastCalls.Load())
+
+	// Canceled loser keeps its endpoint healthy
+	// 被取消的落败者保持其端点健康
+	require.Eventually(t, func() bool {
+		return pool.Endpoints()[0].Healthy
+	}, time.Second, 10*time.Millisecond)
+
+	// Writes wait for the one endpoint they went to
+	// 写调用等待其发往的唯一端点
+	writePool := suirpc.NewEndpointPool(slow.URL, fast.URL).SetHedgePolicy(policy)
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+	_, err = suirpc.NewClient("").SetEndpointPool(writePool).SendRpc(ctx, &suirpc.RpcRequest{Jsonrpc: "2.0", Method: "sui_executeTransactionBlock", Params: []any{}})
+	require.Error(t, err)
+	require.Equal(t, int64(2), slowCalls.Load())
+	require.Equal(t, int64(1), fastCalls.Load())
+}
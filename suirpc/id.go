@@ -0,0 +1,115 @@
+package suirpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+
+	"github.com/yyle88/erero"
+)
+
+var (
+	ErrMalformedResponse = errors.New("malformed json-rpc response")    // Response is not valid JSON-RPC 2.0 envelope // 响应不是有效的 JSON-RPC 2.0 封装
+	ErrIDMismatch        = errors.New("json-rpc response id mismatch")  // Response ID differs from request ID // 响应 ID 与请求 ID 不一致
+	ErrDuplicateID       = errors.New("json-rpc request id duplicated") // Batch holds same ID twice // 批量中存在重复 ID
+)
+
+// withID returns request carrying ID, assigns next ID when caller left it empty
+// Caller request stays unchanged, copy is returned when ID gets assigned
+//
+// withID 返回带 ID 的请求，调用方未设置时分配下一个 ID
+// 调用方请求保持不变，分配 ID 时返回副本
+func withID(request *RpcRequest, nextID func() int) *RpcRequest {
+	if !isEmptyID(request.ID) {
+		return request
+	}
+	clone := *request
+	clone.ID = nextID()
+	return &clone
+}
+
+// isEmptyID checks if request ID is unset, nil and empty string count as unset
+// Numeric zero is a valid caller ID and is kept
+//
+// isEmptyID 检查请求 ID 是否未设置，nil 和空字符串视为未设置
+// 数字零是有效的调用方 ID，会被保留
+func isEmptyID(id any) bool {
+	switch value := id.(type) {
+	case nil:
+		return true
+	case string:
+		return value == ""
+	default:
+		return false
+	}
+}
+
+// idKey returns compact JSON text of request ID used to match responses
+// Numeric 7 and string "7" give different keys, as JSON-RPC demands
+//
+// idKey 返回请求 ID 的紧凑 JSON 文本，用于匹配响应
+// 数字 7 与字符串 "7" 得到不同的键，符合 JSON-RPC 要求
+func idKey(id any) string {
+	data, err := json.Marshal(id)
+	if err != nil {
+		return ""
+	}
+	return rawIDKey(data)
+}
+
+// rawIDKey returns compact JSON text of ID read from response
+//
+// rawIDKey 返回从响应读取的 ID 的紧凑 JSON 文本
+func rawIDKey(raw json.RawMessage) string {
+	return compactJSON(raw)
+}
+
+// compactJSON returns JSON text without insignificant spaces, input as is when invalid
+//
+// compactJSON 返回去除无意义空白的 JSON 文本，无效时原样返回
+func compactJSON(raw []byte) string {
+	var buffer bytes.Buffer
+	if err := json.Compact(&buffer, raw); err != nil {
+		return string(raw)
+	}
+	return buffer.String()
+}
+
+// rpcHead represents envelope fields checked before decoding result
+//
+// rpcHead 表示解码结果前需要校验的封装字段
+type rpcHead struct {
+	Jsonrpc string          `json:"jsonrpc"`         // JSON-RPC version // JSON-RPC 版本
+	ID      json.RawMessage `json:"id"`              // Response ID // 响应 ID
+	Error   json.RawMessage `json:"error,omitempty"` // Error object // 错误对象
+}
+
+// check validates version and ID against request ID key
+// Null ID passes only with error object, when node failed to read the request
+//
+// check 校验版本以及 ID 与请求 ID 键是否一致
+// 仅当带有错误对象时允许空 ID，表示节点未能读取请求
+func (head *rpcHead) check(key string) error {
+	if head.Jsonrpc != "2.0" {
+		return erero.WithMessagef(ErrMalformedResponse, "jsonrpc=%q", head.Jsonrpc)
+	}
+	got := rawIDKey(head.ID)
+	if (got == "" || got == "null") && len(head.Error) > 0 {
+		return nil
+	}
+	if got != key {
+		return erero.WithMessagef(ErrIDMismatch, "want=%s got=%s", key, got)
+	}
+	return nil
+}
+
+// checkResponse validates envelope of single response body against request
+//
+// checkResponse 校验单个响应体的封装与请求是否匹配
+func checkResponse(body []byte, request *RpcRequest) error {
+	var head rpcHead
+	if err := json.Unmarshal(body, &head); err != nil {
+		return erero.WithMessagef(ErrMalformedResponse, "%v", err)
+	}
+	return head.check(idKey(request.ID))
+}
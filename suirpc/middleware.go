@@ -0,0 +1,269 @@
+package suirpc
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/yyle88/erero"
+	"github.com/yyle88/zaplog"
+	"go.uber.org/zap"
+)
+
+// RpcCall represents one JSON-RPC exchange passing through the middleware chain
+// Holds single request or batch requests, plus headers sent with this exchange
+// Middlewares may rewrite requests and headers before calling next handler
+//
+// RpcCall 表示经过中间件链的一次 JSON-RPC 交换
+// 持有单个请求或批量请求，以及此次交换携带的请求头
+// 中间件可在调用下一个处理器前改写请求和请求头
+type RpcCall struct {
+	Request *RpcRequest   // Single request, nil in batch calls // 单个请求，批量调用时为 nil
+	Batch   []*RpcRequest // Batch requests, nil in single calls // 批量请求，单个调用时为 nil
+	Header  http.Header   // Headers sent with this call, starts from client headers // 此次调用携带的请求头，初始为客户端请求头
+
+	serverUrl string                       // Endpoint pinned by health checks, bypassing pool // 健康检查固定的端点，绕过端点池
+	route     string                       // Bound server URL or pool name the call heads to // 调用前往的绑定服务器 URL 或端点池名称
+	endpoint  string                       // Endpoint the call was last posted to // 调用最后发送到的端点
+	stream    func(reader io.Reader) error // Consumer decoding body while it arrives, nil buffers body // 边接收边解码响应体的消费者，nil 时缓冲响应体
+	streamed  bool                         // Transport handed body to stream consumer // 传输层已将响应体交给流式消费者
+	observers []EndpointObserver           // Observers of each post to an endpoint // 每次向端点发送的观察者
+}
+
+// EndpointObserver observes one post of call to an endpoint
+// Called before posting, returns context to post with and function receiving the outcome
+// Runs on each endpoint tried in failover and each hedged copy, possibly at the same time
+//
+// EndpointObserver 观察调用向某个端点的一次发送
+// 在发送前调用，返回用于发送的上下文以及接收结果的函数
+// 在故障转移尝试的每个端点和每个对冲副本上运行，可能同时运行
+type EndpointObserver func(ctx context.Context, serverUrl string) (context.Context, func(data []byte, err error))
+
+// ObserveEndpoints adds observer called on each post of this call to an endpoint
+// Middlewares use it to see failover and hedged posts hidden below them
+//
+// ObserveEndpoints 添加在此调用每次向端点发送时调用的观察者
+// 中间件借此观察其下层隐藏的故障转移和对冲发送
+func (call *RpcCall) ObserveEndpoints(observer EndpointObserver) {
+	call.observers = append(slices.Clip(call.observers), observer)
+}
+
+// Methods returns method names carried by this call
+//
+// Methods 返回此次调用携带的方法名
+func (call *RpcCall) Methods() []string {
+	if call.Request != nil {
+		return []string{call.Request.Method}
+	}
+	methods := make([]string, 0, len(call.Batch))
+	for _, request := range call.Batch {
+		methods = append(methods, request.Method)
+	}
+	return methods
+}
+
+// Endpoint returns server URL the call was last posted to
+// Empty when a middleware answered without reaching the transport
+//
+// Endpoint 返回调用最后发送到的服务器 URL
+// 中间件未经传输层直接应答时为空
+func (call *RpcCall) Endpoint() string {
+	return call.endpoint
+}
+
+// IsWrite checks if this call carries any write method
+//
+// IsWrite 检查此次调用是否携带写方法
+func (call *RpcCall) IsWrite() bool {
+	return slices.ContainsFunc(call.Methods(), IsWriteMethod)
+}
+
+// destination returns server URL the call goes to, pool name when the pool picks the endpoint
+//
+// destination 返回调用前往的服务器 URL，由端点池选择端点时返回端点池名称
+func (call *RpcCall) destination() string {
+	if call.serverUrl != "" {
+		return call.serverUrl
+	}
+	return call.route
+}
+
+// body returns JSON body to post, single object or batch array
+//
+// body 返回要发送的 JSON 主体，单个对象或批量数组
+func (call *RpcCall) body() any {
+	if call.Request != nil {
+		return call.Request
+	}
+	return call.Batch
+}
+
+// Handler sends JSON-RPC call and returns raw response body
+//
+// Handler 发送 JSON-RPC 调用并返回原始响应体
+type Handler func(ctx context.Context, call *RpcCall) ([]byte, error)
+
+// Middleware wraps handler to inspect or rewrite calls, response bytes and errors
+// Works like http.RoundTripper wrappers but at JSON-RPC level
+// Runs on each attempt, inside retries
+//
+// Middleware 包装处理器以检查或改写调用、响应字节和错误
+// 类似 http.RoundTripper 包装器，但工作在 JSON-RPC 层
+// 在重试内部的每次尝试上运行
+type Middleware func(next Handler) Handler
+
+// Use appends middlewares to the client chain
+// First middleware added is the outermost one
+//
+// Use 向客户端链追加中间件
+// 最先添加的中间件位于最外层
+func (c *Client) Use(middlewares ...Middleware) *Client {
+	c.middlewares = append(slices.Clip(c.middlewares), middlewares...)
+	return c
+}
+
+// newCall creates call carrying request copies and client headers
+// Copies keep middleware rewrites away from caller requests and later attempts
+//
+// newCall 创建携带请求副本和客户端请求头的调用
+// 副本使中间件改写不影响调用方请求和后续尝试
+func (c *Client) newCall(request *RpcRequest, batch []*RpcRequest) *RpcCall {
+	call := &RpcCall{Header: http.Header{}, route: c.serverUrl}
+	if c.pool != nil {
+		call.route = c.pool.name
+	}
+	for key, value := range c.headers {
+		call.Header.Set(key, value)
+	}
+	if request != nil {
+		clone := *request
+		call.Request = &clone
+	}
+	for _, request := range batch {
+		clone := *request
+		call.Batch = append(call.Batch, &clone)
+	}
+	return call
+}
+
+// roundTrip runs call through middleware chain down to the transport
+//
+// roundTrip 使调用经过中间件链到达传输层
+func (c *Client) roundTrip(ctx context.Context, call *RpcCall) ([]byte, error) {
+	handler := Handler(c.transmit)
+	for idx := len(c.middlewares) - 1; idx >= 0; idx-- {
+		handler = c.middlewares[idx](handler)
+	}
+	return handler(ctx, call)
+}
+
+// transmit posts call to the bound endpoint or endpoint pool
+// Returns raw response body or error on failure
+//
+// transmit 向绑定的端点或端点池发送调用
+// 返回原始响应体，失败时返回错误
+func (c *Client) transmit(ctx context.Context, call *RpcCall) ([]byte, error) {
+	if call.serverUrl != "" {
+		return c.post(ctx, call.serverUrl, call)
+	}
+	if c.pool != nil {
+		return c.postPool(ctx, call)
+	}
+	return c.post(ctx, c.serverUrl, call)
+}
+
+// post sends call to given endpoint, reporting the post to call observers
+// Streamed calls hand body to the call consumer and return nil body
+//
+// post 将调用发送到给定端点，并向调用的观察者报告此次发送
+// 流式调用将响应体交给调用的消费者并返回 nil 响应体
+func (c *Client) post(ctx context.Context, serverUrl string, call *RpcCall) (data []byte, err error) {
+	call.endpoint = serverUrl
+	for _, observer := range call.observers {
+		var finish func(data []byte, err error)
+		ctx, finish = observer(ctx, serverUrl)
+		defer func() { finish(data, err) }()
+	}
+	if call.stream == nil {
+		return c.postTo(ctx, serverUrl, call.Header, call.body())
+	}
+	err = c.postStream(ctx, serverUrl, call.Header, call.body(), func(reader io.Reader) error {
+		call.streamed = true
+		return call.stream(reader)
+	})
+	if err != nil {
+		return nil, erero.Wro(err)
+	}
+	return nil, nil
+}
+
+// LoggingMiddleware logs each call with methods, ID, latency, size and error
+// Uses zaplog.LOG when logger is nil
+//
+// LoggingMiddleware 记录每次调用的方法、ID、延迟、大小和错误
+// 日志记录器为 nil 时使用 zaplog.LOG
+func LoggingMiddleware(logger *zap.Logger) Middleware {
+	if logger == nil {
+		logger = zaplog.LOG
+	}
+	return func(next Handler) Handler {
+		return func(ctx context.Context, call *RpcCall) ([]byte, error) {
+			startTime := time.Now()
+			data, err := next(ctx, call)
+			fields := []zap.Field{
+				zap.Strings("methods", call.Methods()),
+				zap.Duration("latency", time.Since(startTime)),
+				zap.Int("response_size", len(data)),
+			}
+			if call.Request != nil {
+				fields = append(fields, zap.Any("id", call.Request.ID), zap.Any("params", call.Request.Params))
+			}
+			if err != nil {
+				logger.Warn("rpc call failed", append(fields, zap.Error(err))...)
+				return nil, erero.Wro(err)
+			}
+			logger.Info("rpc call", fields...)
+			return data, nil
+		}
+	}
+}
+
+// HeaderMiddleware sets given headers on each call
+// Suits API keys of paid RPC providers
+//
+// HeaderMiddleware 在每次调用上设置给定的请求头
+// 适用于付费 RPC 服务商的 API 密钥
+func HeaderMiddleware(headers map[string]string) Middleware {
+	return HeaderFuncMiddleware(func(ctx context.Context) (map[string]string, error) {
+		return headers, nil
+	})
+}
+
+// HeaderFuncMiddleware sets headers computed on each call
+// Suits tokens that expire and need refreshing
+//
+// HeaderFuncMiddleware 在每次调用上设置实时计算的请求头
+// 适用于会过期并需要刷新的令牌
+func HeaderFuncMiddleware(headerFunc func(ctx context.Context) (map[string]string, error)) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, call *RpcCall) ([]byte, error) {
+			headers, err := headerFunc(ctx)
+			if err != nil {
+				return nil, erero.Wro(err)
+			}
+			for key, value := range headers {
+				call.Header.Set(key, value)
+			}
+			return next(ctx, call)
+		}
+	}
+}
+
+// BearerTokenMiddleware sets Authorization header with bearer token
+//
+// BearerTokenMiddleware 使用 Bearer 令牌设置 Authorization 请求头
+func BearerTokenMiddleware(token string) Middleware {
+	return HeaderMiddleware(map[string]string{"Authorization": "Bearer " + token})
+}
@@ -0,0 +1,79 @@
+package suirpc_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/go-xlan/sui-go-guide/suirpc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// TestClient_Use tests middlewares run in order and can rewrite request and response
+//
+// TestClient_Use 测试中间件按顺序运行，并可改写请求和响应
+func TestClient_Use(t *testing.T) {
+	server := newEchoServer(t)
+
+	var trace []string
+	tracing := func(name string) suirpc.Middleware {
+		return func(next suirpc.Handler) suirpc.Handler {
+			return func(ctx context.Context, call *suirpc.RpcCall) ([]byte, error) {
+				trace = append(trace, name+">")
+				data, err := next(ctx, call)
+				trace = append(trace, "<"+name)
+				return data, err
+			}
+		}
+	}
+	rewriting := func(next suirpc.Handler) suirpc.Handler {
+		return func(ctx context.Context, call *suirpc.RpcCall) ([]byte, error) {
+			call.Request.Method = "suix_getBalance"
+			data, err := next(ctx, call)
+			if err != nil {
+				return nil, err
+			}
+			return bytes.ReplaceAll(data, []byte("mainnet"), []byte("testnet")), nil
+		}
+	}
+
+	client := suirpc.NewClient(server.URL).
+		Use(tracing("outer"), tracing("inner")).
+		Use(suirpc.HeaderMiddleware(map[string]string{"X-Network": "mainnet"}), rewriting)
+
+	request := &suirpc.RpcRequest{Jsonrpc: "2.0", Method: "sui_getChainIdentifier", Params: []any{}}
+	res, err := suirpc.Call[map[string]string](context.Background(), client, request)
+	require.NoError(t, err)
+	require.Equal(t, "suix_getBalance", res.Result["method"])
+	require.Equal(t, "testnet", res.Result["network"])
+	require.Equal(t, []string{"outer>", "inner>", "<inner", "<outer"}, trace)
+	require.Equal(t, "sui_getChainIdentifier", request.Method) // Caller request stays unchanged // 调用方请求保持不变
+}
+
+// TestLoggingMiddleware tests structured log entry per call, including failed ones
+//
+// TestLoggingMiddleware 测试每次调用的结构化日志条目，包括失败的调用
+func TestLoggingMiddleware(t *testing.T) {
+	server := newEchoServer(t)
+
+	core, logs := observer.New(zap.InfoLevel)
+	client := suirpc.NewClient(server.URL).Use(suirpc.LoggingMiddleware(zap.New(core)))
+
+	_, err := client.SendRpc(context.Background(), &suirpc.RpcRequest{Jsonrpc: "2.0", Method: "sui_getChainIdentifier", Params: []any{}})
+	require.NoError(t, err)
+
+	entries := logs.All()
+	require.Len(t, entries, 1)
+	require.Equal(t, "rpc call", entries[0].Message)
+	fields := entries[0].ContextMap()
+	require.Equal(t, []any{"sui_getChainIdentifier"}, fields["methods"])
+	require.Contains(t, fields, "latency")
+
+	// Failing transport gets logged at warn level
+	// 传输失败时以 warn 级别记录
+	_, err = suirpc.NewClient("http://127.0.0.1:1").Use(suirpc.LoggingMiddleware(zap.New(core))).SendRpc(context.Background(), &suirpc.RpcRequest{Jsonrpc: "2.0", Method: "sui_getChainIdentifier", Params: []any{}})
+	require.ErrorIs(t, err, suirpc.ErrTransport)
+	require.Equal(t, "rpc call failed", logs.All()[len(logs.All())-1].Message)
+}
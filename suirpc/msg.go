@@ -1,6 +1,7 @@
 package suirpc
 
 import (
+	"encoding/json"
 	"fmt"
 )
 
@@ -17,7 +18,7 @@ type RpcRequest struct {
 	Jsonrpc string `json:"jsonrpc"` // JSON-RPC version (always "2.0") // JSON-RPC 版本（始终为 "2.0"）
 	Method  string `json:"method"`  // RPC method name to invoke // 要调用的 RPC 方法名称
 	Params  []any  `json:"params"`  // Method parameters as generic slice // 作为通用切片的方法参数
-	ID      int    `json:"id"`      // Request identifier to match responses // 请求标识符以匹配响应
+	ID      any    `json:"id"`      // Request identifier, number or string, nil or empty string gets assigned by client // 请求标识符，数字或字符串，为 nil 或空字符串时由客户端分配
 }
 
 // RpcResponse represents JSON-RPC 2.0 response structure with generic result type
@@ -31,11 +32,23 @@ type RpcRequest struct {
 // 匹配请求 ID 以关联响应与请求
 type RpcResponse[RES any] struct {
 	Jsonrpc string    `json:"jsonrpc"`         // JSON-RPC version (always "2.0") // JSON-RPC 版本（始终为 "2.0"）
-	ID      int       `json:"id"`              // Request identifier matching request // 匹配请求的请求标识符
+	ID      any       `json:"id"`              // Request identifier matching request, number or string // 匹配请求的请求标识符，数字或字符串
 	Result  RES       `json:"result"`          // Response result with generic type // 带有通用类型的响应结果
 	Error   *RpcError `json:"error,omitempty"` // Error information if call failed // 调用失败时的错误信息
 }
 
+// RawResponse represents JSON-RPC 2.0 response keeping result and error undecoded
+// Error object comes back as raw JSON instead of Go error, callers decide how to handle it
+//
+// RawResponse 表示保持结果和错误未解码的 JSON-RPC 2.0 响应
+// 错误对象以原始 JSON 返回而非 Go 错误，由调用方决定如何处理
+type RawResponse struct {
+	Jsonrpc string          `json:"jsonrpc"`         // JSON-RPC version (always "2.0") // JSON-RPC 版本（始终为 "2.0"）
+	ID      any             `json:"id"`              // Request identifier matching request // 匹配请求的请求标识符
+	Result  json.RawMessage `json:"result"`          // Raw result, empty when call failed // 原始结果，调用失败时为空
+	Error   json.RawMessage `json:"error,omitempty"` // Raw error object, empty when call succeeded // 原始错误对象，调用成功时为空
+}
+
 // RpcError represents JSON-RPC 2.0 error information
 // Contains error code, message, and optional data
 // Implements error interface to work with Go error handling
@@ -61,3 +74,42 @@ type RpcError struct {
 func (rpcError *RpcError) Error() string {
 	return fmt.Sprintf("code=%d message=%s data=%v", rpcError.Code, rpcError.Message, rpcError.Data)
 }
+
+// rpcEnvelope exposes RPC error of decoded response envelope
+// Lets client check errors without knowing the result type
+//
+// rpcEnvelope 暴露已解码响应封装的 RPC 错误
+// 使客户端无需知道结果类型即可检查错误
+type rpcEnvelope interface {
+	rpcError() *RpcError
+	resetError()
+}
+
+// rpcError returns error object of the response
+//
+// rpcError 返回响应的错误对象
+func (rpcResponse *RpcResponse[RES]) rpcError() *RpcError {
+	return rpcResponse.Error
+}
+
+// resetError clears error object left by previous attempt
+//
+// resetError 清除上次尝试遗留的错误对象
+func (rpcResponse *RpcResponse[RES]) resetError() {
+	rpcResponse.Error = nil
+}
+
+// rpcError returns nil so raw error objects reach the caller undecoded
+//
+// rpcError 返回 nil，使原始错误对象未经解码地交给调用方
+func (rawResponse *RawResponse) rpcError() *RpcError {
+	return nil
+}
+
+// resetError clears error object left by previous attempt
+//
+// resetError 清除上次尝试遗留的错误对象
+func (rawResponse *RawResponse) resetError() {
+	rawResponse.Error = nil
+	rawResponse.Result = nil
+}
@@ -0,0 +1,257 @@
+package suirpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+
+	"github.com/yyle88/erero"
+)
+
+// ErrChainMismatch means node reports chain identifier other than the expected one
+//
+// ErrChainMismatch 表示节点报告的链标识符与预期不符
+var ErrChainMismatch = errors.New("chain identifier mismatch")
+
+// Default endpoints of public Sui networks
+//
+// 公共 Sui 网络的默认端点
+const (
+	MainnetRpcUrl = "https://fullnode.mainnet.sui.io/" // Mainnet fullnode RPC URL // 主网全节点 RPC URL
+	TestnetRpcUrl = "https://fullnode.testnet.sui.io/" // Testnet fullnode RPC URL // 测试网全节点 RPC URL
+	DevnetRpcUrl  = "https://fullnode.devnet.sui.io/"  // Devnet fullnode RPC URL // 开发网全节点 RPC URL
+	LocalRpcUrl   = "http://127.0.0.1:9000"            // Localnet fullnode RPC URL // 本地网全节点 RPC URL
+)
+
+// Network represents Sui network with its default endpoints and expected chain identifier
+// Empty ChainId skips verification, suits devnet and localnet which change it on reset
+//
+// Network 表示带有默认端点和预期链标识符的 Sui 网络
+// ChainId 为空时跳过校验，适用于重置时会变化的开发网和本地网
+type Network struct {
+	Name      string // Network name // 网络名称
+	RpcUrl    string // Fullnode JSON-RPC URL // 全节点 JSON-RPC URL
+	WsUrl     string // Fullnode WebSocket URL // 全节点 WebSocket URL
+	FaucetUrl string // Faucet URL, empty when network has no faucet // 水龙头 URL，网络无水龙头时为空
+	ChainId   string // Expected chain identifier // 预期的链标识符
+}
+
+var (
+	Mainnet = Network{
+		Name:    "mainnet",
+		RpcUrl:  MainnetRpcUrl,
+		WsUrl:   "wss://fullnode.mainnet.sui.io:443",
+		ChainId: "35834a8a",
+	}
+	Testnet = Network{
+		Name:      "testnet",
+		RpcUrl:    TestnetRpcUrl,
+		WsUrl:     "wss://fullnode.testnet.sui.io:443",
+		FaucetUrl: "https://faucet.testnet.sui.io/v2/gas",
+		ChainId:   "4c78adac",
+	}
+	Devnet = Network{
+		Name:      "devnet",
+		RpcUrl:    DevnetRpcUrl,
+		WsUrl:     "wss://fullnode.devnet.sui.io:443",
+		FaucetUrl: "https://faucet.devnet.sui.io/v2/gas",
+	}
+	Localnet = Network{
+		Name:      "localnet",
+		RpcUrl:    LocalRpcUrl,
+		WsUrl:     "ws://127.0.0.1:9000",
+		FaucetUrl: "http://127.0.0.1:9123/v2/gas",
+	}
+)
+
+// CustomNetwork creates network bound to given RPC URL and expected chain identifier
+// WebSocket URL comes from RPC URL with ws scheme, faucet stays empty
+//
+// CustomNetwork 创建绑定到给定 RPC URL 和预期链标识符的网络
+// WebSocket URL 由 RPC URL 换成 ws 协议得到，水龙头为空
+func CustomNetwork(rpcUrl string, chainId string) Network {
+	wsUrl := rpcUrl
+	if rest, ok := strings.CutPrefix(rpcUrl, "http"); ok {
+		wsUrl = "ws" + rest
+	}
+	return Network{Name: "custom", RpcUrl: rpcUrl, WsUrl: wsUrl, ChainId: chainId}
+}
+
+// LookupNetwork returns network by name: mainnet, testnet, devnet or localnet
+// HTTP(S) URL gives custom network without chain check
+//
+// LookupNetwork 按名称返回网络：mainnet、testnet、devnet 或 localnet
+// HTTP(S) URL 返回不做链校验的自定义网络
+func LookupNetwork(name string) (Network, error) {
+	for _, network := range []Network{Mainnet, Testnet, Devnet, Localnet} {
+		if network.Name == name {
+			return network, nil
+		}
+	}
+	if strings.HasPrefix(name, "http://") || strings.HasPrefix(name, "https://") {
+		return CustomNetwork(name, ""), nil
+	}
+	return Network{}, erero.Errorf("unknown network %q, want mainnet, testnet, devnet, localnet or RPC URL", name)
+}
+
+// NewClient creates RPC client bound to network RPC URL
+// Client checks chain identifier on first call and fails fast on mismatch
+//
+// NewClient 创建绑定到网络 RPC URL 的 RPC 客户端
+// 客户端在首次调用时校验链标识符，不匹配时快速失败
+func (network Network) NewClient() *Client {
+	return NewClient(network.RpcUrl).SetChainIdentifier(network.ChainId)
+}
+
+// NewWsClient creates WebSocket client bound to network WebSocket URL
+//
+// NewWsClient 创建绑定到网络 WebSocket URL 的 WebSocket 客户端
+func (network Network) NewWsClient() *WsClient {
+	return NewWsClient(network.WsUrl)
+}
+
+// chainCheck represents chain identifier verification shared by client copies, done once per endpoint URL
+//
+// chainCheck 表示客户端副本共享的链标识符校验，每个端点 URL 只执行一次
+type chainCheck struct {
+	expected string                  // Expected chain identifier // 预期的链标识符
+	mutex    sync.Mutex              // Guards results, not held while asking the node // 保护 results，询问节点期间不持有
+	results  map[string]*chainResult // Verification by endpoint URL // 按端点 URL 记录的校验
+}
+
+// chainResult represents verification of one endpoint, callers wait on done while it runs
+//
+// chainResult 表示单个端点的校验，校验进行时调用方等待 done
+type chainResult struct {
+	done     chan struct{} // Closed once verification finishes // 校验结束时关闭
+	answered bool          // Node answered and result is kept // 节点已应答且结果被保留
+	err      error         // Mismatch error, nil when matched // 不匹配错误，匹配时为 nil
+}
+
+// SetChainIdentifier sets chain identifier expected from the node
+// First call checks sui_getChainIdentifier, mismatch fails that call and each later one
+// Empty chain identifier turns the check off
+//
+// SetChainIdentifier 设置节点应返回的链标识符
+// 首次调用时检查 sui_getChainIdentifier，不匹配时该调用及之后每次调用都失败
+// 链标识符为空时关闭校验
+func (c *Client) SetChainIdentifier(chainId string) *Client {
+	c.chain = nil
+	if chainId != "" {
+		c.chain = &chainCheck{expected: chainId, results: map[string]*chainResult{}}
+	}
+	return c
+}
+
+// VerifyChain checks node chain identifier against the expected one
+// Keeps result per server URL after node answers, transport errors let next call try again
+// Pooled clients check each endpoint when a call first reaches it, so this returns nil for them
+// Returns nil when no chain identifier is expected
+//
+// VerifyChain 将节点链标识符与预期值比较
+// 节点应答后按服务器 URL 保留结果，传输错误时下次调用会重试
+// 使用端点池的客户端在调用首次到达各端点时校验，因此对其返回 nil
+// 未设置预期链标识符时返回 nil
+func (c *Client) VerifyChain(ctx context.Context) error {
+	if c.chain == nil || c.pool != nil {
+		return nil
+	}
+	return c.chain.verify(ctx, c.serverUrl, func(ctx context.Context) (chainId string, err error) {
+		err = c.withRetry(ctx, []string{"sui_getChainIdentifier"}, func(ctx context.Context) error {
+			chainId, err = c.chainIdentifier(ctx, c.serverUrl)
+			return err
+		})
+		return chainId, err
+	})
+}
+
+// verifyEndpoint checks chain identifier of one pool endpoint, once per endpoint URL
+//
+// verifyEndpoint 校验单个池中端点的链标识符，每个端点 URL 只校验一次
+func (c *Client) verifyEndpoint(ctx context.Context, serverUrl string) error {
+	if c.chain == nil {
+		return nil
+	}
+	return c.chain.verify(ctx, serverUrl, func(ctx context.Context) (string, error) {
+		return c.chainIdentifier(ctx, serverUrl)
+	})
+}
+
+// verify returns kept result of endpoint or asks the node through fetch
+// One caller asks at a time per endpoint, others wait for its answer without holding the mutex
+// Fetch errors keep nothing, waiting callers then ask again
+//
+// verify 返回端点已保留的结果，或通过 fetch 询问节点
+// 每个端点同一时间只有一个调用方询问，其余调用方在不持有互斥锁的情况下等待其应答
+// fetch 出错时不保留结果，等待的调用方随后重新询问
+func (check *chainCheck) verify(ctx context.Context, serverUrl string, fetch func(ctx context.Context) (string, error)) error {
+	for {
+		check.mutex.Lock()
+		result, ok := check.results[serverUrl]
+		if !ok {
+			result = &chainResult{done: make(chan struct{})}
+			check.results[serverUrl] = result
+		}
+		check.mutex.Unlock()
+		if !ok {
+			return check.settle(ctx, serverUrl, result, fetch)
+		}
+
+		select {
+		case <-result.done:
+		case <-ctx.Done():
+			return erero.Wro(ctx.Err())
+		}
+		if result.answered {
+			return result.err
+		}
+	}
+}
+
+// settle asks the node through fetch and records the answer in result
+//
+// settle 通过 fetch 询问节点并将应答记录到 result
+func (check *chainCheck) settle(ctx context.Context, serverUrl string, result *chainResult, fetch func(ctx context.Context) (string, error)) error {
+	chainId, err := fetch(ctx)
+
+	check.mutex.Lock()
+	defer check.mutex.Unlock()
+	defer close(result.done)
+	if err != nil {
+		delete(check.results, serverUrl)
+		return erero.Wro(err)
+	}
+	result.answered = true
+	if chainId != check.expected {
+		result.err = erero.WithMessagef(ErrChainMismatch, "server_url=%s chain_id=%s expected=%s", serverUrl, chainId, check.expected)
+	}
+	return result.err
+}
+
+// chainIdentifier asks endpoint for its chain identifier through client middlewares
+// Skips the cache so the answer comes from the node itself
+//
+// chainIdentifier 通过客户端中间件向端点询问其链标识符
+// 跳过缓存，使应答来自节点本身
+func (c *Client) chainIdentifier(ctx context.Context, serverUrl string) (string, error) {
+	request := &RpcRequest{Jsonrpc: "2.0", Method: "sui_getChainIdentifier", Params: []any{}, ID: c.nextRequestID()}
+	call := c.newCall(request, nil)
+	call.serverUrl = serverUrl
+	body, err := c.roundTrip(withoutCache(ctx), call)
+	if err != nil {
+		return "", erero.Wro(err)
+	}
+	if err := checkResponse(body, request); err != nil {
+		return "", erero.Wro(err)
+	}
+	var response RpcResponse[string]
+	if err := json.Unmarshal(body, &response); err != nil {
+		return "", erero.Wro(err)
+	}
+	if response.Error != nil {
+		return "", erero.Wro(response.Error)
+	}
+	return response.Result, nil
+}
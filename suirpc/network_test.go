@@ -0,0 +1,156 @@
+package suirpc_test
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-xlan/sui-go-guide/suirpc"
+	"github.com/stretchr/testify/require"
+)
+
+// TestNetwork_NewClient tests chain identifier gets checked once on first call
+// Mismatch fails first call and each later one without sending them
+//
+// TestNetwork_NewClient 测试链标识符在首次调用时仅校验一次
+// 不匹配时首次调用及之后每次调用都失败且不再发送
+func TestNetwork_NewClient(t *testing.T) {
+	var status, calls atomic.Int64
+	status.Store(http.StatusOK)
+	node := newStandInNode(t, "node", suirpc.Testnet.ChainId, &status, &calls)
+
+	request := &suirpc.RpcRequest{Jsonrpc: "2.0", Method: "sui_getTotalTransactionBlocks", Params: []any{}}
+	client := suirpc.CustomNetwork(node.URL, suirpc.Testnet.ChainId).NewClient()
+	for range 2 {
+		res, err := suirpc.Call[string](context.Background(), client, request)
+		require.NoError(t, err)
+		require.Equal(t, "node", res.Result)
+	}
+	require.Equal(t, int64(3), calls.Load())
+
+	calls.Store(0)
+	client = suirpc.CustomNetwork(node.URL, suirpc.Mainnet.ChainId).NewClient()
+	for range 2 {
+		_, err := suirpc.Call[string](context.Background(), client, request)
+		require.ErrorIs(t, err, suirpc.ErrChainMismatch)
+	}
+	_, err := client.SendBatch(context.Background(), []*suirpc.RpcRequest{request})
+	require.ErrorIs(t, err, suirpc.ErrChainMismatch)
+	require.Equal(t, int64(1), calls.Load())
+
+	// Failed check leaves the client unverified and next call tries again
+	// 校验失败时客户端保持未校验状态，下次调用会重试
+	calls.Store(0)
+	status.Store(http.StatusBadGateway)
+	client = suirpc.CustomNetwork(node.URL, suirpc.Testnet.ChainId).NewClient()
+	_, err = suirpc.Call[string](context.Background(), client, request)
+	require.Error(t, err)
+	status.Store(http.StatusOK)
+	_, err = suirpc.Call[string](context.Background(), client, request)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), calls.Load())
+}
+
+// TestClient_WithServerUrl_ChainCheck tests copies share chain check, each URL gets checked once
+//
+// TestClient_WithServerUrl_ChainCheck 测试副本共享链校验，每个 URL 只校验一次
+func TestClient_WithServerUrl_ChainCheck(t *testing.T) {
+	var status, testnetCalls, mainnetCalls atomic.Int64
+	status.Store(http.StatusOK)
+	testnet := newStandInNode(t, "testnet", suirpc.Testnet.ChainId, &status, &testnetCalls)
+	mainnet := newStandInNode(t, "mainnet", suirpc.Mainnet.ChainId, &status, &mainnetCalls)
+
+	request := &suirpc.RpcRequest{Jsonrpc: "2.0", Method: "sui_getTotalTransactionBlocks", Params: []any{}}
+	client := suirpc.NewClient("").SetChainIdentifier(suirpc.Testnet.ChainId)
+	for range 2 {
+		res, err := suirpc.Call[string](context.Background(), client.WithServerUrl(testnet.URL), request)
+		require.NoError(t, err)
+		require.Equal(t, "testnet", res.Result)
+		_, err = suirpc.Call[string](context.Background(), client.WithServerUrl(mainnet.URL), request)
+		require.ErrorIs(t, err, suirpc.ErrChainMismatch)
+	}
+	require.Equal(t, int64(3), testnetCalls.Load())
+	require.Equal(t, int64(1), mainnetCalls.Load())
+}
+
+// TestClient_EndpointPool_ChainCheck tests pooled client checks each endpoint it reaches
+// Endpoint on wrong chain leaves the pool and the call fails over
+//
+// TestClient_EndpointPool_ChainCheck 测试使用端点池的客户端校验其到达的每个端点
+// 链不匹配的端点被移出端点池且调用故障转移
+func TestClient_EndpointPool_ChainCheck(t *testing.T) {
+	var status, testnetCalls, mainnetCalls atomic.Int64
+	status.Store(http.StatusOK)
+	testnet := newStandInNode(t, "testnet", suirpc.Testnet.ChainId, &status, &testnetCalls)
+	mainnet := newStandInNode(t, "mainnet", suirpc.Mainnet.ChainId, &status, &mainnetCalls)
+
+	pool := suirpc.NewEndpointPool(mainnet.URL, testnet.URL)
+	client := suirpc.NewClient("").SetEndpointPool(pool).SetChainIdentifier(suirpc.Testnet.ChainId)
+	request := &suirpc.RpcRequest{Jsonrpc: "2.0", Method: "sui_getTotalTransactionBlocks", Params: []any{}}
+	for range 4 {
+		res, err := suirpc.Call[string](context.Background(), client, request)
+		require.NoError(t, err)
+		require.Equal(t, "testnet", res.Result)
+	}
+	require.Equal(t, int64(1), mainnetCalls.Load())
+	require.Equal(t, int64(5), testnetCalls.Load())
+	endpoints := pool.Endpoints()
+	require.Len(t, endpoints, 1)
+	require.Equal(t, testnet.URL, endpoints[0].ServerUrl)
+}
+
+// TestClient_ChainCheck_Concurrent tests concurrent calls share one check per URL
+// Slow check of one URL does not hold up calls to another URL
+//
+// TestClient_ChainCheck_Concurrent 测试并发调用对每个 URL 共享同一次校验
+// 某个 URL 的慢速校验不会阻塞对其它 URL 的调用
+func TestClient_ChainCheck_Concurrent(t *testing.T) {
+	var status, slowCalls, fastCalls atomic.Int64
+	status.Store(http.StatusOK)
+	slow := newStandInNode(t, "slow", suirpc.Testnet.ChainId, &status, &slowCalls, withDelay(500*time.Millisecond))
+	fast := newStandInNode(t, "fast", suirpc.Testnet.ChainId, &status, &fastCalls)
+
+	client := suirpc.NewClient("").SetChainIdentifier(suirpc.Testnet.ChainId)
+	request := &suirpc.RpcRequest{Jsonrpc: "2.0", Method: "sui_getTotalTransactionBlocks", Params: []any{}}
+	var wg sync.WaitGroup
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			res, err := suirpc.Call[string](context.Background(), client.WithServerUrl(slow.URL), request)
+			require.NoError(t, err)
+			require.Equal(t, "slow", res.Result)
+		}()
+	}
+	require.Eventually(t, func() bool { return slowCalls.Load() == 1 }, time.Second, time.Millisecond)
+
+	res, err := suirpc.Call[string](context.Background(), client.WithServerUrl(fast.URL), request)
+	require.NoError(t, err)
+	require.Equal(t, "fast", res.Result)
+	require.Equal(t, int64(1), slowCalls.Load()) // Other slow calls still wait on the check // 其余慢速调用仍在等待校验
+
+	wg.Wait()
+	require.Equal(t, int64(6), slowCalls.Load())
+	require.Equal(t, int64(2), fastCalls.Load())
+}
+
+// TestLookupNetwork tests network lookup by name and by URL
+//
+// TestLookupNetwork 测试按名称和 URL 查找网络
+func TestLookupNetwork(t *testing.T) {
+	network, err := suirpc.LookupNetwork("mainnet")
+	require.NoError(t, err)
+	require.Equal(t, suirpc.Mainnet, network)
+
+	network, err = suirpc.LookupNetwork("https://rpc.example.com")
+	require.NoError(t, err)
+	require.Equal(t, "custom", network.Name)
+	require.Equal(t, "wss://rpc.example.com", network.WsUrl)
+	require.Empty(t, network.ChainId)
+
+	_, err = suirpc.LookupNetwork("moonnet")
+	require.Error(t, err)
+}
@@ -0,0 +1,426 @@
+package suirpc
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/yyle88/erero"
+	"github.com/yyle88/zaplog"
+	"go.uber.org/zap"
+)
+
+// SelectMode represents strategy to order pool endpoints on each call
+//
+// SelectMode 表示每次调用时对池中端点排序的策略
+type SelectMode string
+
+const (
+	RoundRobin      SelectMode = "round_robin"      // Rotate through endpoints in turn // 依次轮询端点
+	LatencyWeighted SelectMode = "latency_weighted" // Prefer endpoints with lower latency // 优先选择延迟更低的端点
+)
+
+// Endpoint represents snapshot of one pool endpoint state
+// Returned to callers so they can inspect pool health
+//
+// Endpoint 表示池中单个端点状态的快照
+// 返回给调用方以便检查池的健康状况
+type Endpoint struct {
+	ServerUrl string        // Fullnode RPC endpoint URL // 全节点 RPC 端点 URL
+	Healthy   bool          // Result of last call or health check // 上次调用或健康检查的结果
+	Latency   time.Duration // Smoothed latency of successful calls // 成功调用的平滑延迟
+	Failures  int           // Consecutive failures count // 连续失败次数
+	State     BreakerState  // Circuit breaker state // 熔断器状态
+
+	openedAt time.Time // Time the breaker opened // 熔断器打开的时间
+	probes   int       // Probe calls running when half-open // 半开时正在运行的探测调用数
+}
+
+// EndpointPool represents set of fullnode endpoints serving one chain
+// Orders endpoints by round-robin or latency and fails over on errors
+// Drops endpoints whose chain identifier differs from the expected one
+// Safe to use across goroutines
+//
+// EndpointPool 表示服务同一条链的一组全节点端点
+// 按轮询或延迟排序端点，并在出错时故障转移
+// 移除链标识符与预期不符的端点
+// 可在多个 goroutine 间安全使用
+type EndpointPool struct {
+	mutex     sync.Mutex
+	name      string          // Endpoint URLs given at creation, names the pool in cache keys // 创建时给定的端点 URL，在缓存键中命名端点池
+	endpoints []*Endpoint     // Endpoints kept in the pool // 池中保留的端点
+	mode      SelectMode      // Endpoint order strategy // 端点排序策略
+	chainId   string          // Expected chain identifier // 预期的链标识符
+	cursor    int             // Round-robin position // 轮询位置
+	breaker   *BreakerPolicy  // Circuit breaker policy, nil disables it // 熔断器策略，nil 表示禁用
+	hedge     *HedgePolicy    // Hedged read policy, nil disables it // 对冲读策略，nil 表示禁用
+	samples   []time.Duration // Recent successful call latencies // 近期成功调用的延迟
+	sampleAt  int             // Next sample slot once samples is full // 样本已满时下一个写入位置
+}
+
+// Sample counts of latency window used by hedged reads
+//
+// 对冲读使用的延迟窗口样本数
+const (
+	maxHedgeSamples = 128 // Latencies kept // 保留的延迟数
+	minHedgeSamples = 10  // Latencies needed before percentile applies // 分位数生效前需要的延迟数
+)
+
+// NewEndpointPool creates pool with given endpoint URLs using round-robin order
+// All endpoints start healthy until a call or health check fails
+//
+// NewEndpointPool 使用给定端点 URL 创建轮询顺序的端点池
+// 所有端点初始为健康状态，直到调用或健康检查失败
+func NewEndpointPool(serverUrls ...string) *EndpointPool {
+	endpoints := make([]*Endpoint, 0, len(serverUrls))
+	for _, serverUrl := range serverUrls {
+		endpoints = append(endpoints, &Endpoint{ServerUrl: serverUrl, Healthy: true, State: BreakerClosed})
+	}
+	return &EndpointPool{name: "pool:" + strings.Join(serverUrls, ","), endpoints: endpoints, mode: RoundRobin}
+}
+
+// SetSelectMode sets strategy used to order endpoints
+//
+// SetSelectMode 设置端点排序策略
+func (pool *EndpointPool) SetSelectMode(mode SelectMode) *EndpointPool {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+	pool.mode = mode
+	return pool
+}
+
+// SetChainIdentifier sets expected chain identifier checked in health checks
+// When empty, the first identifier seen in health checks becomes expected
+//
+// SetChainIdentifier 设置健康检查时校验的预期链标识符
+// 为空时，健康检查中首次看到的标识符成为预期值
+func (pool *EndpointPool) SetChainIdentifier(chainId string) *EndpointPool {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+	pool.chainId = chainId
+	return pool
+}
+
+// Endpoints returns snapshot of endpoints kept in the pool
+//
+// Endpoints 返回池中保留端点的快照
+func (pool *EndpointPool) Endpoints() []Endpoint {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+	results := make([]Endpoint, 0, len(pool.endpoints))
+	for _, endpoint := range pool.endpoints {
+		results = append(results, *endpoint)
+	}
+	return results
+}
+
+// CheckHealth sends sui_getChainIdentifier to each endpoint through client middlewares
+// Marks endpoints healthy or unhealthy and removes endpoints on wrong chain
+// Calls skip the cache so each check reaches the endpoint
+// Returns error when no endpoint stays in the pool
+//
+// CheckHealth 通过客户端中间件向每个端点发送 sui_getChainIdentifier
+// 标记端点健康或不健康，并移除链不匹配的端点
+// 调用跳过缓存，使每次检查都访问端点
+// 池中没有剩余端点时返回错误
+func (pool *EndpointPool) CheckHealth(ctx context.Context, client *Client) error {
+	for _, endpoint := range pool.Endpoints() {
+		startTime := time.Now()
+		chainId, err := client.chainIdentifier(ctx, endpoint.ServerUrl)
+		if err != nil {
+			pool.markFailure(endpoint.ServerUrl)
+			continue
+		}
+		if !pool.matchChain(endpoint.ServerUrl, chainId) {
+			continue
+		}
+		pool.markSuccess(endpoint.ServerUrl, time.Since(startTime))
+	}
+	if len(pool.Endpoints()) == 0 {
+		return erero.New("no endpoint left in pool")
+	}
+	return nil
+}
+
+// StartHealthCheck runs CheckHealth at given interval until context is done
+//
+// StartHealthCheck 按给定间隔运行 CheckHealth 直到上下文结束
+func (pool *EndpointPool) StartHealthCheck(ctx context.Context, client *Client, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			if err := pool.CheckHealth(ctx, client); err != nil {
+				zaplog.LOG.Warn("endpoint pool health check", zap.Error(err))
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// matchChain compares chain identifier and removes endpoint on mismatch
+// Returns false when endpoint got removed
+//
+// matchChain 比较链标识符，不匹配时移除端点
+// 端点被移除时返回 false
+func (pool *EndpointPool) matchChain(serverUrl string, chainId string) bool {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+	if pool.chainId == "" {
+		pool.chainId = chainId
+	}
+	if chainId == pool.chainId {
+		return true
+	}
+	zaplog.LOG.Warn("remove endpoint on wrong chain", zap.String("server_url", serverUrl), zap.String("chain_id", chainId), zap.String("expected", pool.chainId))
+	pool.drop(serverUrl)
+	return false
+}
+
+// remove takes endpoint out of the pool
+//
+// remove 将端点移出端点池
+func (pool *EndpointPool) remove(serverUrl string) {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+	pool.drop(serverUrl)
+}
+
+// drop deletes endpoint with given URL, caller holds the mutex
+//
+// drop 删除给定 URL 的端点，调用方需持有互斥锁
+func (pool *EndpointPool) drop(serverUrl string) {
+	pool.endpoints = slices.DeleteFunc(pool.endpoints, func(endpoint *Endpoint) bool {
+		return endpoint.ServerUrl == serverUrl
+	})
+}
+
+// candidates returns endpoint URLs in the order calls should try them
+// Healthy endpoints come first, unhealthy ones stay as last resort
+//
+// candidates 返回调用应尝试的端点 URL 顺序
+// 健康端点在前，不健康端点作为最后手段
+func (pool *EndpointPool) candidates() []string {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+
+	var healthy, unhealthy []*Endpoint
+	for _, endpoint := range pool.endpoints {
+		if endpoint.Healthy {
+			healthy = append(healthy, endpoint)
+		} else {
+			unhealthy = append(unhealthy, endpoint)
+		}
+	}
+
+	switch pool.mode {
+	case LatencyWeighted:
+		healthy = pool.orderByLatency(healthy)
+	default:
+		if len(healthy) > 0 {
+			offset := pool.cursor % len(healthy)
+			healthy = append(healthy[offset:], healthy[:offset]...)
+			pool.cursor++
+		}
+	}
+
+	serverUrls := make([]string, 0, len(pool.endpoints))
+	for _, endpoint := range append(healthy, unhealthy...) {
+		serverUrls = append(serverUrls, endpoint.ServerUrl)
+	}
+	return serverUrls
+}
+
+// orderByLatency picks first endpoint with weight inverse to latency
+// Remaining endpoints follow in ascending latency
+//
+// orderByLatency 以与延迟成反比的权重选出首个端点
+// 其余端点按延迟升序排列
+func (pool *EndpointPool) orderByLatency(endpoints []*Endpoint) []*Endpoint {
+	if len(endpoints) == 0 {
+		return endpoints
+	}
+	weight := func(endpoint *Endpoint) float64 {
+		return 1 / float64(max(endpoint.Latency, time.Millisecond))
+	}
+
+	var total float64
+	for _, endpoint := range endpoints {
+		total += weight(endpoint)
+	}
+	point := rand.Float64() * total
+	first := len(endpoints) - 1
+	for idx, endpoint := range endpoints {
+		if point -= weight(endpoint); point < 0 {
+			first = idx
+			break
+		}
+	}
+
+	results := []*Endpoint{endpoints[first]}
+	rest := slices.Delete(slices.Clone(endpoints), first, first+1)
+	slices.SortFunc(rest, func(a, b *Endpoint) int {
+		return int(a.Latency - b.Latency)
+	})
+	return append(results, rest...)
+}
+
+// markSuccess marks endpoint healthy, closes its breaker and updates smoothed latency
+// Keeps latency in the window used by hedged reads
+//
+// markSuccess 标记端点健康、关闭其熔断器并更新平滑延迟
+// 将延迟保留在对冲读使用的窗口中
+func (pool *EndpointPool) markSuccess(serverUrl string, latency time.Duration) {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+	if endpoint := pool.find(serverUrl); endpoint != nil {
+		endpoint.Healthy = true
+		endpoint.Failures = 0
+		if endpoint.Latency == 0 {
+			endpoint.Latency = latency
+		} else {
+			endpoint.Latency = (endpoint.Latency*4 + latency) / 5
+		}
+		pool.tripBreaker(endpoint, true)
+	}
+	if len(pool.samples) < maxHedgeSamples {
+		pool.samples = append(pool.samples, latency)
+	} else {
+		pool.samples[pool.sampleAt] = latency
+		pool.sampleAt = (pool.sampleAt + 1) % maxHedgeSamples
+	}
+}
+
+// markFailure marks endpoint unhealthy after failed call, opening its breaker past the threshold
+//
+// markFailure 在调用失败后标记端点不健康，超过阈值时打开其熔断器
+func (pool *EndpointPool) markFailure(serverUrl string) {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+	if endpoint := pool.find(serverUrl); endpoint != nil {
+		endpoint.Healthy = false
+		endpoint.Failures++
+		pool.tripBreaker(endpoint, false)
+	}
+}
+
+// find returns endpoint with given URL, caller holds the mutex
+//
+// find 返回给定 URL 的端点，调用方需持有互斥锁
+func (pool *EndpointPool) find(serverUrl string) *Endpoint {
+	for _, endpoint := range pool.endpoints {
+		if endpoint.ServerUrl == serverUrl {
+			return endpoint
+		}
+	}
+	return nil
+}
+
+// SetEndpointPool makes client send requests through endpoint pool
+// Pool takes over from the bound server URL when set
+//
+// SetEndpointPool 使客户端通过端点池发送请求
+// 设置后端点池取代绑定的服务器 URL
+func (c *Client) SetEndpointPool(pool *EndpointPool) *Client {
+	c.pool = pool
+	return c
+}
+
+// postPool sends call through pool endpoints and fails over on errors
+// Writes fail over only when the node surely did not process them
+// Streamed calls stop failing over once body reached the consumer
+// Skips endpoints with open breaker, reads get hedged when pool has hedge policy
+//
+// postPool 通过池中端点发送调用并在出错时故障转移
+// 写方法仅在节点确定未处理时才故障转移
+// 流式调用在响应体交给消费者后不再故障转移
+// 跳过熔断器打开的端点，端点池设置对冲策略时读调用会被对冲
+func (c *Client) postPool(ctx context.Context, call *RpcCall) ([]byte, error) {
+	serverUrls := c.pool.candidates()
+	if len(serverUrls) == 0 {
+		return nil, erero.New("no endpoint in pool")
+	}
+	if policy := c.pool.hedgePolicy(call); policy != nil {
+		return c.postHedged(ctx, call, serverUrls, policy)
+	}
+
+	write := call.IsWrite()
+	var errs []error
+	for _, serverUrl := range serverUrls {
+		if !c.pool.acquire(serverUrl) {
+			continue
+		}
+		data, err := c.postEndpoint(ctx, serverUrl, call)
+		if err == nil {
+			return data, nil
+		}
+		errs = append(errs, err)
+		if !failoverable(err) || call.streamed {
+			return nil, erero.Wro(err)
+		}
+		if ctx.Err() != nil || (write && !notProcessed(err) && !errors.Is(err, ErrChainMismatch)) {
+			break
+		}
+		if c.debugMode {
+			c.debugLog().Debugln("Failover:", serverUrl, "reason:", err)
+		}
+	}
+	if len(errs) == 0 {
+		return nil, erero.Wro(ErrCircuitOpen)
+	}
+	return nil, erero.Wro(errors.Join(errs...))
+}
+
+// postEndpoint posts call to one pool endpoint granted by acquire and records the outcome
+// Checks chain identifier of the endpoint first when client expects one, endpoint on wrong chain leaves the pool
+// Failures caused by canceled context leave endpoint state untouched
+//
+// postEndpoint 将调用发送到经 acquire 获准的池中端点并记录结果
+// 客户端设置了预期链标识符时先校验端点的链标识符，链不匹配的端点被移出端点池
+// 上下文取消导致的失败不改变端点状态
+func (c *Client) postEndpoint(ctx context.Context, serverUrl string, call *RpcCall) ([]byte, error) {
+	defer c.pool.release(serverUrl)
+	if err := c.verifyEndpoint(ctx, serverUrl); err != nil {
+		if errors.Is(err, ErrChainMismatch) {
+			c.pool.remove(serverUrl)
+		} else if failoverable(err) && ctx.Err() == nil {
+			c.pool.markFailure(serverUrl)
+		}
+		return nil, erero.Wro(err)
+	}
+	startTime := time.Now()
+	data, err := c.post(ctx, serverUrl, call)
+	if err == nil {
+		c.pool.markSuccess(serverUrl, time.Since(startTime))
+		return data, nil
+	}
+	if failoverable(err) && ctx.Err() == nil {
+		c.pool.markFailure(serverUrl)
+	}
+	return nil, erero.Wro(err)
+}
+
+// failoverable checks if error comes from endpoint trouble worth trying another endpoint
+// Endpoint on wrong chain counts as such trouble
+//
+// failoverable 检查错误是否源于端点故障而值得尝试其它端点
+// 链不匹配的端点也属于此类故障
+func failoverable(err error) bool {
+	if errors.Is(err, ErrChainMismatch) {
+		return true
+	}
+	var statusErr *ErrHTTPStatus
+	if errors.As(err, &statusErr) {
+		return statusErr.Code == http.StatusTooManyRequests || statusErr.Code >= http.StatusInternalServerError
+	}
+	return isTransportError(err)
+}
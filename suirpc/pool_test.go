@@ -0,0 +1,170 @@
+package suirpc_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-xlan/sui-go-guide/suirpc"
+	"github.com/stretchr/testify/require"
+)
+
+// newStandInNode starts local server acting as fullnode of given chain
+// Answers sui_getChainIdentifier with chainId and other methods with its name
+// Fails with given HTTP status when status is not 200
+// Options such as withDelay slow down answers
+//
+// newStandInNode 启动充当给定链全节点的本地服务器
+// 使用 chainId 应答 sui_getChainIdentifier，其它方法应答其名称
+// 状态不为 200 时以该 HTTP 状态失败
+// withDelay 等选项会放慢应答
+func newStandInNode(t *testing.T, name string, chainId string, status *atomic.Int64, calls *atomic.Int64, options ...standInOption) *httptest.Server {
+	var settings standInSettings
+	for _, option := range options {
+		option(&settings)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if code := int(status.Load()); code != http.StatusOK {
+			w.WriteHeader(code)
+			return
+		}
+		var request suirpc.RpcRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		select {
+		case <-time.After(settings.delay):
+		case <-r.Context().Done():
+			return
+		}
+		result := name
+		if request.Method == "sui_getChainIdentifier" {
+			result = chainId
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": request.ID, "result": result})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// standInSettings represents optional behavior of stand-in node
+//
+// standInSettings 表示替身节点的可选行为
+type standInSettings struct {
+	delay time.Duration // Wait before each answer // 每次应答前的等待
+}
+
+// standInOption customizes stand-in node
+//
+// standInOption 定制替身节点
+type standInOption func(settings *standInSettings)
+
+// withDelay makes stand-in node wait given duration before each answer
+//
+// withDelay 使替身节点在每次应答前等待给定时长
+func withDelay(delay time.Duration) standInOption {
+	return func(settings *standInSettings) {
+		settings.delay = delay
+	}
+}
+
+// TestEndpointPool_Failover tests calls fail over to healthy endpoint on 5xx
+// Verifies round-robin order spreads calls across healthy endpoints
+//
+// TestEndpointPool_Failover 测试调用在 5xx 时故障转移到健康端点
+// 验证轮询顺序将调用分散到健康端点
+func TestEndpointPool_Failover(t *testing.T) {
+	var status1, status2, calls1, calls2 atomic.Int64
+	status1.Store(http.StatusOK)
+	status2.Store(http.StatusOK)
+	node1 := newStandInNode(t, "node1", "35834a8a", &status1, &calls1)
+	node2 := newStandInNode(t, "node2", "35834a8a", &status2, &calls2)
+
+	pool := suirpc.NewEndpointPool(node1.URL, node2.URL)
+	client := suirpc.NewClient("").SetEndpointPool(pool)
+
+	request := &suirpc.RpcRequest{Jsonrpc: "2.0", Method: "sui_getTotalTransactionBlocks", Params: []any{}}
+	names := map[string]int{}
+	for range 4 {
+		res, err := suirpc.Call[string](context.Background(), client, request)
+		require.NoError(t, err)
+		names[res.Result]++
+	}
+	require.Equal(t, map[string]int{"node1": 2, "node2": 2}, names)
+
+	// node1 degrades and each call lands on node2
+	// node1 降级后每次调用都落到 node2
+	status1.Store(http.StatusBadGateway)
+	for range 3 {
+		res, err := suirpc.Call[string](context.Background(), client, request)
+		require.NoError(t, err)
+		require.Equal(t, "node2", res.Result)
+	}
+	require.Equal(t, int64(3), calls1.Load()) // Only first call after failure tries node1 // 故障后仅首次调用尝试 node1
+
+	endpoints := pool.Endpoints()
+	require.False(t, endpoints[0].Healthy)
+	require.True(t, endpoints[1].Healthy)
+
+	// Health check revives node1 once it recovers
+	// node1 恢复后健康检查使其重新可用
+	status1.Store(http.StatusOK)
+	require.NoError(t, pool.CheckHealth(context.Background(), client))
+	require.True(t, pool.Endpoints()[0].Healthy)
+}
+
+// TestEndpointPool_WrongChain tests health check removes endpoint serving another chain
+//
+// TestEndpointPool_WrongChain 测试健康检查移除服务其它链的端点
+func TestEndpointPool_WrongChain(t *testing.T) {
+	var status1, status2, calls1, calls2 atomic.Int64
+	status1.Store(http.StatusOK)
+	status2.Store(http.StatusOK)
+	mainnet := newStandInNode(t, "mainnet", "35834a8a", &status1, &calls1)
+	testnet := newStandInNode(t, "testnet", "4c78adac", &status2, &calls2)
+
+	pool := suirpc.NewEndpointPool(testnet.URL, mainnet.URL).SetChainIdentifier("35834a8a")
+	client := suirpc.NewClient("").SetEndpointPool(pool)
+	require.NoError(t, pool.CheckHealth(context.Background(), client))
+
+	endpoints := pool.Endpoints()
+	require.Len(t, endpoints, 1)
+	require.Equal(t, mainnet.URL, endpoints[0].ServerUrl)
+
+	res, err := suirpc.Call[string](context.Background(), client, &suirpc.RpcRequest{Jsonrpc: "2.0", Method: "suix_getBalance", Params: []any{}})
+	require.NoError(t, err)
+	require.Equal(t, "mainnet", res.Result)
+}
+
+// TestEndpointPool_LatencyWeighted tests latency mode prefers the faster endpoint
+//
+// TestEndpointPool_LatencyWeighted 测试延迟模式优先选择更快的端点
+func TestEndpointPool_LatencyWeighted(t *testing.T) {
+	var status, calls atomic.Int64
+	status.Store(http.StatusOK)
+	fast := newStandInNode(t, "fast", "35834a8a", &status, &calls)
+	slowNode := newStandInNode(t, "slow", "35834a8a", &status, &calls)
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(50 * time.Millisecond)
+		slowNode.Config.Handler.ServeHTTP(w, r)
+	}))
+	defer slow.Close()
+
+	pool := suirpc.NewEndpointPool(slow.URL, fast.URL).SetSelectMode(suirpc.LatencyWeighted)
+	client := suirpc.NewClient("").SetEndpointPool(pool)
+	require.NoError(t, pool.CheckHealth(context.Background(), client))
+
+	names := map[string]int{}
+	for range 20 {
+		res, err := suirpc.Call[string](context.Background(), client, &suirpc.RpcRequest{Jsonrpc: "2.0", Method: "suix_getBalance", Params: []any{}})
+		require.NoError(t, err)
+		names[res.Result]++
+	}
+	require.Greater(t, names["fast"], names["slow"])
+}
@@ -0,0 +1,240 @@
+package suirpc
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/yyle88/erero"
+)
+
+// RetryPolicy represents retry settings applied to each RPC call
+// Uses exponential backoff with jitter and honors Retry-After header
+// Retries transport errors, HTTP 429 and 5xx, and chosen JSON-RPC error codes
+// Write methods are retried only when the node surely did not process them
+//
+// RetryPolicy 表示应用于每次 RPC 调用的重试配置
+// 使用带抖动的指数退避并遵循 Retry-After 响应头
+// 重试传输错误、HTTP 429 和 5xx，以及选定的 JSON-RPC 错误码
+// 写方法仅在节点确定未处理时才会重试
+type RetryPolicy struct {
+	MaxAttempts    int           // Total attempts including the first one // 包含首次在内的总尝试次数
+	InitialBackoff time.Duration // Wait before the second attempt // 第二次尝试前的等待时间
+	MaxBackoff     time.Duration // Upper bound of computed backoff // 计算出的退避上限
+	Multiplier     float64       // Backoff growth factor // 退避增长因子
+	Jitter         float64       // Random fraction (0-1) removed from backoff // 从退避中随机扣除的比例（0-1）
+	RetryableCodes []int         // JSON-RPC error codes worth retrying // 值得重试的 JSON-RPC 错误码
+	MaxElapsed     time.Duration // Retry budget of one call, zero means context only // 单次调用的重试预算，零表示仅受上下文限制
+	RetryWrites    bool          // Retry write methods on any retryable failure // 写方法在任何可重试失败时都重试
+}
+
+// DefaultRetryPolicy returns policy suited to public fullnodes
+// Uses four attempts with backoff from 200ms up to 5s
+//
+// DefaultRetryPolicy 返回适合公共全节点的策略
+// 使用四次尝试，退避从 200ms 增长到 5s
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:    4,
+		InitialBackoff: 200 * time.Millisecond,
+		MaxBackoff:     5 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+	}
+}
+
+// SetRetryPolicy sets retry policy of this client, nil disables retries
+//
+// SetRetryPolicy 设置此客户端的重试策略，nil 表示禁用重试
+func (c *Client) SetRetryPolicy(policy *RetryPolicy) *Client {
+	c.retryPolicy = policy
+	return c
+}
+
+// writeMethods lists RPC methods that change chain state
+// Sending them twice is only safe when the first attempt never reached the node
+//
+// writeMethods 列出会改变链上状态的 RPC 方法
+// 仅当首次尝试未到达节点时重复发送才是安全的
+var writeMethods = map[string]bool{
+	"sui_executeTransactionBlock": true,
+}
+
+// IsWriteMethod checks if RPC method changes chain state
+//
+// IsWriteMethod 检查 RPC 方法是否会改变链上状态
+func IsWriteMethod(method string) bool {
+	return writeMethods[method]
+}
+
+// parseRetryAfter parses Retry-After header as seconds or HTTP date
+// Returns zero when header is absent or invalid
+//
+// parseRetryAfter 将 Retry-After 响应头解析为秒数或 HTTP 日期
+// 响应头缺失或无效时返回零
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(max(seconds, 0)) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0)
+	}
+	return 0
+}
+
+// withRetry runs attempt until success, non-retryable failure, or exhausted budget
+// Stops before sleeping past context deadline or MaxElapsed
+//
+// withRetry 运行 attempt 直到成功、遇到不可重试失败或预算耗尽
+// 不会在超过上下文截止时间或 MaxElapsed 后继续等待
+func (c *Client) withRetry(ctx context.Context, methods []string, attempt func(ctx context.Context) error) error {
+	policy := c.retryPolicy
+	if policy == nil {
+		return attempt(withAttempt(ctx, 1))
+	}
+	write := slices.ContainsFunc(methods, IsWriteMethod)
+	startTime := time.Now()
+	for times := 1; ; times++ {
+		err := attempt(withAttempt(ctx, times))
+		if err == nil {
+			return nil
+		}
+		if times >= policy.MaxAttempts || !policy.retryable(ctx, err, write) {
+			return err
+		}
+
+		// Stop when waiting would exceed the call budget
+		// 等待会超出调用预算时停止
+		wait := policy.backoff(times, err)
+		if policy.MaxElapsed > 0 && time.Since(startTime)+wait > policy.MaxElapsed {
+			return err
+		}
+		if deadline, ok := ctx.Deadline(); ok && time.Now().Add(wait).After(deadline) {
+			return err
+		}
+
+		if c.debugMode {
+			c.debugLog().Debugln("Retry:", methods, "attempt:", times, "wait:", wait, "reason:", err)
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return erero.Join(err, ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+// retryable checks if failed attempt is worth retrying
+// Writes are retried on failures proving the node did not process the request
+//
+// retryable 检查失败的尝试是否值得重试
+// 写方法仅在能证明节点未处理请求的失败时重试
+func (policy *RetryPolicy) retryable(ctx context.Context, err error, write bool) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	var rpcError *RpcError
+	if errors.As(err, &rpcError) {
+		return slices.Contains(policy.RetryableCodes, rpcError.Code) && (!write || policy.RetryWrites)
+	}
+
+	var statusErr *ErrHTTPStatus
+	if errors.As(err, &statusErr) {
+		switch statusErr.Code {
+		case http.StatusTooManyRequests:
+			return true // Rejected before processing // 在处理前被拒绝
+		case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return !write || policy.RetryWrites
+		default:
+			return false
+		}
+	}
+
+	if notProcessed(err) {
+		return true // Connection never established // 连接从未建立
+	}
+	if isTransportError(err) {
+		return !write || policy.RetryWrites
+	}
+	return false
+}
+
+// notProcessed checks if failure proves the node never processed the request
+// Covers rate limit rejections and connections that were never established
+//
+// notProcessed 检查失败是否能证明节点从未处理该请求
+// 包括限流拒绝和从未建立的连接
+func notProcessed(err error) bool {
+	if errors.Is(err, ErrRateLimited) {
+		return true
+	}
+	var opError *net.OpError
+	return errors.As(err, &opError) && opError.Op == "dial"
+}
+
+// isTransportError checks if error comes from network transport
+//
+// isTransportError 检查错误是否来自网络传输
+func isTransportError(err error) bool {
+	var netError net.Error
+	return errors.Is(err, ErrTransport) || errors.As(err, &netError)
+}
+
+// backoff computes wait before next attempt with jitter
+// Retry-After from node wins when it asks to wait longer
+//
+// backoff 计算下次尝试前带抖动的等待时间
+// 节点通过 Retry-After 要求更长等待时以其为准
+func (policy *RetryPolicy) backoff(times int, err error) time.Duration {
+	wait := float64(policy.InitialBackoff)
+	for range times - 1 {
+		wait *= max(policy.Multiplier, 1)
+	}
+	if policy.MaxBackoff > 0 {
+		wait = min(wait, float64(policy.MaxBackoff))
+	}
+	if policy.Jitter > 0 {
+		wait -= wait * min(policy.Jitter, 1) * rand.Float64()
+	}
+
+	var statusErr *ErrHTTPStatus
+	if errors.As(err, &statusErr) && statusErr.RetryAfter > time.Duration(wait) {
+		return statusErr.RetryAfter
+	}
+	return time.Duration(wait)
+}
+
+// attemptKey represents context key of attempt number
+//
+// attemptKey 表示尝试序号的上下文键
+type attemptKey struct{}
+
+// withAttempt returns context carrying attempt number
+//
+// withAttempt 返回携带尝试序号的上下文
+func withAttempt(ctx context.Context, times int) context.Context {
+	return context.WithValue(ctx, attemptKey{}, times)
+}
+
+// AttemptFromContext returns attempt number of the running call, 1 for the first attempt
+// Middlewares read it to tell retries from first attempts
+//
+// AttemptFromContext 返回当前调用的尝试序号，首次尝试为 1
+// 中间件读取它以区分重试和首次尝试
+func AttemptFromContext(ctx context.Context) int {
+	if times, ok := ctx.Value(attemptKey{}).(int); ok {
+		return times
+	}
+	return 1
+}
@@ -0,0 +1,156 @@
+package suirpc_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-xlan/sui-go-guide/suirpc"
+	"github.com/stretchr/testify/require"
+)
+
+// newFlakyServer starts local server failing the first few calls with given status
+// Succeeds afterwards with string result "ok"
+//
+// newFlakyServer 启动本地服务器，前几次调用以给定状态失败
+// 之后以字符串结果 "ok" 成功应答
+func newFlakyServer(t *testing.T, failures int64, status int, retryAfter string, calls *atomic.Int64) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) <= failures {
+			if retryAfter != "" {
+				w.Header().Set("Retry-After", retryAfter)
+			}
+			w.WriteHeader(status)
+			return
+		}
+		var request suirpc.RpcRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": request.ID, "result": "ok"})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// fastRetryPolicy returns retry policy with tiny backoff to keep tests quick
+//
+// fastRetryPolicy 返回退避极短的重试策略以保持测试快速
+func fastRetryPolicy() *suirpc.RetryPolicy {
+	return &suirpc.RetryPolicy{
+		MaxAttempts:    4,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     10 * time.Millisecond,
+		Multiplier:     2,
+		Jitter:         0.5,
+	}
+}
+
+// TestRetryPolicy_ServerError tests reads are retried on 503 until success
+//
+// TestRetryPolicy_ServerError 测试读方法在 503 时重试直到成功
+func TestRetryPolicy_ServerError(t *testing.T) {
+	var calls atomic.Int64
+	server := newFlakyServer(t, 2, http.StatusServiceUnavailable, "", &calls)
+
+	client := suirpc.NewClient(server.URL).SetRetryPolicy(fastRetryPolicy())
+	res, err := suirpc.Call[string](context.Background(), client, &suirpc.RpcRequest{Jsonrpc: "2.0", Method: "sui_getChainIdentifier", Params: []any{}})
+	require.NoError(t, err)
+	require.Equal(t, "ok", res.Result)
+	require.Equal(t, int64(3), calls.Load())
+}
+
+// TestRetryPolicy_WriteNotRetried tests writes are not retried on 503
+// The node may have processed the transaction before failing
+//
+// TestRetryPolicy_WriteNotRetried 测试写方法在 503 时不重试
+// 节点可能已在失败前处理了交易
+func TestRetryPolicy_WriteNotRetried(t *testing.T) {
+	var calls atomic.Int64
+	server := newFlakyServer(t, 2, http.StatusServiceUnavailable, "", &calls)
+
+	client := suirpc.NewClient(server.URL).SetRetryPolicy(fastRetryPolicy())
+	_, err := suirpc.Call[string](context.Background(), client, &suirpc.RpcRequest{Jsonrpc: "2.0", Method: "sui_executeTransactionBlock", Params: []any{}})
+	require.Error(t, err)
+	require.Equal(t, int64(1), calls.Load())
+
+	// Opt in when the caller knows the transaction is idempotent
+	// 调用方确认交易幂等时可选择开启
+	policy := fastRetryPolicy()
+	policy.RetryWrites = true
+	client.SetRetryPolicy(policy)
+	res, err := suirpc.Call[string](context.Background(), client, &suirpc.RpcRequest{Jsonrpc: "2.0", Method: "sui_executeTransactionBlock", Params: []any{}})
+	require.NoError(t, err)
+	require.Equal(t, "ok", res.Result)
+}
+
+// TestRetryPolicy_RetryAfter tests 429 honors Retry-After and retries writes
+//
+// TestRetryPolicy_RetryAfter 测试 429 遵循 Retry-After 并重试写方法
+func TestRetryPolicy_RetryAfter(t *testing.T) {
+	var calls atomic.Int64
+	server := newFlakyServer(t, 1, http.StatusTooManyRequests, "1", &calls)
+
+	client := suirpc.NewClient(server.URL).SetRetryPolicy(fastRetryPolicy())
+	startTime := time.Now()
+	res, err := suirpc.Call[string](context.Background(), client, &suirpc.RpcRequest{Jsonrpc: "2.0", Method: "sui_executeTransactionBlock", Params: []any{}})
+	require.NoError(t, err)
+	require.Equal(t, "ok", res.Result)
+	require.GreaterOrEqual(t, time.Since(startTime), time.Second)
+}
+
+// TestRetryPolicy_ContextBudget tests retries stop when wait exceeds context deadline
+//
+// TestRetryPolicy_ContextBudget 测试等待超出上下文截止时间时停止重试
+func TestRetryPolicy_ContextBudget(t *testing.T) {
+	var calls atomic.Int64
+	server := newFlakyServer(t, 10, http.StatusTooManyRequests, "30", &calls)
+
+	client := suirpc.NewClient(server.URL).SetRetryPolicy(fastRetryPolicy())
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	startTime := time.Now()
+	_, err := suirpc.Call[string](ctx, client, &suirpc.RpcRequest{Jsonrpc: "2.0", Method: "sui_getChainIdentifier", Params: []any{}})
+	require.Error(t, err)
+	require.Equal(t, int64(1), calls.Load())
+	require.Less(t, time.Since(startTime), time.Second)
+}
+
+// TestRetryPolicy_RetryableCodes tests chosen JSON-RPC error codes are retried
+//
+// TestRetryPolicy_RetryableCodes 测试选定的 JSON-RPC 错误码会被重试
+func TestRetryPolicy_RetryableCodes(t *testing.T) {
+	var calls atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request suirpc.RpcRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if calls.Add(1) == 1 {
+			_ = json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": request.ID, "error": map[string]any{"code": -32603, "message": "Internal error"}})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": request.ID, "result": "ok"})
+	}))
+	defer server.Close()
+
+	client := suirpc.NewClient(server.URL).SetRetryPolicy(fastRetryPolicy())
+	_, err := suirpc.Call[string](context.Background(), client, &suirpc.RpcRequest{Jsonrpc: "2.0", Method: "sui_getChainIdentifier", Params: []any{}})
+	require.Error(t, err)
+
+	policy := fastRetryPolicy()
+	policy.RetryableCodes = []int{-32603}
+	client.SetRetryPolicy(policy)
+	calls.Store(0)
+	res, err := suirpc.Call[string](context.Background(), client, &suirpc.RpcRequest{Jsonrpc: "2.0", Method: "sui_getChainIdentifier", Params: []any{}})
+	require.NoError(t, err)
+	require.Equal(t, "ok", res.Result)
+	require.Equal(t, int64(2), calls.Load())
+}
@@ -11,109 +11,422 @@ package suirpc
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"maps"
 	"net/http"
-	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/go-resty/resty/v2"
 	"github.com/yyle88/erero"
 	"github.com/yyle88/neatjson/neatjsons"
 	"github.com/yyle88/zaplog"
+	"go.uber.org/zap"
 )
 
-var debugModeOpen = false
-
-// SetDebugMode enables or disables debug logging
-// When enabled, logs request and response details
-// Accepts boolean flag to control debug state
+// Client represents RPC client bound to one fullnode endpoint
+// Contains endpoint URL, HTTP transport, headers, logger and debug flag
+// Each instance keeps its own settings so multiple networks can coexist
+// Configure with Set* methods before sharing across goroutines
 //
-// SetDebugMode 启用或禁用调试日志
-// 启用时记录请求和响应详细信息
-// 接受布尔标志来控制调试状态
-func SetDebugMode(enable bool) {
-	debugModeOpen = enable
+// Client 表示绑定到单个全节点端点的 RPC 客户端
+// 包含端点 URL、HTTP 传输、请求头、日志记录器和调试标志
+// 每个实例保持独立配置，使多个网络可以共存
+// 在跨 goroutine 共享前使用 Set* 方法完成配置
+type Client struct {
+	serverUrl   string             // Fullnode RPC endpoint URL // 全节点 RPC 端点 URL
+	httpClient  *resty.Client      // HTTP transport client // HTTP 传输客户端
+	headers     map[string]string  // Headers sent with each request // 每个请求携带的请求头
+	logger      *zap.SugaredLogger // Logger used in debug mode // 调试模式使用的日志记录器
+	debugMode   bool               // Debug logging switch // 调试日志开关
+	batchLimit  int                // Max requests in one batch array // 单个批量数组的最大请求数
+	requestID   *atomic.Int64      // Request ID counter shared by copies // 副本共享的请求 ID 计数器
+	retryPolicy *RetryPolicy       // Retry policy, nil disables retries // 重试策略，nil 表示禁用重试
+	pool        *EndpointPool      // Endpoint pool replacing server URL // 取代服务器 URL 的端点池
+	middlewares []Middleware       // Middlewares wrapping each attempt // 包装每次尝试的中间件
+	chain       *chainCheck        // Chain identifier check, nil skips it // 链标识符校验，nil 时跳过
+	sizeLimit   int64              // Response body size limit, zero means no limit // 响应体大小限制，零表示不限制
 }
 
-var httpClient *resty.Client
-var clientOnce = &sync.Once{}
+// NewClient creates RPC client bound to given server URL
+// Uses resty client with one minute timeout as default transport
+// Returns client ready to send requests
+//
+// NewClient 创建绑定到给定服务器 URL 的 RPC 客户端
+// 默认使用一分钟超时的 resty 客户端作为传输
+// 返回可直接发送请求的客户端
+func NewClient(serverUrl string) *Client {
+	return &Client{
+		serverUrl:  serverUrl,
+		httpClient: resty.New().SetTimeout(time.Minute),
+		headers:    map[string]string{},
+		requestID:  &atomic.Int64{},
+	}
+}
 
-// newClient creates or returns existing HTTP client instance
-// Uses sync.Once to ensure single client creation
-// Configures default timeout and debug mode
-// Returns configured resty client
+// ServerUrl returns the endpoint URL bound to this client
 //
-// newClient 创建或返回现有的 HTTP 客户端实例
-// 使用 sync.Once 确保单一客户端创建
-// 配置默认超时和调试模式
-// 返回配置的 resty 客户端
-func newClient() *resty.Client {
-	clientOnce.Do(func() {
-		httpClient = resty.New().SetDebug(debugModeOpen).SetTimeout(time.Minute)
-	})
-	return httpClient
+// ServerUrl 返回绑定到此客户端的端点 URL
+func (c *Client) ServerUrl() string {
+	return c.serverUrl
 }
 
-// SetClient allows custom HTTP client configuration
+// SetHttpClient replaces the underlying resty client
 // Accepts pre-configured resty client instance
-// Uses sync.Once to prevent multiple client assignments
 //
-// SetClient 允许自定义 HTTP 客户端配置
+// SetHttpClient 替换底层 resty 客户端
 // 接受预配置的 resty 客户端实例
-// 使用 sync.Once 防止多次客户端分配
-func SetClient(client *resty.Client) {
-	clientOnce.Do(func() {
-		httpClient = client
-	})
+func (c *Client) SetHttpClient(httpClient *resty.Client) *Client {
+	c.httpClient = httpClient
+	return c
 }
 
-// SendRpc sends RPC request and deserializes response into generic type
-// Accepts context, server URL, and RPC request structure
+// SetTransport sets HTTP round tripper used to send requests
+//
+// SetTransport 设置发送请求使用的 HTTP RoundTripper
+func (c *Client) SetTransport(transport http.RoundTripper) *Client {
+	c.httpClient.SetTransport(transport)
+	return c
+}
+
+// SetTimeout sets request timeout of the underlying HTTP client
+//
+// SetTimeout 设置底层 HTTP 客户端的请求超时
+func (c *Client) SetTimeout(timeout time.Duration) *Client {
+	c.httpClient.SetTimeout(timeout)
+	return c
+}
+
+// SetHeader sets header sent with each request
+//
+// SetHeader 设置每个请求携带的请求头
+func (c *Client) SetHeader(key string, value string) *Client {
+	c.headers[key] = value
+	return c
+}
+
+// SetLogger sets logger used in debug mode
+// Falls back to zaplog.SUG when logger is nil
+//
+// SetLogger 设置调试模式使用的日志记录器
+// 日志记录器为 nil 时回退到 zaplog.SUG
+func (c *Client) SetLogger(logger *zap.SugaredLogger) *Client {
+	c.logger = logger
+	return c
+}
+
+// SetDebug enables or disables debug logging of this client
+//
+// SetDebug 启用或禁用此客户端的调试日志
+func (c *Client) SetDebug(enable bool) *Client {
+	c.debugMode = enable
+	return c
+}
+
+// SetMaxResponseSize sets response body size limit in bytes, zero means no limit
+// Larger responses fail with ErrResponseTooLarge without being read in full
+//
+// SetMaxResponseSize 设置响应体大小限制（字节），零表示不限制
+// 更大的响应以 ErrResponseTooLarge 失败，且不会被完整读取
+func (c *Client) SetMaxResponseSize(limit int64) *Client {
+	c.sizeLimit = limit
+	return c
+}
+
+// WithServerUrl returns client copy bound to another server URL
+// Copy shares transport and settings with the source client, headers are copied
+// Copy leaves endpoint pool out and talks to the given URL alone
+// Copy shares the chain check, each URL gets checked once
+//
+// WithServerUrl 返回绑定到另一个服务器 URL 的客户端副本
+// 副本与源客户端共享传输和配置，请求头会被复制
+// 副本不使用端点池，仅访问给定的 URL
+// 副本共享链校验，每个 URL 只校验一次
+func (c *Client) WithServerUrl(serverUrl string) *Client {
+	clone := *c
+	clone.serverUrl = serverUrl
+	clone.headers = maps.Clone(c.headers)
+	clone.pool = nil
+	return &clone
+}
+
+// nextRequestID returns next unique request ID of this client
+//
+// nextRequestID 返回此客户端的下一个唯一请求 ID
+func (c *Client) nextRequestID() int {
+	return int(c.requestID.Add(1))
+}
+
+// debugLog returns logger to use in debug mode
+//
+// debugLog 返回调试模式使用的日志记录器
+func (c *Client) debugLog() *zap.SugaredLogger {
+	if c.logger != nil {
+		return c.logger
+	}
+	return zaplog.SUG
+}
+
+// Send sends RPC request and decodes result field into result
+// Accepts pointer as result target, nil skips decoding
+// Returns RpcError when node responds with error object
+//
+// Send 发送 RPC 请求并将 result 字段解码到 result
+// 接受指针作为结果目标，nil 时跳过解码
+// 节点返回错误对象时返回 RpcError
+func (c *Client) Send(ctx context.Context, request *RpcRequest, result any) error {
+	if err := c.invoke(ctx, request, &RpcResponse[any]{Result: result}); err != nil {
+		return erero.Wro(err)
+	}
+	return nil
+}
+
+// SendRpc sends RPC request and returns response with raw result
+// Keeps result bytes undecoded so callers can decode them later
+//
+// SendRpc 发送 RPC 请求并返回带原始结果的响应
+// 保留未解码的结果字节以便调用方稍后解码
+func (c *Client) SendRpc(ctx context.Context, request *RpcRequest) (*RpcResponse[json.RawMessage], error) {
+	return Call[json.RawMessage](ctx, c, request)
+}
+
+// SendRpcRaw sends RPC request and returns response with raw result and raw error
+// Node error objects stay in the response instead of becoming Go errors
+// Skips retries of RPC errors since those never become Go errors
+//
+// SendRpcRaw 发送 RPC 请求并返回带原始结果和原始错误的响应
+// 节点错误对象保留在响应中，而不转换为 Go 错误
+// 由于 RPC 错误不会转换为 Go 错误，因此不会针对其重试
+func (c *Client) SendRpcRaw(ctx context.Context, request *RpcRequest) (*RawResponse, error) {
+	rawResponse := &RawResponse{}
+	if err := c.invoke(ctx, request, rawResponse); err != nil {
+		return nil, erero.Wro(err)
+	}
+	return rawResponse, nil
+}
+
+// Call sends RPC request with client and deserializes response into generic type
 // Returns typed RPC response or error if request fails
-// Supports debug logging when debug mode is enabled
 //
-// SendRpc 发送 RPC 请求并将响应反序列化为通用类型
-// 接受上下文、服务器 URL 和 RPC 请求结构
+// Call 使用客户端发送 RPC 请求并将响应反序列化为通用类型
 // 返回类型化的 RPC 响应，如果请求失败则返回错误
-// 启用调试模式时支持调试日志记录
-func SendRpc[RES any](ctx context.Context, serverUrl string, request *RpcRequest) (rpcResponse *RpcResponse[RES], err error) {
-	resp := &RpcResponse[RES]{}
+func Call[RES any](ctx context.Context, client *Client, request *RpcRequest) (*RpcResponse[RES], error) {
+	rpcResponse := &RpcResponse[RES]{}
+	if err := client.invoke(ctx, request, rpcResponse); err != nil {
+		return nil, erero.Wro(err)
+	}
+	return rpcResponse, nil
+}
+
+// invoke posts request and decodes response body into envelope
+// Assigns request ID when empty and rejects responses of another ID
+// Checks HTTP status and RPC error object, retries per client policy
+// Verifies chain identifier first when client expects one
+//
+// invoke 发送请求并将响应体解码到响应封装
+// 请求 ID 为空时分配 ID，并拒绝 ID 不符的响应
+// 检查 HTTP 状态和 RPC 错误对象，按客户端策略重试
+// 客户端设置了预期链标识符时先进行校验
+func (c *Client) invoke(ctx context.Context, request *RpcRequest, envelope rpcEnvelope) error {
+	if err := c.VerifyChain(ctx); err != nil {
+		return erero.Wro(err)
+	}
+	request = withID(request, c.nextRequestID)
+	err := c.withRetry(ctx, []string{request.Method}, func(ctx context.Context) error {
+		call := c.newCall(request, nil)
+		body, err := c.roundTrip(ctx, call)
+		if err != nil {
+			return erero.Wro(err)
+		}
+
+		// Check version and ID before decoding result
+		// 解码结果前校验版本和 ID
+		if err := checkResponse(body, call.Request); err != nil {
+			return erero.Wro(err)
+		}
 
-	// Send POST request with JSON body
-	// 发送带 JSON 主体的 POST 请求
-	response, err := newClient().
+		envelope.resetError()
+		if err := json.Unmarshal(body, envelope); err != nil {
+			return erero.Wro(err)
+		}
+
+		// Check RPC response errors
+		// 检查 RPC 响应错误
+		if rpcError := envelope.rpcError(); rpcError != nil {
+			return erero.Wro(rpcError)
+		}
+		return nil
+	})
+	if err != nil {
+		return erero.Wro(err)
+	}
+
+	// Log parsed response in debug mode
+	// 在调试模式下记录解析的响应
+	if c.debugMode {
+		c.debugLog().Debugln("Response Msg:", neatjsons.S(envelope))
+	}
+	return nil
+}
+
+// postTo sends JSON body to given endpoint and returns raw response body
+// Returns error on transport failure, non-200 HTTP status or oversized body
+//
+// postTo 向给定端点发送 JSON 主体并返回原始响应体
+// 传输失败、HTTP 状态非 200 或响应体过大时返回错误
+func (c *Client) postTo(ctx context.Context, serverUrl string, header http.Header, body any) ([]byte, error) {
+	var data []byte
+	if err := c.postStream(ctx, serverUrl, header, body, func(reader io.Reader) (err error) {
+		data, err = io.ReadAll(reader)
+		return err
+	}); err != nil {
+		return nil, erero.Wro(err)
+	}
+
+	// Log raw response in debug mode
+	// 在调试模式下记录原始响应
+	if c.debugMode {
+		c.debugLog().Debugln("Response Raw:", neatjsons.SxB(data))
+	}
+	return data, nil
+}
+
+// postStream sends JSON body to given endpoint and hands response body reader to consume
+// Body reader enforces client size limit, read failures count as transport errors
+//
+// postStream 向给定端点发送 JSON 主体并将响应体读取器交给 consume
+// 响应体读取器执行客户端大小限制，读取失败视为传输错误
+func (c *Client) postStream(ctx context.Context, serverUrl string, header http.Header, body any, consume func(reader io.Reader) error) error {
+	// Send POST request with JSON body, leaving response body unread
+	// 发送带 JSON 主体的 POST 请求，响应体保持未读取
+	response, err := c.httpClient.
 		R().
 		SetContext(ctx).
+		SetDoNotParseResponse(true).
+		SetHeaderMultiValues(header).
 		SetHeader("Content-Type", "application/json").
-		SetBody(request).
-		SetResult(resp).
+		SetBody(body).
 		Post(serverUrl)
 	if err != nil {
-		return nil, erero.Wro(err)
+		if ctx.Err() != nil {
+			return erero.Wro(err)
+		}
+		return erero.Wro(transportError(err))
 	}
+	rawBody := response.RawBody()
+	defer func() { _ = rawBody.Close() }()
+	reader := &bodyReader{reader: rawBody, limit: c.sizeLimit}
 
 	// Check HTTP status code
 	// 检查 HTTP 状态码
 	if response.StatusCode() != http.StatusOK {
-		return nil, erero.New(response.Status())
+		data, _ := io.ReadAll(io.LimitReader(reader, maxErrorBodySize))
+		return erero.Wro(&ErrHTTPStatus{
+			Code:       response.StatusCode(),
+			Status:     response.Status(),
+			Body:       data,
+			RetryAfter: parseRetryAfter(response.Header().Get("Retry-After")),
+		})
 	}
 
-	// Log raw response in debug mode
-	// 在调试模式下记录原始响应
-	if debugModeOpen {
-		zaplog.SUG.Debugln("Response Raw:", neatjsons.SxB(response.Body()))
+	if err := consume(reader); err != nil {
+		switch {
+		case reader.err == nil || errors.Is(reader.err, ErrResponseTooLarge):
+			return erero.Wro(err)
+		case ctx.Err() != nil:
+			return erero.Wro(ctx.Err())
+		default:
+			return erero.Wro(transportError(reader.err))
+		}
 	}
+	return nil
+}
 
-	// Check RPC response errors
-	// 检查 RPC 响应错误
-	if resp.Error != nil {
-		return nil, erero.Wro(resp.Error)
-	}
+// maxErrorBodySize caps body bytes kept in ErrHTTPStatus
+//
+// maxErrorBodySize 限制 ErrHTTPStatus 中保留的响应体字节数
+const maxErrorBodySize = 64 << 10
 
-	// Log parsed response in debug mode
-	// 在调试模式下记录解析的响应
-	if debugModeOpen {
-		zaplog.SUG.Debugln("Response Msg:", neatjsons.S(resp))
+// bodyReader reads response body, fails once size limit is passed and keeps read error
+//
+// bodyReader 读取响应体，超过大小限制时失败，并保留读取错误
+type bodyReader struct {
+	reader io.Reader // Response body // 响应体
+	limit  int64     // Size limit, zero means no limit // 大小限制，零表示不限制
+	size   int64     // Bytes read so far // 已读取的字节数
+	err    error     // First read error other than EOF // 首个非 EOF 的读取错误
+}
+
+// Read reads from response body and enforces the size limit
+//
+// Read 从响应体读取并执行大小限制
+func (r *bodyReader) Read(p []byte) (int, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+	n, err := r.reader.Read(p)
+	r.size += int64(n)
+	if r.limit > 0 && r.size > r.limit {
+		r.err = erero.WithMessagef(ErrResponseTooLarge, "limit=%d", r.limit)
+		return 0, r.err
 	}
+	if err != nil && !errors.Is(err, io.EOF) {
+		r.err = err
+	}
+	return n, err
+}
+
+var defaultClient = NewClient("")
 
-	return resp, nil
+// DefaultClient returns process-wide client used by package-level functions
+//
+// DefaultClient 返回包级函数使用的进程级客户端
+func DefaultClient() *Client {
+	return defaultClient
+}
+
+// SetDebugMode enables or disables debug logging
+// When enabled, logs request and response details
+// Applies to the default client used by SendRpc
+//
+// SetDebugMode 启用或禁用调试日志
+// 启用时记录请求和响应详细信息
+// 作用于 SendRpc 使用的默认客户端
+func SetDebugMode(enable bool) {
+	defaultClient.SetDebug(enable)
+}
+
+// SetClient allows custom HTTP client configuration
+// Accepts pre-configured resty client instance
+// Replaces transport of the default client used by SendRpc
+//
+// SetClient 允许自定义 HTTP 客户端配置
+// 接受预配置的 resty 客户端实例
+// 替换 SendRpc 使用的默认客户端的传输
+func SetClient(client *resty.Client) {
+	defaultClient.SetHttpClient(client)
+}
+
+// SendRpc sends RPC request and deserializes response into generic type
+// Accepts context, server URL, and RPC request structure
+// Returns typed RPC response or error if request fails
+// Uses the default client bound to given server URL
+//
+// SendRpc 发送 RPC 请求并将响应反序列化为通用类型
+// 接受上下文、服务器 URL 和 RPC 请求结构
+// 返回类型化的 RPC 响应，如果请求失败则返回错误
+// 使用绑定到给定服务器 URL 的默认客户端
+func SendRpc[RES any](ctx context.Context, serverUrl string, request *RpcRequest) (rpcResponse *RpcResponse[RES], err error) {
+	return Call[RES](ctx, defaultClient.WithServerUrl(serverUrl), request)
+}
+
+// SendRpcRaw sends RPC request using the default client bound to given server URL
+// Returns response with raw result and raw error object
+//
+// SendRpcRaw 使用绑定到给定服务器 URL 的默认客户端发送 RPC 请求
+// 返回带原始结果和原始错误对象的响应
+func SendRpcRaw(ctx context.Context, serverUrl string, request *RpcRequest) (*RawResponse, error) {
+	return defaultClient.WithServerUrl(serverUrl).SendRpcRaw(ctx, request)
 }
@@ -0,0 +1,155 @@
+package suirpc_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-xlan/sui-go-guide/suirpc"
+	"github.com/stretchr/testify/require"
+)
+
+// newEchoServer starts local server answering each request with method name and header value
+// Echoes request ID, except bad_id and bad_version methods which answer broken envelopes
+// Helper shared across client test cases
+//
+// newEchoServer 启动本地服务器，使用方法名和请求头值应答每个请求
+// 回显请求 ID，bad_id 和 bad_version 方法除外，它们应答损坏的封装
+// 在客户端测试用例中共享的辅助函数
+func newEchoServer(t *testing.T) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request suirpc.RpcRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch request.Method {
+		case "bad_method":
+			_ = json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": request.ID, "error": map[string]any{"code": -32601, "message": "Method not found"}})
+			return
+		case "bad_id":
+			_ = json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": "other", "result": map[string]string{}})
+			return
+		case "bad_version":
+			_ = json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "1.0", "id": request.ID, "result": map[string]string{}})
+			return
+		}
+		result := map[string]string{"method": request.Method, "network": r.Header.Get("X-Network"), "id": fmt.Sprint(request.ID)}
+		_ = json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": request.ID, "result": result})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// TestClient_Send tests two clients with independent headers against one server
+// Verifies each client keeps its own settings
+//
+// TestClient_Send 测试两个拥有独立请求头的客户端访问同一服务器
+// 验证每个客户端保持各自的配置
+func TestClient_Send(t *testing.T) {
+	server := newEchoServer(t)
+
+	mainnet := suirpc.NewClient(server.URL).SetHeader("X-Network", "mainnet").SetTimeout(time.Second)
+	testnet := suirpc.NewClient(server.URL).SetHeader("X-Network", "testnet").SetDebug(true)
+
+	type Result struct {
+		Method  string `json:"method"`
+		Network string `json:"network"`
+	}
+
+	var res1 Result
+	require.NoError(t, mainnet.Send(context.Background(), &suirpc.RpcRequest{Jsonrpc: "2.0", Method: "sui_getChainIdentifier", Params: []any{}}, &res1))
+	require.Equal(t, "sui_getChainIdentifier", res1.Method)
+	require.Equal(t, "mainnet", res1.Network)
+
+	res2, err := suirpc.Call[Result](context.Background(), testnet, &suirpc.RpcRequest{Jsonrpc: "2.0", Method: "sui_getChainIdentifier", Params: []any{}})
+	require.NoError(t, err)
+	require.Equal(t, "testnet", res2.Result.Network)
+}
+
+// TestClient_WithServerUrl tests copy headers stay apart from source client headers
+//
+// TestClient_WithServerUrl 测试副本的请求头与源客户端的请求头互不影响
+func TestClient_WithServerUrl(t *testing.T) {
+	server := newEchoServer(t)
+	source := suirpc.NewClient("").SetHeader("X-Network", "mainnet")
+	clone := source.WithServerUrl(server.URL).SetHeader("X-Network", "testnet")
+
+	request := &suirpc.RpcRequest{Jsonrpc: "2.0", Method: "suix_getCoins", Params: []any{}}
+	res, err := suirpc.Call[map[string]string](context.Background(), clone, request)
+	require.NoError(t, err)
+	require.Equal(t, "testnet", res.Result["network"])
+	res, err = suirpc.Call[map[string]string](context.Background(), source.WithServerUrl(server.URL), request)
+	require.NoError(t, err)
+	require.Equal(t, "mainnet", res.Result["network"])
+}
+
+// TestClient_Send_RpcError tests RPC error object is returned as error
+//
+// TestClient_Send_RpcError 测试 RPC 错误对象作为错误返回
+func TestClient_Send_RpcError(t *testing.T) {
+	server := newEchoServer(t)
+
+	client := suirpc.NewClient(server.URL)
+	err := client.Send(context.Background(), &suirpc.RpcRequest{Jsonrpc: "2.0", Method: "bad_method", Params: []any{}}, nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Method not found")
+}
+
+// TestSendRpc tests package-level function using the default client
+//
+// TestSendRpc 测试使用默认客户端的包级函数
+func TestSendRpc(t *testing.T) {
+	server := newEchoServer(t)
+
+	rpcResponse, err := suirpc.SendRpc[map[string]string](context.Background(), server.URL, &suirpc.RpcRequest{Jsonrpc: "2.0", Method: "suix_getCoins", Params: []any{}})
+	require.NoError(t, err)
+	require.Equal(t, "suix_getCoins", rpcResponse.Result["method"])
+}
+
+// TestClient_Send_RequestID tests client assigns increasing IDs and keeps caller IDs
+// String IDs are sent and matched as strings
+//
+// TestClient_Send_RequestID 测试客户端分配递增 ID 并保留调用方 ID
+// 字符串 ID 按字符串发送和匹配
+func TestClient_Send_RequestID(t *testing.T) {
+	server := newEchoServer(t)
+	client := suirpc.NewClient(server.URL)
+
+	request := &suirpc.RpcRequest{Jsonrpc: "2.0", Method: "sui_getChainIdentifier", Params: []any{}}
+	res1, err := suirpc.Call[map[string]string](context.Background(), client, request)
+	require.NoError(t, err)
+	res2, err := suirpc.Call[map[string]string](context.Background(), client, request)
+	require.NoError(t, err)
+	require.Equal(t, "1", res1.Result["id"])
+	require.Equal(t, "2", res2.Result["id"])
+	require.Nil(t, request.ID) // Caller request stays unchanged // 调用方请求保持不变
+
+	res3, err := suirpc.Call[map[string]string](context.Background(), client, &suirpc.RpcRequest{Jsonrpc: "2.0", Method: "sui_getChainIdentifier", Params: []any{}, ID: "req-abc"})
+	require.NoError(t, err)
+	require.Equal(t, "req-abc", res3.Result["id"])
+	require.Equal(t, "req-abc", res3.ID)
+
+	res4, err := suirpc.Call[map[string]string](context.Background(), client, &suirpc.RpcRequest{Jsonrpc: "2.0", Method: "sui_getChainIdentifier", Params: []any{}, ID: 0})
+	require.NoError(t, err)
+	require.Equal(t, "0", res4.Result["id"])
+}
+
+// TestClient_Send_BadEnvelope tests responses with other ID or wrong version are rejected
+//
+// TestClient_Send_BadEnvelope 测试拒绝 ID 不符或版本错误的响应
+func TestClient_Send_BadEnvelope(t *testing.T) {
+	server := newEchoServer(t)
+	client := suirpc.NewClient(server.URL)
+
+	err := client.Send(context.Background(), &suirpc.RpcRequest{Jsonrpc: "2.0", Method: "bad_id", Params: []any{}}, nil)
+	require.ErrorIs(t, err, suirpc.ErrIDMismatch)
+
+	err = client.Send(context.Background(), &suirpc.RpcRequest{Jsonrpc: "2.0", Method: "bad_version", Params: []any{}}, nil)
+	require.ErrorIs(t, err, suirpc.ErrMalformedResponse)
+}
@@ -0,0 +1,173 @@
+package suirpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+
+	"github.com/yyle88/erero"
+)
+
+// StreamCall sends RPC request and decodes result items one by one while body arrives
+// Result array yields each element, result object yields each element of its data array
+// Other fields of result object, such as nextCursor, come back as raw JSON
+// Suits huge sui_multiGetTransactionBlocks and sui_getCheckpoints responses
+// Runs one attempt without retries, since items already yielded cannot be taken back
+// Middlewares see nil body of streamed calls, cached or replayed bodies get decoded the same way
+//
+// StreamCall 发送 RPC 请求，并在响应体到达时逐个解码结果条目
+// 结果为数组时逐个产出元素，结果为对象时逐个产出其 data 数组的元素
+// 结果对象的其它字段（例如 nextCursor）以原始 JSON 返回
+// 适用于巨大的 sui_multiGetTransactionBlocks 和 sui_getCheckpoints 响应
+// 只尝试一次不做重试，因为已产出的条目无法撤回
+// 中间件看到的流式调用响应体为 nil，缓存或回放的响应体按同样方式解码
+func StreamCall[ITEM any](ctx context.Context, client *Client, request *RpcRequest, yield func(item *ITEM) error) (map[string]json.RawMessage, error) {
+	if err := client.VerifyChain(ctx); err != nil {
+		return nil, erero.Wro(err)
+	}
+	request = withID(request, client.nextRequestID)
+
+	var fields map[string]json.RawMessage
+	decode := func(reader io.Reader) error {
+		var err error
+		fields, err = decodeStream(reader, idKey(request.ID), yield)
+		return err
+	}
+
+	call := client.newCall(request, nil)
+	call.stream = decode
+	data, err := client.roundTrip(ctx, call)
+	if err != nil {
+		return nil, erero.Wro(err)
+	}
+	if !call.streamed {
+		if err := decode(bytes.NewReader(data)); err != nil {
+			return nil, erero.Wro(err)
+		}
+	}
+	return fields, nil
+}
+
+// decodeStream walks response envelope token by token and yields result items
+// Checks version and ID like checkResponse, returns RpcError on error object
+//
+// decodeStream 逐个词法单元遍历响应封装并产出结果条目
+// 像 checkResponse 一样校验版本和 ID，遇到错误对象时返回 RpcError
+func decodeStream[ITEM any](reader io.Reader, key string, yield func(item *ITEM) error) (map[string]json.RawMessage, error) {
+	decoder := json.NewDecoder(reader)
+	if err := expectDelim(decoder, '{'); err != nil {
+		return nil, erero.Wro(err)
+	}
+
+	var head rpcHead
+	var rpcError *RpcError
+	fields := map[string]json.RawMessage{}
+	for decoder.More() {
+		name, err := decoder.Token()
+		if err != nil {
+			return nil, erero.Wro(err)
+		}
+		switch name {
+		case "jsonrpc":
+			err = decoder.Decode(&head.Jsonrpc)
+		case "id":
+			err = decoder.Decode(&head.ID)
+		case "error":
+			head.Error = json.RawMessage(`{}`)
+			err = decoder.Decode(&rpcError)
+		case "result":
+			// Check version and ID before yielding when both come ahead of result, else after the walk
+			// 版本和 ID 都位于结果之前时在产出前校验，否则在遍历结束后校验
+			if head.Jsonrpc != "" && head.ID != nil {
+				if err := head.check(key); err != nil {
+					return nil, erero.Wro(err)
+				}
+			}
+			err = decodeStreamResult(decoder, fields, yield)
+		default:
+			var skip json.RawMessage
+			err = decoder.Decode(&skip)
+		}
+		if err != nil {
+			return nil, erero.Wro(err)
+		}
+	}
+	if err := head.check(key); err != nil {
+		return nil, erero.Wro(err)
+	}
+	if rpcError != nil {
+		return nil, erero.Wro(rpcError)
+	}
+	return fields, nil
+}
+
+// decodeStreamResult yields items of result array or of data array inside result object
+//
+// decodeStreamResult 产出结果数组或结果对象内 data 数组的条目
+func decodeStreamResult[ITEM any](decoder *json.Decoder, fields map[string]json.RawMessage, yield func(item *ITEM) error) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return erero.Wro(err)
+	}
+	switch token {
+	case nil:
+		return nil
+	case json.Delim('['):
+		return decodeStreamItems(decoder, yield)
+	case json.Delim('{'):
+		for decoder.More() {
+			name, err := decoder.Token()
+			if err != nil {
+				return erero.Wro(err)
+			}
+			if name == "data" {
+				if err := expectDelim(decoder, '['); err != nil {
+					return erero.Wro(err)
+				}
+				if err := decodeStreamItems(decoder, yield); err != nil {
+					return erero.Wro(err)
+				}
+				continue
+			}
+			var value json.RawMessage
+			if err := decoder.Decode(&value); err != nil {
+				return erero.Wro(err)
+			}
+			fields[name.(string)] = value
+		}
+		return expectDelim(decoder, '}')
+	default:
+		return erero.WithMessagef(ErrMalformedResponse, "result is %v, want array or object", token)
+	}
+}
+
+// decodeStreamItems decodes array elements after its opening bracket and yields each
+//
+// decodeStreamItems 解码左括号之后的数组元素并逐个产出
+func decodeStreamItems[ITEM any](decoder *json.Decoder, yield func(item *ITEM) error) error {
+	for decoder.More() {
+		item := new(ITEM)
+		if err := decoder.Decode(item); err != nil {
+			return erero.Wro(err)
+		}
+		if err := yield(item); err != nil {
+			return erero.Wro(err)
+		}
+	}
+	return expectDelim(decoder, ']')
+}
+
+// expectDelim reads next token and checks it is the given delimiter
+//
+// expectDelim 读取下一个词法单元并检查其是否为给定分隔符
+func expectDelim(decoder *json.Decoder, delim json.Delim) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return erero.Wro(err)
+	}
+	if token != delim {
+		return erero.WithMessagef(ErrMalformedResponse, "got %v, want %v", token, delim)
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package suirpc_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-xlan/sui-go-guide/suirpc"
+	"github.com/stretchr/testify/require"
+)
+
+// newRawServer starts local server answering each request with body built from request ID and method
+// Keeps fullnode field order: jsonrpc, result or error, then id
+//
+// newRawServer 启动本地服务器，根据请求 ID 和方法构建响应体
+// 保持全节点字段顺序：jsonrpc、result 或 error、最后是 id
+func newRawServer(t *testing.T) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		var body string
+		switch request.Method {
+		case "sui_multiGetTransactionBlocks":
+			items := make([]string, 0, 1000)
+			for idx := range 1000 {
+				items = append(items, fmt.Sprintf(`{"digest":"tx%d","effects":{"status":{"status":"success"}}}`, idx))
+			}
+			body = fmt.Sprintf(`{"jsonrpc":"2.0","result":[%s],"id":%s}`, strings.Join(items, ","), request.ID)
+		case "sui_getCheckpoints":
+			body = fmt.Sprintf(`{"jsonrpc":"2.0","id":%s,"result":{"data":[{"digest":"cp1"},{"digest":"cp2"}],"nextCursor":"2","hasNextPage":true}}`, request.ID)
+		case "sui_getTransactionBlock":
+			body = fmt.Sprintf(`{"jsonrpc":"2.0","error":{"code":-32602,"message":"Invalid params"},"id":%s}`, request.ID)
+		default:
+			body = fmt.Sprintf(`{"jsonrpc":"2.0","result":[{"digest":"x"}],"id":"other"}`)
+		}
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// TestStreamCall tests result items get yielded one by one and envelope still gets checked
+//
+// TestStreamCall 测试结果条目被逐个产出且封装仍会被校验
+func TestStreamCall(t *testing.T) {
+	server := newRawServer(t)
+	client := suirpc.NewClient(server.URL)
+
+	type Digest struct {
+		Digest string `json:"digest"`
+	}
+	var digests []string
+	yield := func(item *Digest) error {
+		digests = append(digests, item.Digest)
+		return nil
+	}
+
+	_, err := suirpc.StreamCall[Digest](context.Background(), client, &suirpc.RpcRequest{Jsonrpc: "2.0", Method: "sui_multiGetTransactionBlocks", Params: []any{}}, yield)
+	require.NoError(t, err)
+	require.Len(t, digests, 1000)
+	require.Equal(t, "tx999", digests[999])
+
+	digests = nil
+	fields, err := suirpc.StreamCall[Digest](context.Background(), client, &suirpc.RpcRequest{Jsonrpc: "2.0", Method: "sui_getCheckpoints", Params: []any{}}, yield)
+	require.NoError(t, err)
+	require.Equal(t, []string{"cp1", "cp2"}, digests)
+	require.JSONEq(t, `"2"`, string(fields["nextCursor"]))
+	require.JSONEq(t, `true`, string(fields["hasNextPage"]))
+
+	_, err = suirpc.StreamCall[Digest](context.Background(), client, &suirpc.RpcRequest{Jsonrpc: "2.0", Method: "sui_getTransactionBlock", Params: []any{}}, yield)
+	require.ErrorIs(t, err, suirpc.ErrInvalidParams)
+
+	_, err = suirpc.StreamCall[Digest](context.Background(), client, &suirpc.RpcRequest{Jsonrpc: "2.0", Method: "bad_id", Params: []any{}}, yield)
+	require.ErrorIs(t, err, suirpc.ErrIDMismatch)
+}
+
+// TestClient_SetMaxResponseSize tests oversized responses fail in buffered and streamed calls
+//
+// TestClient_SetMaxResponseSize 测试过大的响应在缓冲调用和流式调用中都会失败
+func TestClient_SetMaxResponseSize(t *testing.T) {
+	server := newRawServer(t)
+	client := suirpc.NewClient(server.URL).SetMaxResponseSize(4 << 10)
+	request := &suirpc.RpcRequest{Jsonrpc: "2.0", Method: "sui_multiGetTransactionBlocks", Params: []any{}}
+
+	_, err := client.SendRpc(context.Background(), request)
+	require.ErrorIs(t, err, suirpc.ErrResponseTooLarge)
+
+	_, err = suirpc.StreamCall[map[string]any](context.Background(), client, request, func(item *map[string]any) error { return nil })
+	require.ErrorIs(t, err, suirpc.ErrResponseTooLarge)
+
+	_, err = client.SendRpc(context.Background(), &suirpc.RpcRequest{Jsonrpc: "2.0", Method: "sui_getCheckpoints", Params: []any{}})
+	require.NoError(t, err)
+}
+
+// TestSendRpcRaw tests raw error object comes back without Go error
+//
+// TestSendRpcRaw 测试原始错误对象返回且不产生 Go 错误
+func TestSendRpcRaw(t *testing.T) {
+	server := newRawServer(t)
+
+	res, err := suirpc.SendRpcRaw(context.Background(), server.URL, &suirpc.RpcRequest{Jsonrpc: "2.0", Method: "sui_getTransactionBlock", Params: []any{}})
+	require.NoError(t, err)
+	require.Empty(t, res.Result)
+	require.JSONEq(t, `{"code":-32602,"message":"Invalid params"}`, string(res.Error))
+
+	res, err = suirpc.NewClient(server.URL).SendRpcRaw(context.Background(), &suirpc.RpcRequest{Jsonrpc: "2.0", Method: "sui_getCheckpoints", Params: []any{}})
+	require.NoError(t, err)
+	require.Empty(t, res.Error)
+	require.Contains(t, string(res.Result), "nextCursor")
+}
@@ -0,0 +1,406 @@
+package suirpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Span attribute keys set by TracingMiddleware, following OpenTelemetry RPC naming
+//
+// TracingMiddleware 设置的 span 属性键，遵循 OpenTelemetry RPC 命名
+const (
+	AttrRpcSystem      = "rpc.system"                // Always "jsonrpc" // 固定为 "jsonrpc"
+	AttrRpcMethod      = "rpc.method"                // Method name, "batch" in batch calls // 方法名，批量调用时为 "batch"
+	AttrBatchSize      = "rpc.batch.size"            // Number of requests in batch calls // 批量调用中的请求数
+	AttrEndpoint       = "server.address"            // Endpoint the call was posted to // 调用发送到的端点
+	AttrAttempt        = "rpc.attempt"               // Attempt number, 1 for the first one // 尝试序号，首次为 1
+	AttrStatus         = "rpc.status"                // Call status, see Status consts // 调用状态，见 Status 常量
+	AttrErrorCode      = "rpc.jsonrpc.error_code"    // JSON-RPC error code // JSON-RPC 错误码
+	AttrHTTPStatusCode = "http.response.status_code" // HTTP status code of failed responses // 失败响应的 HTTP 状态码
+)
+
+// Call statuses reported by telemetry middlewares
+//
+// 遥测中间件报告的调用状态
+const (
+	StatusOK             = "ok"              // Result came back // 返回了结果
+	StatusRpcError       = "rpc_error"       // Node answered JSON-RPC error // 节点应答了 JSON-RPC 错误
+	StatusHTTPError      = "http_error"      // Node answered HTTP error status // 节点应答了 HTTP 错误状态
+	StatusTransportError = "transport_error" // Request never got an answer // 请求未得到应答
+	StatusCanceled       = "canceled"        // Context got canceled or timed out // 上下文被取消或超时
+	StatusError          = "error"           // Other failure // 其它失败
+)
+
+// Tracer starts spans around RPC calls
+// Adapts to OpenTelemetry trace.Tracer in a few lines, keeping the dependency out of this package
+//
+// Tracer 在 RPC 调用周围开启 span
+// 几行代码即可适配 OpenTelemetry trace.Tracer，使本包不依赖它
+type Tracer interface {
+	// Start starts span and returns context carrying it
+	// Start 开启 span 并返回携带它的上下文
+	Start(ctx context.Context, name string) (context.Context, Span)
+}
+
+// Span represents one traced RPC call
+//
+// Span 表示一次被追踪的 RPC 调用
+type Span interface {
+	// SetAttribute sets span attribute of string, int or bool value
+	// SetAttribute 设置字符串、整数或布尔值的 span 属性
+	SetAttribute(key string, value any)
+	// RecordError records error and marks span failed
+	// RecordError 记录错误并将 span 标记为失败
+	RecordError(err error)
+	// End ends the span
+	// End 结束 span
+	End()
+}
+
+// RequestObservation represents outcome of one RPC post to an endpoint, or of an attempt answered by middleware
+//
+// RequestObservation 表示一次向端点发送 RPC 的结果，或由中间件应答的一次尝试的结果
+type RequestObservation struct {
+	Method       string        // Method name, "batch" in batch calls // 方法名，批量调用时为 "batch"
+	Endpoint     string        // Endpoint the call was posted to, empty when answered by middleware // 调用发送到的端点，由中间件应答时为空
+	Status       string        // Call status, see Status consts // 调用状态，见 Status 常量
+	ErrorCode    int           // JSON-RPC error code, zero without one // JSON-RPC 错误码，没有时为零
+	Attempt      int           // Attempt number, above 1 on retries // 尝试序号，重试时大于 1
+	Latency      time.Duration // Post latency // 发送延迟
+	ResponseSize int           // Response size in bytes // 响应大小（字节）
+}
+
+// MetricsCollector receives RPC request metrics
+// Adapts to Prometheus counter, histogram and gauge vectors labeled by method, endpoint and status
+//
+// MetricsCollector 接收 RPC 请求指标
+// 可适配按方法、端点和状态打标签的 Prometheus 计数器、直方图和仪表向量
+type MetricsCollector interface {
+	// AddInFlight adds delta to in-flight gauge of method
+	// AddInFlight 向方法的进行中仪表增加 delta
+	AddInFlight(method string, delta int)
+	// ObserveRequest records outcome of one endpoint post
+	// ObserveRequest 记录一次向端点发送的结果
+	ObserveRequest(observation RequestObservation)
+}
+
+// TracingMiddleware returns middleware wrapping each attempt in a span, with child span per endpoint post
+// Attempt span carries method, attempt and status of the attempt as a whole
+// Endpoint spans add endpoint and their own status, covering failover posts and hedged copies
+// Hedged copies losing the race end as canceled
+//
+// TracingMiddleware 返回将每次尝试包裹在 span 中的中间件，每次向端点发送都有子 span
+// 尝试 span 携带方法、尝试序号以及整个尝试的状态
+// 端点 span 额外携带端点及其自身状态，覆盖故障转移发送和对冲副本
+// 竞争落败的对冲副本以已取消状态结束
+func TracingMiddleware(tracer Tracer) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, call *RpcCall) ([]byte, error) {
+			method := callMethod(call)
+			attempt := AttemptFromContext(ctx)
+			ctx, span := tracer.Start(ctx, method)
+			startSpan(span, call, method, attempt)
+
+			call.ObserveEndpoints(func(ctx context.Context, serverUrl string) (context.Context, func(data []byte, err error)) {
+				ctx, endpointSpan := tracer.Start(ctx, method)
+				startSpan(endpointSpan, call, method, attempt)
+				endpointSpan.SetAttribute(AttrEndpoint, serverUrl)
+				return ctx, func(data []byte, err error) {
+					endSpan(endpointSpan, classifyCall(ctx, data, err), err)
+				}
+			})
+
+			data, err := next(ctx, call)
+			endSpan(span, classifyCall(ctx, data, err), err)
+			return data, err
+		}
+	}
+}
+
+// startSpan sets attributes shared by attempt and endpoint spans
+//
+// startSpan 设置尝试 span 和端点 span 共有的属性
+func startSpan(span Span, call *RpcCall, method string, attempt int) {
+	span.SetAttribute(AttrRpcSystem, "jsonrpc")
+	span.SetAttribute(AttrRpcMethod, method)
+	if call.Request == nil {
+		span.SetAttribute(AttrBatchSize, len(call.Batch))
+	}
+	span.SetAttribute(AttrAttempt, attempt)
+}
+
+// endSpan sets outcome attributes, records error and ends span
+//
+// endSpan 设置结果属性、记录错误并结束 span
+func endSpan(span Span, outcome callOutcome, err error) {
+	span.SetAttribute(AttrStatus, outcome.status)
+	if outcome.errorCode != 0 {
+		span.SetAttribute(AttrErrorCode, outcome.errorCode)
+	}
+	if outcome.httpStatus != 0 {
+		span.SetAttribute(AttrHTTPStatusCode, outcome.httpStatus)
+	}
+	if err != nil {
+		span.RecordError(err)
+	}
+	span.End()
+}
+
+// MetricsMiddleware returns middleware reporting in-flight gauge and outcome of each endpoint post
+// Failover posts and hedged copies each get observed with their own endpoint, status and latency
+// Attempt answered without reaching an endpoint gets one observation with empty endpoint
+//
+// MetricsMiddleware 返回报告进行中仪表和每次向端点发送结果的中间件
+// 故障转移发送和对冲副本各自以其端点、状态和延迟被观测
+// 未到达端点即被应答的尝试得到一条端点为空的观测
+func MetricsMiddleware(collector MetricsCollector) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, call *RpcCall) ([]byte, error) {
+			method := callMethod(call)
+			attempt := AttemptFromContext(ctx)
+			collector.AddInFlight(method, 1)
+			defer collector.AddInFlight(method, -1)
+
+			observe := func(ctx context.Context, serverUrl string, startTime time.Time, data []byte, err error) {
+				outcome := classifyCall(ctx, data, err)
+				collector.ObserveRequest(RequestObservation{
+					Method:       method,
+					Endpoint:     serverUrl,
+					Status:       outcome.status,
+					ErrorCode:    outcome.errorCode,
+					Attempt:      attempt,
+					Latency:      time.Since(startTime),
+					ResponseSize: len(data),
+				})
+			}
+			var posts atomic.Int64
+			call.ObserveEndpoints(func(ctx context.Context, serverUrl string) (context.Context, func(data []byte, err error)) {
+				posts.Add(1)
+				startTime := time.Now()
+				return ctx, func(data []byte, err error) {
+					observe(ctx, serverUrl, startTime, data, err)
+				}
+			})
+
+			startTime := time.Now()
+			data, err := next(ctx, call)
+			if posts.Load() == 0 {
+				observe(ctx, "", startTime, data, err)
+			}
+			return data, err
+		}
+	}
+}
+
+// callMethod returns method label of call, "batch" in batch calls
+//
+// callMethod 返回调用的方法标签，批量调用时为 "batch"
+func callMethod(call *RpcCall) string {
+	if call.Request != nil {
+		return call.Request.Method
+	}
+	return "batch"
+}
+
+// callOutcome represents classified result of one attempt
+//
+// callOutcome 表示一次尝试的分类结果
+type callOutcome struct {
+	status     string // Call status // 调用状态
+	errorCode  int    // JSON-RPC error code // JSON-RPC 错误码
+	httpStatus int    // HTTP status code of failed responses // 失败响应的 HTTP 状态码
+}
+
+// classifyCall sorts attempt into status, reading JSON-RPC error code from error or single response body
+//
+// classifyCall 将尝试归类为状态，从错误或单个响应体中读取 JSON-RPC 错误码
+func classifyCall(ctx context.Context, data []byte, err error) callOutcome {
+	if err != nil {
+		var rpcError *RpcError
+		var statusErr *ErrHTTPStatus
+		switch {
+		case errors.As(err, &rpcError):
+			return callOutcome{status: StatusRpcError, errorCode: rpcError.Code}
+		case errors.As(err, &statusErr):
+			return callOutcome{status: StatusHTTPError, httpStatus: statusErr.Code}
+		case ctx.Err() != nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded):
+			return callOutcome{status: StatusCanceled}
+		case isTransportError(err):
+			return callOutcome{status: StatusTransportError}
+		default:
+			return callOutcome{status: StatusError}
+		}
+	}
+	var head struct {
+		Error *RpcError `json:"error"`
+	}
+	// Skip decoding bodies that cannot carry an error object, huge results stay cheap
+	// 跳过不可能携带错误对象的响应体，使巨大结果的开销保持较低
+	if bytes.HasPrefix(data, []byte("{")) && bytes.Contains(data, []byte(`"error"`)) && json.Unmarshal(data, &head) == nil && head.Error != nil {
+		return callOutcome{status: StatusRpcError, errorCode: head.Error.Code}
+	}
+	return callOutcome{status: StatusOK}
+}
+
+// MemorySpan represents span kept by MemoryTracer
+//
+// MemorySpan 表示 MemoryTracer 保存的 span
+type MemorySpan struct {
+	Name       string         // Span name // span 名称
+	Attributes map[string]any // Span attributes // span 属性
+	Errors     []error        // Recorded errors // 记录的错误
+	StartTime  time.Time      // Start time // 开始时间
+	EndTime    time.Time      // End time, zero while running // 结束时间，运行中为零
+	tracer     *MemoryTracer  // Owning tracer guarding fields // 保护字段的所属追踪器
+}
+
+// SetAttribute sets span attribute
+//
+// SetAttribute 设置 span 属性
+func (span *MemorySpan) SetAttribute(key string, value any) {
+	span.tracer.mutex.Lock()
+	defer span.tracer.mutex.Unlock()
+	span.Attributes[key] = value
+}
+
+// RecordError records error on span
+//
+// RecordError 在 span 上记录错误
+func (span *MemorySpan) RecordError(err error) {
+	span.tracer.mutex.Lock()
+	defer span.tracer.mutex.Unlock()
+	span.Errors = append(span.Errors, err)
+}
+
+// End ends span and hands it to the tracer
+//
+// End 结束 span 并交给追踪器
+func (span *MemorySpan) End() {
+	span.tracer.mutex.Lock()
+	defer span.tracer.mutex.Unlock()
+	span.EndTime = time.Now()
+	span.tracer.ended = append(span.tracer.ended, span)
+}
+
+// MemoryTracer represents in-memory tracer keeping ended spans, suits tests
+// Safe to use across goroutines
+//
+// MemoryTracer 表示保存已结束 span 的内存追踪器，适用于测试
+// 可在多个 goroutine 间安全使用
+type MemoryTracer struct {
+	mutex sync.Mutex    // Guards spans // 保护 span
+	ended []*MemorySpan // Ended spans in end order // 按结束顺序排列的已结束 span
+}
+
+// NewMemoryTracer creates empty in-memory tracer
+//
+// NewMemoryTracer 创建空的内存追踪器
+func NewMemoryTracer() *MemoryTracer {
+	return &MemoryTracer{}
+}
+
+// Start starts span kept in memory once ended
+//
+// Start 开启结束后保存在内存中的 span
+func (tracer *MemoryTracer) Start(ctx context.Context, name string) (context.Context, Span) {
+	return ctx, &MemorySpan{Name: name, Attributes: map[string]any{}, StartTime: time.Now(), tracer: tracer}
+}
+
+// Spans returns ended spans in end order
+//
+// Spans 按结束顺序返回已结束的 span
+func (tracer *MemoryTracer) Spans() []*MemorySpan {
+	tracer.mutex.Lock()
+	defer tracer.mutex.Unlock()
+	return slices.Clone(tracer.ended)
+}
+
+// MemoryMetrics represents in-memory metrics collector, suits tests
+// Safe to use across goroutines
+//
+// MemoryMetrics 表示内存指标收集器，适用于测试
+// 可在多个 goroutine 间安全使用
+type MemoryMetrics struct {
+	mutex        sync.Mutex           // Guards fields below // 保护以下字段
+	inFlight     map[string]int       // In-flight gauge by method // 按方法的进行中仪表
+	observations []RequestObservation // Observed attempts in order // 按顺序观测到的尝试
+}
+
+// NewMemoryMetrics creates empty in-memory metrics collector
+//
+// NewMemoryMetrics 创建空的内存指标收集器
+func NewMemoryMetrics() *MemoryMetrics {
+	return &MemoryMetrics{inFlight: map[string]int{}}
+}
+
+// AddInFlight adds delta to in-flight gauge of method
+//
+// AddInFlight 向方法的进行中仪表增加 delta
+func (metrics *MemoryMetrics) AddInFlight(method string, delta int) {
+	metrics.mutex.Lock()
+	defer metrics.mutex.Unlock()
+	metrics.inFlight[method] += delta
+}
+
+// ObserveRequest records outcome of one attempt
+//
+// ObserveRequest 记录一次尝试的结果
+func (metrics *MemoryMetrics) ObserveRequest(observation RequestObservation) {
+	metrics.mutex.Lock()
+	defer metrics.mutex.Unlock()
+	metrics.observations = append(metrics.observations, observation)
+}
+
+// InFlight returns in-flight gauge of method
+//
+// InFlight 返回方法的进行中仪表
+func (metrics *MemoryMetrics) InFlight(method string) int {
+	metrics.mutex.Lock()
+	defer metrics.mutex.Unlock()
+	return metrics.inFlight[method]
+}
+
+// Observations returns observed attempts in order
+//
+// Observations 按顺序返回观测到的尝试
+func (metrics *MemoryMetrics) Observations() []RequestObservation {
+	metrics.mutex.Lock()
+	defer metrics.mutex.Unlock()
+	return slices.Clone(metrics.observations)
+}
+
+// Count returns number of attempts matching method and status, empty status matches any
+//
+// Count 返回匹配方法和状态的尝试次数，状态为空时匹配任意状态
+func (metrics *MemoryMetrics) Count(method string, status string) int {
+	metrics.mutex.Lock()
+	defer metrics.mutex.Unlock()
+	var count int
+	for _, observation := range metrics.observations {
+		if observation.Method == method && (status == "" || observation.Status == status) {
+			count++
+		}
+	}
+	return count
+}
+
+// Retries returns number of retry attempts of method
+//
+// Retries 返回方法的重试次数
+func (metrics *MemoryMetrics) Retries(method string) int {
+	metrics.mutex.Lock()
+	defer metrics.mutex.Unlock()
+	var count int
+	for _, observation := range metrics.observations {
+		if observation.Method == method && observation.Attempt > 1 {
+			count++
+		}
+	}
+	return count
+}
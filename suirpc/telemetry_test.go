@@ -0,0 +1,140 @@
+package suirpc_test
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-xlan/sui-go-guide/suirpc"
+	"github.com/stretchr/testify/require"
+)
+
+// TestTracingMiddleware tests each attempt gets span with method, endpoint, attempt and status
+//
+// TestTracingMiddleware 测试每次尝试都有携带方法、端点、尝试序号和状态的 span
+func TestTracingMiddleware(t *testing.T) {
+	var status, calls atomic.Int64
+	status.Store(http.StatusServiceUnavailable)
+	node := newStandInNode(t, "node", "35834a8a", &status, &calls)
+
+	tracer := suirpc.NewMemoryTracer()
+	policy := &suirpc.RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond, Multiplier: 1}
+	client := suirpc.NewClient(node.URL).SetRetryPolicy(policy).Use(suirpc.TracingMiddleware(tracer))
+
+	request := &suirpc.RpcRequest{Jsonrpc: "2.0", Method: "sui_getTotalTransactionBlocks", Params: []any{}}
+	_, err := client.SendRpc(context.Background(), request)
+	require.ErrorIs(t, err, &suirpc.ErrHTTPStatus{Code: http.StatusServiceUnavailable})
+
+	// Endpoint span ends before its attempt span
+	// 端点 span 在其尝试 span 之前结束
+	spans := tracer.Spans()
+	require.Len(t, spans, 4)
+	for idx, span := range spans {
+		require.Equal(t, "sui_getTotalTransactionBlocks", span.Name)
+		require.Equal(t, idx/2+1, span.Attributes[suirpc.AttrAttempt])
+		require.Equal(t, suirpc.StatusHTTPError, span.Attributes[suirpc.AttrStatus])
+		require.Equal(t, http.StatusServiceUnavailable, span.Attributes[suirpc.AttrHTTPStatusCode])
+		require.Len(t, span.Errors, 1)
+		if idx%2 == 0 {
+			require.Equal(t, node.URL, span.Attributes[suirpc.AttrEndpoint])
+		} else {
+			require.NotContains(t, span.Attributes, suirpc.AttrEndpoint)
+		}
+	}
+
+	status.Store(http.StatusOK)
+	_, err = client.SendRpc(context.Background(), request)
+	require.NoError(t, err)
+	require.Len(t, tracer.Spans(), 6)
+	span := tracer.Spans()[5]
+	require.Equal(t, suirpc.StatusOK, span.Attributes[suirpc.AttrStatus])
+	require.Empty(t, span.Errors)
+	require.False(t, span.EndTime.Before(span.StartTime))
+}
+
+// TestMetricsMiddleware tests counts, retries, error codes and in-flight gauge
+//
+// TestMetricsMiddleware 测试计数、重试、错误码和进行中仪表
+func TestMetricsMiddleware(t *testing.T) {
+	server := newRawServer(t)
+
+	metrics := suirpc.NewMemoryMetrics()
+	client := suirpc.NewClient(server.URL).Use(suirpc.MetricsMiddleware(metrics))
+
+	_, err := client.SendRpc(context.Background(), &suirpc.RpcRequest{Jsonrpc: "2.0", Method: "sui_getCheckpoints", Params: []any{}})
+	require.NoError(t, err)
+	_, err = client.SendRpc(context.Background(), &suirpc.RpcRequest{Jsonrpc: "2.0", Method: "sui_getTransactionBlock", Params: []any{}})
+	require.ErrorIs(t, err, suirpc.ErrInvalidParams)
+
+	require.Equal(t, 1, metrics.Count("sui_getCheckpoints", suirpc.StatusOK))
+	require.Equal(t, 1, metrics.Count("sui_getTransactionBlock", suirpc.StatusRpcError))
+	require.Equal(t, 0, metrics.InFlight("sui_getCheckpoints"))
+
+	observations := metrics.Observations()
+	require.Len(t, observations, 2)
+	require.Equal(t, server.URL, observations[0].Endpoint)
+	require.Positive(t, observations[0].ResponseSize)
+	require.Equal(t, -32602, observations[1].ErrorCode)
+
+	// Retried attempts get counted separately
+	// 重试的尝试单独计数
+	var status, calls atomic.Int64
+	status.Store(http.StatusTooManyRequests)
+	node := newStandInNode(t, "node", "35834a8a", &status, &calls)
+	policy := &suirpc.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond, Multiplier: 1}
+	_, err = suirpc.NewClient(node.URL).SetRetryPolicy(policy).Use(suirpc.MetricsMiddleware(metrics)).
+		SendRpc(context.Background(), &suirpc.RpcRequest{Jsonrpc: "2.0", Method: "suix_getBalance", Params: []any{}})
+	require.Error(t, err)
+	require.Equal(t, 3, metrics.Count("suix_getBalance", suirpc.StatusHTTPError))
+	require.Equal(t, 2, metrics.Retries("suix_getBalance"))
+}
+
+// TestTelemetry_Pool tests failover posts and hedged copies get recorded per endpoint
+//
+// TestTelemetry_Pool 测试故障转移发送和对冲副本按端点记录
+func TestTelemetry_Pool(t *testing.T) {
+	var status1, status2, calls1, calls2 atomic.Int64
+	status1.Store(http.StatusBadGateway)
+	status2.Store(http.StatusOK)
+	node1 := newStandInNode(t, "node1", "35834a8a", &status1, &calls1)
+	node2 := newStandInNode(t, "node2", "35834a8a", &status2, &calls2)
+
+	tracer := suirpc.NewMemoryTracer()
+	metrics := suirpc.NewMemoryMetrics()
+	client := suirpc.NewClient("").SetEndpointPool(suirpc.NewEndpointPool(node1.URL, node2.URL)).
+		Use(suirpc.TracingMiddleware(tracer), suirpc.MetricsMiddleware(metrics))
+	_, err := client.SendRpc(context.Background(), &suirpc.RpcRequest{Jsonrpc: "2.0", Method: "suix_getBalance", Params: []any{}})
+	require.NoError(t, err)
+
+	observations := metrics.Observations()
+	require.Len(t, observations, 2)
+	require.Equal(t, node1.URL, observations[0].Endpoint)
+	require.Equal(t, suirpc.StatusHTTPError, observations[0].Status)
+	require.Equal(t, node2.URL, observations[1].Endpoint)
+	require.Equal(t, suirpc.StatusOK, observations[1].Status)
+	spans := tracer.Spans()
+	require.Len(t, spans, 3)
+	require.Equal(t, node1.URL, spans[0].Attributes[suirpc.AttrEndpoint])
+	require.Equal(t, node2.URL, spans[1].Attributes[suirpc.AttrEndpoint])
+	require.Equal(t, suirpc.StatusOK, spans[2].Attributes[suirpc.AttrStatus])
+
+	// Losing hedged copy gets observed as canceled
+	// 落败的对冲副本被观测为已取消
+	var slowCalls, fastCalls atomic.Int64
+	slow := newStandInNode(t, "slow", "35834a8a", &status2, &slowCalls, withDelay(2*time.Second))
+	fast := newStandInNode(t, "fast", "35834a8a", &status2, &fastCalls)
+	policy := &suirpc.HedgePolicy{Percentile: 0.95, MinDelay: 10 * time.Millisecond, MaxDelay: 30 * time.Millisecond, MaxHedges: 1}
+	metrics = suirpc.NewMemoryMetrics()
+	client = suirpc.NewClient("").SetEndpointPool(suirpc.NewEndpointPool(slow.URL, fast.URL).SetHedgePolicy(policy)).
+		Use(suirpc.MetricsMiddleware(metrics))
+	_, err = client.SendRpc(context.Background(), &suirpc.RpcRequest{Jsonrpc: "2.0", Method: "suix_getBalance", Params: []any{}})
+	require.NoError(t, err)
+	require.Eventually(t, func() bool { return len(metrics.Observations()) == 2 }, time.Second, 5*time.Millisecond)
+	observations = metrics.Observations()
+	require.Equal(t, fast.URL, observations[0].Endpoint)
+	require.Equal(t, suirpc.StatusOK, observations[0].Status)
+	require.Equal(t, slow.URL, observations[1].Endpoint)
+	require.Equal(t, suirpc.StatusCanceled, observations[1].Status)
+}
@@ -0,0 +1,686 @@
+package suirpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/yyle88/erero"
+	"github.com/yyle88/neatjson/neatjsons"
+	"github.com/yyle88/zaplog"
+	"go.uber.org/zap"
+	"golang.org/x/net/websocket"
+)
+
+var (
+	ErrWsClosed       = errors.New("websocket client closed")              // Client closed by caller // 客户端已被调用方关闭
+	ErrWsDisconnected = errors.New("websocket connection lost")            // Connection dropped before response // 响应到达前连接断开
+	ErrSlowConsumer   = errors.New("subscription consumer falls too back") // Consumer did not keep up with notifications // 消费方跟不上通知速度
+)
+
+// WsClient represents JSON-RPC client over one WebSocket connection
+// Multiplexes calls and subscriptions on the same connection
+// Reconnects after connection loss and restores active subscriptions
+// Safe to use across goroutines
+//
+// WsClient 表示基于单个 WebSocket 连接的 JSON-RPC 客户端
+// 在同一连接上复用调用和订阅
+// 连接断开后重新连接并恢复活跃订阅
+// 可在多个 goroutine 间安全使用
+type WsClient struct {
+	wsUrl         string        // WebSocket endpoint URL // WebSocket 端点 URL
+	origin        string        // Origin header used in handshake // 握手使用的 Origin 头
+	headers       http.Header   // Headers sent in handshake // 握手携带的请求头
+	bufferSize    int           // Notifications buffered per subscription // 每个订阅缓冲的通知数
+	slowTimeout   time.Duration // Wait on full buffer before dropping subscription // 缓冲满时放弃订阅前的等待时间
+	reconnectWait time.Duration // Wait between reconnect attempts // 重连尝试之间的等待时间
+	debugMode     bool          // Debug logging switch // 调试日志开关
+
+	dialMutex     sync.Mutex                   // Serializes dialing // 串行化拨号
+	writeMutex    sync.Mutex                   // Serializes frame writes // 串行化帧写入
+	mutex         sync.Mutex                   // Guards fields below // 保护以下字段
+	conn          *websocket.Conn              // Current connection, nil when lost // 当前连接，断开时为 nil
+	pending       map[string]*wsWaiter         // Calls waiting responses by ID // 按 ID 等待响应的调用
+	subscriptions map[string]*wsSubscription   // Active subscriptions by server ID // 按服务端 ID 索引的活跃订阅
+	active        map[*wsSubscription]struct{} // Subscriptions to restore on reconnect // 重连时需恢复的订阅
+	subscribing   int                          // Subscribe calls waiting server ID // 等待服务端 ID 的订阅调用数
+	early         map[string][]json.RawMessage // Notifications arriving before their subscribe call returns // 在订阅调用返回前到达的通知
+	requestID     atomic.Int64                 // Request ID counter // 请求 ID 计数器
+	done          chan struct{}                // Closed when client closes // 客户端关闭时关闭
+	closed        bool                         // Set once client closes // 客户端关闭后置位
+}
+
+// NewWsClient creates WebSocket client with given endpoint URL
+// Connection is established on first call or subscription
+//
+// NewWsClient 使用给定端点 URL 创建 WebSocket 客户端
+// 在首次调用或订阅时建立连接
+func NewWsClient(wsUrl string) *WsClient {
+	return &WsClient{
+		wsUrl:         wsUrl,
+		origin:        "http://localhost/",
+		headers:       http.Header{},
+		bufferSize:    64,
+		slowTimeout:   10 * time.Second,
+		reconnectWait: time.Second,
+		pending:       map[string]*wsWaiter{},
+		subscriptions: map[string]*wsSubscription{},
+		active:        map[*wsSubscription]struct{}{},
+		early:         map[string][]json.RawMessage{},
+		done:          make(chan struct{}),
+	}
+}
+
+// SetHeader sets header sent in WebSocket handshake
+//
+// SetHeader 设置 WebSocket 握手携带的请求头
+func (w *WsClient) SetHeader(key string, value string) *WsClient {
+	w.headers.Set(key, value)
+	return w
+}
+
+// SetBufferSize sets count of notifications buffered per subscription
+//
+// SetBufferSize 设置每个订阅缓冲的通知数量
+func (w *WsClient) SetBufferSize(bufferSize int) *WsClient {
+	w.bufferSize = max(bufferSize, 1)
+	return w
+}
+
+// SetSlowConsumerTimeout sets how long reading waits on full subscription buffer
+// Subscription ends with ErrSlowConsumer once the wait runs out
+//
+// SetSlowConsumerTimeout 设置读取在订阅缓冲满时的等待时长
+// 等待超时后订阅以 ErrSlowConsumer 结束
+func (w *WsClient) SetSlowConsumerTimeout(timeout time.Duration) *WsClient {
+	w.slowTimeout = timeout
+	return w
+}
+
+// SetReconnectWait sets wait between reconnect attempts
+//
+// SetReconnectWait 设置重连尝试之间的等待时间
+func (w *WsClient) SetReconnectWait(wait time.Duration) *WsClient {
+	w.reconnectWait = wait
+	return w
+}
+
+// SetDebug enables or disables debug logging of this client
+//
+// SetDebug 启用或禁用此客户端的调试日志
+func (w *WsClient) SetDebug(enable bool) *WsClient {
+	w.debugMode = enable
+	return w
+}
+
+// Connect establishes connection ahead of first call
+//
+// Connect 在首次调用前建立连接
+func (w *WsClient) Connect(ctx context.Context) error {
+	if _, err := w.ensureConn(ctx); err != nil {
+		return erero.Wro(err)
+	}
+	return nil
+}
+
+// Close closes connection and ends each subscription
+//
+// Close 关闭连接并结束每个订阅
+func (w *WsClient) Close() error {
+	w.mutex.Lock()
+	if w.closed {
+		w.mutex.Unlock()
+		return nil
+	}
+	w.closed = true
+	close(w.done)
+	conn := w.conn
+	w.conn = nil
+	pending := w.pending
+	w.pending = map[string]*wsWaiter{}
+	active := w.active
+	w.active = map[*wsSubscription]struct{}{}
+	w.subscriptions = map[string]*wsSubscription{}
+	w.early = map[string][]json.RawMessage{}
+	w.mutex.Unlock()
+
+	for _, waiter := range pending {
+		close(waiter.lost)
+	}
+	for sub := range active {
+		sub.drop(ErrWsClosed)
+	}
+	if conn != nil {
+		return conn.Close()
+	}
+	return nil
+}
+
+// Send sends RPC request over WebSocket and decodes result into result
+// Accepts pointer as result target, nil skips decoding
+//
+// Send 通过 WebSocket 发送 RPC 请求并将结果解码到 result
+// 接受指针作为结果目标，nil 时跳过解码
+func (w *WsClient) Send(ctx context.Context, request *RpcRequest, result any) error {
+	message, err := w.call(ctx, request)
+	if err != nil {
+		return erero.Wro(err)
+	}
+	if result != nil && len(message.Result) > 0 {
+		if err := json.Unmarshal(message.Result, result); err != nil {
+			return erero.Wro(err)
+		}
+	}
+	return nil
+}
+
+// Subscription represents live subscription with typed notification channel
+// Channel closes when subscription ends, Err tells the reason
+//
+// Subscription 表示带类型化通知通道的活跃订阅
+// 订阅结束时通道关闭，Err 说明原因
+type Subscription[T any] struct {
+	sub           *wsSubscription // Underlying subscription state // 底层订阅状态
+	notifications chan T          // Typed notifications // 类型化通知
+	cancel        func()          // Cancels subscription context // 取消订阅上下文
+}
+
+// Notifications returns channel delivering typed notifications
+//
+// Notifications 返回传递类型化通知的通道
+func (s *Subscription[T]) Notifications() <-chan T {
+	return s.notifications
+}
+
+// Err returns reason subscription ended, nil when ended by unsubscribe
+//
+// Err 返回订阅结束的原因，通过取消订阅结束时为 nil
+func (s *Subscription[T]) Err() error {
+	return s.sub.getErr()
+}
+
+// Unsubscribe ends subscription and tells the node to stop sending
+//
+// Unsubscribe 结束订阅并通知节点停止发送
+func (s *Subscription[T]) Unsubscribe() {
+	s.cancel()
+}
+
+// Subscribe starts subscription with given methods and params
+// Notifications decode into T and flow through bounded buffer
+// Context cancel unsubscribes and closes the notification channel
+//
+// Subscribe 使用给定方法和参数开始订阅
+// 通知解码为 T 并经过有界缓冲传递
+// 上下文取消时取消订阅并关闭通知通道
+func Subscribe[T any](ctx context.Context, wsClient *WsClient, method string, unsubscribeMethod string, params []any) (*Subscription[T], error) {
+	sub := &wsSubscription{
+		method:            method,
+		unsubscribeMethod: unsubscribeMethod,
+		params:            params,
+		inbox:             make(chan json.RawMessage, wsClient.bufferSize),
+		dropped:           make(chan struct{}),
+	}
+	if err := wsClient.subscribe(ctx, sub); err != nil {
+		return nil, erero.Wro(err)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	subscription := &Subscription[T]{sub: sub, notifications: make(chan T), cancel: cancel}
+	go func() {
+		defer close(subscription.notifications)
+		for {
+			select {
+			case <-ctx.Done():
+				wsClient.unsubscribe(sub)
+				return
+			case <-sub.dropped:
+				wsClient.unsubscribe(sub)
+				return
+			case raw := <-sub.inbox:
+				var notification T
+				if err := json.Unmarshal(raw, &notification); err != nil {
+					sub.drop(erero.Wro(err))
+					continue
+				}
+				select {
+				case subscription.notifications <- notification:
+				case <-ctx.Done():
+				case <-sub.dropped:
+				}
+			}
+		}
+	}()
+	return subscription, nil
+}
+
+// SubscribeEvent subscribes to events matching filter through suix_subscribeEvent
+//
+// SubscribeEvent 通过 suix_subscribeEvent 订阅匹配过滤器的事件
+func SubscribeEvent[T any](ctx context.Context, wsClient *WsClient, filter any) (*Subscription[T], error) {
+	return Subscribe[T](ctx, wsClient, "suix_subscribeEvent", "suix_unsubscribeEvent", []any{filter})
+}
+
+// SubscribeTransaction subscribes to transaction effects matching filter through suix_subscribeTransaction
+//
+// SubscribeTransaction 通过 suix_subscribeTransaction 订阅匹配过滤器的交易效果
+func SubscribeTransaction[T any](ctx context.Context, wsClient *WsClient, filter any) (*Subscription[T], error) {
+	return Subscribe[T](ctx, wsClient, "suix_subscribeTransaction", "suix_unsubscribeTransaction", []any{filter})
+}
+
+// wsSubscription represents subscription state shared with the read loop
+//
+// wsSubscription 表示与读取循环共享的订阅状态
+type wsSubscription struct {
+	method            string               // Subscribe method name // 订阅方法名
+	unsubscribeMethod string               // Unsubscribe method name // 取消订阅方法名
+	params            []any                // Subscribe params kept to resubscribe // 为重新订阅保留的订阅参数
+	inbox             chan json.RawMessage // Raw notifications buffer // 原始通知缓冲
+	dropped           chan struct{}        // Closed when subscription ends // 订阅结束时关闭
+	mutex             sync.Mutex           // Guards fields below // 保护以下字段
+	serverID          string               // Subscription ID given by the node // 节点分配的订阅 ID
+	err               error                // Reason subscription ended // 订阅结束的原因
+	once              sync.Once            // Ensures single drop // 确保仅结束一次
+}
+
+// drop ends subscription with given reason
+//
+// drop 以给定原因结束订阅
+func (sub *wsSubscription) drop(err error) {
+	sub.once.Do(func() {
+		sub.mutex.Lock()
+		sub.err = err
+		sub.mutex.Unlock()
+		close(sub.dropped)
+	})
+}
+
+// getErr returns reason subscription ended
+//
+// getErr 返回订阅结束的原因
+func (sub *wsSubscription) getErr() error {
+	sub.mutex.Lock()
+	defer sub.mutex.Unlock()
+	return sub.err
+}
+
+// wsWaiter represents call waiting its response
+// Response is sent without blocking while the entry is in pending, lost closes once taken out on connection loss
+//
+// wsWaiter 表示等待响应的调用
+// 条目位于 pending 中时以非阻塞方式发送响应，连接断开将其取出后关闭 lost
+type wsWaiter struct {
+	response chan *wsMessage // Response, buffered by one // 响应，缓冲为一
+	lost     chan struct{}   // Closed when connection drops or client closes // 连接断开或客户端关闭时关闭
+}
+
+// wsMessage represents any message read from the connection
+// Responses carry ID, notifications carry method and params
+//
+// wsMessage 表示从连接读取的任意消息
+// 响应携带 ID，通知携带方法和参数
+type wsMessage struct {
+	Jsonrpc string          `json:"jsonrpc"`          // JSON-RPC version // JSON-RPC 版本
+	ID      json.RawMessage `json:"id,omitempty"`     // Response ID // 响应 ID
+	Method  string          `json:"method,omitempty"` // Notification method // 通知方法
+	Params  *struct {
+		Subscription json.RawMessage `json:"subscription"` // Subscription ID // 订阅 ID
+		Result       json.RawMessage `json:"result"`       // Notification payload // 通知载荷
+	} `json:"params,omitempty"` // Notification params // 通知参数
+	Result json.RawMessage `json:"result,omitempty"` // Response result // 响应结果
+	Error  *RpcError       `json:"error,omitempty"`  // Response error // 响应错误
+}
+
+// subscribe sends subscribe call and registers subscription
+// Notifications that beat the response are handed over in order before later ones
+// Subscription ended meanwhile stays unregistered and the node is told to stop
+//
+// subscribe 发送订阅调用并注册订阅
+// 先于响应到达的通知会在后续通知之前按顺序交付
+// 期间已结束的订阅不会被注册，并通知节点停止发送
+func (w *WsClient) subscribe(ctx context.Context, sub *wsSubscription) error {
+	w.mutex.Lock()
+	w.subscribing++
+	w.mutex.Unlock()
+	message, err := w.call(ctx, &RpcRequest{Jsonrpc: "2.0", Method: sub.method, Params: sub.params})
+
+	w.mutex.Lock()
+	w.subscribing--
+	var early []json.RawMessage
+	if err == nil {
+		early = w.early[string(message.Result)]
+		delete(w.early, string(message.Result))
+	}
+	if w.subscribing == 0 {
+		clear(w.early)
+	}
+	if err != nil {
+		w.mutex.Unlock()
+		return erero.Wro(err)
+	}
+	serverID := string(message.Result)
+	if w.closed {
+		w.mutex.Unlock()
+		return erero.Wro(ErrWsClosed)
+	}
+	select {
+	case <-sub.dropped:
+		w.mutex.Unlock()
+		w.stopServer(sub, serverID)
+		return nil
+	default:
+	}
+	sub.mutex.Lock()
+	delete(w.subscriptions, sub.serverID)
+	sub.serverID = serverID
+	sub.mutex.Unlock()
+	w.subscriptions[serverID] = sub
+	w.active[sub] = struct{}{}
+	for _, raw := range early {
+		select {
+		case sub.inbox <- raw:
+		default:
+			sub.drop(ErrSlowConsumer)
+		}
+	}
+	w.mutex.Unlock()
+	return nil
+}
+
+// unsubscribe removes subscription and sends unsubscribe call when connected
+//
+// unsubscribe 移除订阅并在已连接时发送取消订阅调用
+func (w *WsClient) unsubscribe(sub *wsSubscription) {
+	sub.drop(nil)
+
+	w.mutex.Lock()
+	_, ok := w.active[sub]
+	delete(w.active, sub)
+	sub.mutex.Lock()
+	serverID := sub.serverID
+	sub.mutex.Unlock()
+	delete(w.subscriptions, serverID)
+	connected := w.conn != nil
+	w.mutex.Unlock()
+
+	if !ok || !connected {
+		return
+	}
+	w.stopServer(sub, serverID)
+}
+
+// stopServer sends unsubscribe call telling the node to stop sending to server ID
+//
+// stopServer 发送取消订阅调用，通知节点停止向服务端 ID 发送
+func (w *WsClient) stopServer(sub *wsSubscription, serverID string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if _, err := w.call(ctx, &RpcRequest{Jsonrpc: "2.0", Method: sub.unsubscribeMethod, Params: []any{json.RawMessage(serverID)}}); err != nil {
+		zaplog.LOG.Debug("websocket unsubscribe", zap.String("method", sub.unsubscribeMethod), zap.Error(err))
+	}
+}
+
+// call sends request and waits response of the same ID
+// Assigns request ID when empty, rejects IDs already waiting
+//
+// call 发送请求并等待相同 ID 的响应
+// 请求 ID 为空时分配 ID，拒绝仍在等待中的 ID
+func (w *WsClient) call(ctx context.Context, request *RpcRequest) (*wsMessage, error) {
+	conn, err := w.ensureConn(ctx)
+	if err != nil {
+		return nil, erero.Wro(err)
+	}
+
+	request = withID(request, w.nextRequestID)
+	key := idKey(request.ID)
+	waiter := &wsWaiter{response: make(chan *wsMessage, 1), lost: make(chan struct{})}
+	w.mutex.Lock()
+	if _, ok := w.pending[key]; ok {
+		w.mutex.Unlock()
+		return nil, erero.WithMessagef(ErrDuplicateID, "id=%s method=%s", key, request.Method)
+	}
+	w.pending[key] = waiter
+	w.mutex.Unlock()
+	defer func() {
+		w.mutex.Lock()
+		if w.pending[key] == waiter {
+			delete(w.pending, key)
+		}
+		w.mutex.Unlock()
+	}()
+
+	if err := w.write(conn, request); err != nil {
+		return nil, erero.Wro(err)
+	}
+
+	var message *wsMessage
+	select {
+	case <-ctx.Done():
+		return nil, erero.Wro(ctx.Err())
+	case message = <-waiter.response:
+	case <-waiter.lost:
+		// Response may have landed just before the connection dropped
+		// 响应可能恰好在连接断开前到达
+		select {
+		case message = <-waiter.response:
+		default:
+			return nil, erero.Wro(transportError(ErrWsDisconnected))
+		}
+	}
+	if message.Jsonrpc != "2.0" {
+		return nil, erero.WithMessagef(ErrMalformedResponse, "jsonrpc=%q", message.Jsonrpc)
+	}
+	if message.Error != nil {
+		return nil, erero.Wro(message.Error)
+	}
+	return message, nil
+}
+
+// nextRequestID returns next unique request ID of this client
+//
+// nextRequestID 返回此客户端的下一个唯一请求 ID
+func (w *WsClient) nextRequestID() int {
+	return int(w.requestID.Add(1))
+}
+
+// write sends one JSON text frame
+//
+// write 发送单个 JSON 文本帧
+func (w *WsClient) write(conn *websocket.Conn, request *RpcRequest) error {
+	data, err := json.Marshal(request)
+	if err != nil {
+		return erero.Wro(err)
+	}
+	w.writeMutex.Lock()
+	defer w.writeMutex.Unlock()
+	if err := websocket.Message.Send(conn, string(data)); err != nil {
+		return erero.Wro(transportError(err))
+	}
+	return nil
+}
+
+// ensureConn returns current connection or dials a new one
+//
+// ensureConn 返回当前连接或拨号建立新连接
+func (w *WsClient) ensureConn(ctx context.Context) (*websocket.Conn, error) {
+	w.dialMutex.Lock()
+	defer w.dialMutex.Unlock()
+
+	w.mutex.Lock()
+	conn, closed := w.conn, w.closed
+	w.mutex.Unlock()
+	if closed {
+		return nil, erero.Wro(ErrWsClosed)
+	}
+	if conn != nil {
+		return conn, nil
+	}
+
+	config, err := websocket.NewConfig(w.wsUrl, w.origin)
+	if err != nil {
+		return nil, erero.Wro(err)
+	}
+	config.Header = w.headers.Clone()
+	conn, err = config.DialContext(ctx)
+	if err != nil {
+		return nil, erero.Wro(transportError(err))
+	}
+
+	w.mutex.Lock()
+	if w.closed {
+		w.mutex.Unlock()
+		_ = conn.Close()
+		return nil, erero.Wro(ErrWsClosed)
+	}
+	w.conn = conn
+	w.mutex.Unlock()
+
+	go w.readLoop(conn)
+	return conn, nil
+}
+
+// readLoop reads messages until connection fails, then reconnects
+//
+// readLoop 读取消息直到连接失败，然后重新连接
+func (w *WsClient) readLoop(conn *websocket.Conn) {
+	for {
+		var data []byte
+		if err := websocket.Message.Receive(conn, &data); err != nil {
+			w.reconnect(conn, err)
+			return
+		}
+		if w.debugMode {
+			zaplog.SUG.Debugln("Websocket Raw:", neatjsons.SxB(data))
+		}
+		w.dispatch(data)
+	}
+}
+
+// dispatch routes message to waiting call or subscription
+//
+// dispatch 将消息路由到等待的调用或订阅
+func (w *WsClient) dispatch(data []byte) {
+	var message wsMessage
+	if err := json.Unmarshal(data, &message); err != nil {
+		zaplog.LOG.Debug("websocket message", zap.ByteString("data", data), zap.Error(err))
+		return
+	}
+
+	if message.Params != nil && len(message.Params.Subscription) > 0 {
+		serverID := string(message.Params.Subscription)
+		w.mutex.Lock()
+		sub := w.subscriptions[serverID]
+		if sub == nil && w.subscribing > 0 && len(w.early[serverID]) < w.bufferSize {
+			// Keep notification until the subscribe call learns its server ID
+			// 保留通知，直到订阅调用获知其服务端 ID
+			w.early[serverID] = append(w.early[serverID], message.Params.Result)
+		}
+		w.mutex.Unlock()
+		if sub != nil {
+			w.deliver(sub, message.Params.Result)
+		}
+		return
+	}
+
+	// Send while holding the mutex so the waiter cannot be taken out meanwhile
+	// 持有互斥锁时发送，使等待者在此期间不会被取出
+	w.mutex.Lock()
+	if waiter := w.pending[rawIDKey(message.ID)]; waiter != nil {
+		select {
+		case waiter.response <- &message:
+		default:
+		}
+	}
+	w.mutex.Unlock()
+}
+
+// deliver puts notification into subscription buffer
+// Waits up to slow consumer timeout when buffer is full, then drops subscription
+// Waiting stalls reading, which pushes back on the node through TCP flow control
+//
+// deliver 将通知放入订阅缓冲
+// 缓冲满时最多等待慢消费超时时间，之后放弃订阅
+// 等待期间读取暂停，通过 TCP 流控向节点施加反压
+func (w *WsClient) deliver(sub *wsSubscription, raw json.RawMessage) {
+	select {
+	case sub.inbox <- raw:
+		return
+	case <-sub.dropped:
+		return
+	default:
+	}
+
+	timer := time.NewTimer(w.slowTimeout)
+	defer timer.Stop()
+	select {
+	case sub.inbox <- raw:
+	case <-sub.dropped:
+	case <-timer.C:
+		sub.drop(ErrSlowConsumer)
+	}
+}
+
+// reconnect fails pending calls, dials again and restores subscriptions still live
+//
+// reconnect 使等待中的调用失败，重新拨号并恢复仍然活跃的订阅
+func (w *WsClient) reconnect(conn *websocket.Conn, reason error) {
+	_ = conn.Close()
+
+	w.mutex.Lock()
+	if w.conn == conn {
+		w.conn = nil
+	}
+	pending := w.pending
+	w.pending = map[string]*wsWaiter{}
+	closed := w.closed
+	w.mutex.Unlock()
+
+	for _, waiter := range pending {
+		close(waiter.lost)
+	}
+	if closed {
+		return
+	}
+	zaplog.LOG.Debug("websocket connection lost", zap.String("ws_url", w.wsUrl), zap.Error(reason))
+
+	for {
+		select {
+		case <-w.done:
+			return
+		case <-time.After(w.reconnectWait):
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		_, err := w.ensureConn(ctx)
+		cancel()
+		if errors.Is(err, ErrWsClosed) {
+			return
+		}
+		if err == nil {
+			break
+		}
+	}
+
+	w.mutex.Lock()
+	subs := make([]*wsSubscription, 0, len(w.active))
+	for sub := range w.active {
+		subs = append(subs, sub)
+	}
+	w.mutex.Unlock()
+
+	for _, sub := range subs {
+		select {
+		case <-sub.dropped:
+			continue
+		default:
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		if err := w.subscribe(ctx, sub); err != nil {
+			sub.drop(erero.Wro(err))
+		}
+		cancel()
+	}
+}
@@ -0,0 +1,233 @@
+package suirpc_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-xlan/sui-go-guide/suirpc"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/websocket"
+)
+
+// wsStandInNode represents in-process WebSocket node serving event subscriptions
+// Pushes one numbered event every few milliseconds to each live subscription
+//
+// wsStandInNode 表示提供事件订阅的进程内 WebSocket 节点
+// 每隔几毫秒向每个活跃订阅推送一个编号事件
+type wsStandInNode struct {
+	server       *httptest.Server
+	subscribes   atomic.Int64 // Subscribe calls count // 订阅调用次数
+	unsubscribes atomic.Int64 // Unsubscribe calls count // 取消订阅调用次数
+	dropAfter    atomic.Int64 // Events sent before dropping connection, zero keeps it // 断开连接前发送的事件数，零表示保持
+	eventFirst   atomic.Bool  // Push first event ahead of subscribe response // 在订阅响应之前推送首个事件
+	sequence     atomic.Int64 // Event sequence shared across connections // 跨连接共享的事件序号
+}
+
+// newWsStandInNode starts in-process WebSocket node
+//
+// newWsStandInNode 启动进程内 WebSocket 节点
+func newWsStandInNode(t *testing.T) *wsStandInNode {
+	node := &wsStandInNode{}
+	node.server = httptest.NewServer(websocket.Handler(node.serve))
+	t.Cleanup(node.server.Close)
+	return node
+}
+
+// wsUrl returns WebSocket URL of the node
+//
+// wsUrl 返回节点的 WebSocket URL
+func (node *wsStandInNode) wsUrl() string {
+	return "ws" + strings.TrimPrefix(node.server.URL, "http")
+}
+
+// serve handles one WebSocket connection
+//
+// serve 处理单个 WebSocket 连接
+func (node *wsStandInNode) serve(conn *websocket.Conn) {
+	var mutex sync.Mutex
+	send := func(message any) error {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return websocket.JSON.Send(conn, message)
+	}
+	done := make(chan struct{})
+	defer close(done)
+
+	var sent atomic.Int64
+	for {
+		var request struct {
+			ID     int    `json:"id"`
+			Method string `json:"method"`
+			Params []any  `json:"params"`
+		}
+		if err := websocket.JSON.Receive(conn, &request); err != nil {
+			return
+		}
+		switch request.Method {
+		case "suix_subscribeEvent":
+			subscriptionID := node.subscribes.Add(1) + 100
+			if node.eventFirst.Load() {
+				event := map[string]any{"id": map[string]any{"txDigest": "first", "eventSeq": node.sequence.Add(1)}}
+				_ = send(map[string]any{"jsonrpc": "2.0", "method": "suix_subscribeEvent", "params": map[string]any{"subscription": subscriptionID, "result": event}})
+			}
+			_ = send(map[string]any{"jsonrpc": "2.0", "id": request.ID, "result": subscriptionID})
+			go func() {
+				for {
+					select {
+					case <-done:
+						return
+					case <-time.After(5 * time.Millisecond):
+					}
+					event := map[string]any{"id": map[string]any{"txDigest": "digest", "eventSeq": node.sequence.Add(1)}}
+					if err := send(map[string]any{"jsonrpc": "2.0", "method": "suix_subscribeEvent", "params": map[string]any{"subscription": subscriptionID, "result": event}}); err != nil {
+						return
+					}
+					if limit := node.dropAfter.Load(); limit > 0 && sent.Add(1) == limit {
+						node.dropAfter.Store(0)
+						_ = conn.Close()
+						return
+					}
+				}
+			}()
+		case "suix_unsubscribeEvent":
+			node.unsubscribes.Add(1)
+			_ = send(map[string]any{"jsonrpc": "2.0", "id": request.ID, "result": true})
+		default:
+			_ = send(map[string]any{"jsonrpc": "2.0", "id": request.ID, "result": "4c78adac"})
+		}
+	}
+}
+
+// standInEvent represents event pushed by the stand-in node
+//
+// standInEvent 表示替身节点推送的事件
+type standInEvent struct {
+	ID struct {
+		TxDigest string `json:"txDigest"`
+		EventSeq int64  `json:"eventSeq"`
+	} `json:"id"`
+}
+
+// TestSubscribeEvent tests typed notifications and unsubscribe on context cancel
+//
+// TestSubscribeEvent 测试类型化通知以及上下文取消时取消订阅
+func TestSubscribeEvent(t *testing.T) {
+	node := newWsStandInNode(t)
+	wsClient := suirpc.NewWsClient(node.wsUrl())
+	defer func() { _ = wsClient.Close() }()
+
+	var chainId string
+	require.NoError(t, wsClient.Send(context.Background(), &suirpc.RpcRequest{Jsonrpc: "2.0", Method: "sui_getChainIdentifier", Params: []any{}}, &chainId))
+	require.Equal(t, "4c78adac", chainId)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	subscription, err := suirpc.SubscribeEvent[standInEvent](ctx, wsClient, map[string]any{"All": []any{}})
+	require.NoError(t, err)
+	for range 3 {
+		event := <-subscription.Notifications()
+		require.Equal(t, "digest", event.ID.TxDigest)
+	}
+
+	cancel()
+	for range subscription.Notifications() {
+	}
+	require.NoError(t, subscription.Err())
+	require.Eventually(t, func() bool { return node.unsubscribes.Load() == 1 }, time.Second, 5*time.Millisecond)
+}
+
+// TestSubscribeEvent_Reconnect tests client reconnects and resubscribes after connection loss
+//
+// TestSubscribeEvent_Reconnect 测试连接断开后客户端重新连接并重新订阅
+func TestSubscribeEvent_Reconnect(t *testing.T) {
+	node := newWsStandInNode(t)
+	node.dropAfter.Store(2)
+	wsClient := suirpc.NewWsClient(node.wsUrl()).SetReconnectWait(10 * time.Millisecond)
+	defer func() { _ = wsClient.Close() }()
+
+	subscription, err := suirpc.SubscribeEvent[standInEvent](context.Background(), wsClient, map[string]any{"All": []any{}})
+	require.NoError(t, err)
+	defer subscription.Unsubscribe()
+
+	for range 5 {
+		select {
+		case _, ok := <-subscription.Notifications():
+			require.True(t, ok, subscription.Err())
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "no notification after reconnect")
+		}
+	}
+	require.Equal(t, int64(2), node.subscribes.Load())
+}
+
+// TestSubscribeEvent_SlowConsumer tests subscription ends when consumer stops reading
+//
+// TestSubscribeEvent_SlowConsumer 测试消费方停止读取时订阅结束
+func TestSubscribeEvent_SlowConsumer(t *testing.T) {
+	node := newWsStandInNode(t)
+	wsClient := suirpc.NewWsClient(node.wsUrl()).SetBufferSize(2).SetSlowConsumerTimeout(20 * time.Millisecond)
+	defer func() { _ = wsClient.Close() }()
+
+	subscription, err := suirpc.SubscribeEvent[json.RawMessage](context.Background(), wsClient, map[string]any{"All": []any{}})
+	require.NoError(t, err)
+
+	time.Sleep(200 * time.Millisecond)
+	for range subscription.Notifications() {
+	}
+	require.ErrorIs(t, subscription.Err(), suirpc.ErrSlowConsumer)
+	require.Eventually(t, func() bool { return node.unsubscribes.Load() == 1 }, time.Second, 5*time.Millisecond)
+}
+
+// TestSubscribeEvent_EventFirst tests notification arriving ahead of subscribe response is kept
+//
+// TestSubscribeEvent_EventFirst 测试先于订阅响应到达的通知被保留
+func TestSubscribeEvent_EventFirst(t *testing.T) {
+	node := newWsStandInNode(t)
+	node.eventFirst.Store(true)
+	wsClient := suirpc.NewWsClient(node.wsUrl())
+	defer func() { _ = wsClient.Close() }()
+
+	subscription, err := suirpc.SubscribeEvent[standInEvent](context.Background(), wsClient, map[string]any{"All": []any{}})
+	require.NoError(t, err)
+	defer subscription.Unsubscribe()
+
+	event := <-subscription.Notifications()
+	require.Equal(t, "first", event.ID.TxDigest)
+	require.Equal(t, int64(1), event.ID.EventSeq)
+	event = <-subscription.Notifications()
+	require.Equal(t, int64(2), event.ID.EventSeq)
+}
+
+// TestWsClient_Close tests closing client while calls wait on responses fails them without panic
+//
+// TestWsClient_Close 测试在调用等待响应时关闭客户端会使其失败而不会 panic
+func TestWsClient_Close(t *testing.T) {
+	node := newWsStandInNode(t)
+	wsClient := suirpc.NewWsClient(node.wsUrl())
+	require.NoError(t, wsClient.Connect(context.Background()))
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				var chainId string
+				if err := wsClient.Send(context.Background(), &suirpc.RpcRequest{Jsonrpc: "2.0", Method: "sui_getChainIdentifier", Params: []any{}}, &chainId); err != nil {
+					return
+				}
+			}
+		}()
+	}
+	time.Sleep(20 * time.Millisecond)
+	require.NoError(t, wsClient.Close())
+	wg.Wait()
+
+	err := wsClient.Send(context.Background(), &suirpc.RpcRequest{Jsonrpc: "2.0", Method: "sui_getChainIdentifier", Params: []any{}}, nil)
+	require.ErrorIs(t, err, suirpc.ErrWsClosed)
+}
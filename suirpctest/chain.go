@@ -0,0 +1,221 @@
+package suirpctest
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/go-xlan/sui-go-guide/suirpc"
+)
+
+// genesisTimestampMs is the timestamp of checkpoint zero, each next checkpoint comes one second later
+//
+// genesisTimestampMs 是零号检查点的时间戳，之后每个检查点晚一秒
+const genesisTimestampMs = 1_700_000_000_000
+
+// SetCoinMetadata sets metadata returned by suix_getCoinMetadata of given coin type
+// SUI metadata comes preset, other coin types have none until set
+//
+// SetCoinMetadata 设置给定代币类型的 suix_getCoinMetadata 返回的元数据
+// SUI 元数据已预置，其他代币类型在设置前没有元数据
+func (s *Server) SetCoinMetadata(coinType string, decimals int, symbol string, name string) *Server {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.setCoinMetadata(coinType, decimals, symbol, name)
+	return s
+}
+
+// setCoinMetadata stores coin metadata, caller holds the mutex
+//
+// setCoinMetadata 保存代币元数据，调用方需持有互斥锁
+func (s *Server) setCoinMetadata(coinType string, decimals int, symbol string, name string) {
+	s.metadata[coinType] = map[string]any{
+		"decimals":    decimals,
+		"name":        name,
+		"symbol":      symbol,
+		"description": "",
+		"iconUrl":     nil,
+		"id":          s.ledger.newObjectId(),
+	}
+}
+
+// appendCheckpoint seals next checkpoint holding given transaction, nil response seals empty genesis
+// Stamps transaction response with checkpoint and timestamp like a fullnode
+//
+// appendCheckpoint 封存包含给定交易的下一个检查点，nil 响应封存空的创世检查点
+// 像全节点一样在交易响应上标注检查点和时间戳
+func (s *Server) appendCheckpoint(response map[string]any, gasCost uint64) {
+	sequence := uint64(len(s.checkpoints))
+	timestampMs := formatUint(genesisTimestampMs + sequence*1000)
+	transactions := []string{}
+	if response != nil {
+		transactions = append(transactions, response["digest"].(string))
+		response["checkpoint"] = formatUint(sequence)
+		response["timestampMs"] = timestampMs
+	}
+
+	var previousDigest any
+	var rollingGas uint64
+	if sequence > 0 {
+		previous := s.checkpoints[sequence-1]
+		previousDigest = previous["digest"]
+		rollingGas = previous["rollingGas"].(uint64)
+	}
+	rollingGas += gasCost
+
+	s.checkpoints = append(s.checkpoints, map[string]any{
+		"epoch":                    "0",
+		"sequenceNumber":           formatUint(sequence),
+		"digest":                   s.ledger.newDigest(),
+		"networkTotalTransactions": formatUint(uint64(len(s.transactions))),
+		"previousDigest":           previousDigest,
+		"epochRollingGasCostSummary": map[string]any{
+			"computationCost":         formatUint(rollingGas),
+			"storageCost":             "0",
+			"storageRebate":           "0",
+			"nonRefundableStorageFee": "0",
+		},
+		"timestampMs":           timestampMs,
+		"transactions":          transactions,
+		"checkpointCommitments": []any{},
+		"validatorSignature":    "",
+		"rollingGas":            rollingGas,
+	})
+}
+
+// checkpointJSON returns checkpoint without simulator bookkeeping fields
+//
+// checkpointJSON 返回去掉模拟器记账字段的检查点
+func checkpointJSON(checkpoint map[string]any) map[string]any {
+	result := make(map[string]any, len(checkpoint))
+	for key, value := range checkpoint {
+		if key != "rollingGas" {
+			result[key] = value
+		}
+	}
+	return result
+}
+
+// getCheckpoint serves sui_getCheckpoint by sequence number or digest
+//
+// getCheckpoint 按序号或摘要提供 sui_getCheckpoint
+func (s *Server) getCheckpoint(params params) (any, *suirpc.RpcError) {
+	id, err := params.string(0)
+	if err != nil {
+		return nil, invalidParams(err)
+	}
+	for _, checkpoint := range s.checkpoints {
+		if checkpoint["sequenceNumber"] == id || checkpoint["digest"] == id {
+			return checkpointJSON(checkpoint), nil
+		}
+	}
+	return nil, &suirpc.RpcError{Code: suirpc.CodeInvalidParams, Message: fmt.Sprintf("Could not find the referenced checkpoint [%s].", id)}
+}
+
+// getCheckpoints serves sui_getCheckpoints with cursor pagination in both orders
+// Params: cursor, limit, descending
+//
+// getCheckpoints 提供按两种顺序游标分页的 sui_getCheckpoints
+// 参数：游标、数量上限、是否降序
+func (s *Server) getCheckpoints(params params) (any, *suirpc.RpcError) {
+	cursor, err := params.optionalString(0)
+	if err != nil {
+		return nil, invalidParams(err)
+	}
+	limit, err := params.optionalUint(1)
+	if err != nil {
+		return nil, invalidParams(err)
+	}
+	if limit == 0 {
+		limit = defaultPageLimit
+	}
+	descending := params.present(2) && strings.TrimSpace(string(params[2])) == "true"
+
+	sequences := make([]uint64, 0, len(s.checkpoints))
+	for sequence := range uint64(len(s.checkpoints)) {
+		sequences = append(sequences, sequence)
+	}
+	if descending {
+		slices.Reverse(sequences)
+	}
+	if cursor != "" {
+		after, err := parseUint([]byte(cursor))
+		if err != nil {
+			return nil, invalidParams(err)
+		}
+		first := len(sequences)
+		for idx, sequence := range sequences {
+			if (!descending && sequence > after) || (descending && sequence < after) {
+				first = idx
+				break
+			}
+		}
+		sequences = sequences[first:]
+	}
+	hasNextPage := uint64(len(sequences)) > limit
+	if hasNextPage {
+		sequences = sequences[:limit]
+	}
+
+	data := make([]map[string]any, 0, len(sequences))
+	for _, sequence := range sequences {
+		data = append(data, checkpointJSON(s.checkpoints[sequence]))
+	}
+	var nextCursor any
+	if len(sequences) > 0 {
+		nextCursor = formatUint(sequences[len(sequences)-1])
+	}
+	return map[string]any{"data": data, "hasNextPage": hasNextPage, "nextCursor": nextCursor}, nil
+}
+
+// multiGetTransactionBlocks serves sui_multiGetTransactionBlocks, unknown digests are skipped
+//
+// multiGetTransactionBlocks 提供 sui_multiGetTransactionBlocks，跳过未知摘要
+func (s *Server) multiGetTransactionBlocks(params params) (any, *suirpc.RpcError) {
+	digests, err := params.strings(0)
+	if err != nil {
+		return nil, invalidParams(err)
+	}
+	options, err := params.options(1)
+	if err != nil {
+		return nil, invalidParams(err)
+	}
+	results := make([]map[string]any, 0, len(digests))
+	for _, digest := range digests {
+		if response, ok := s.transactions[digest]; ok {
+			results = append(results, responseView(response, options))
+		}
+	}
+	return results, nil
+}
+
+// getCoinMetadata serves suix_getCoinMetadata, null when coin type has no metadata
+//
+// getCoinMetadata 提供 suix_getCoinMetadata，代币类型没有元数据时为 null
+func (s *Server) getCoinMetadata(params params) (any, *suirpc.RpcError) {
+	coinType, err := params.string(0)
+	if err != nil {
+		return nil, invalidParams(err)
+	}
+	if metadata, ok := s.metadata[coinType]; ok {
+		return metadata, nil
+	}
+	return nil, nil
+}
+
+// getTotalSupply serves suix_getTotalSupply as sum of coins of the type in the ledger
+//
+// getTotalSupply 以账本中该类型代币的总和提供 suix_getTotalSupply
+func (s *Server) getTotalSupply(params params) (any, *suirpc.RpcError) {
+	coinType, err := params.string(0)
+	if err != nil {
+		return nil, invalidParams(err)
+	}
+	var total uint64
+	for _, coin := range s.ledger.coins {
+		if coin.CoinType == coinType {
+			total += coin.Balance
+		}
+	}
+	return map[string]any{"value": formatUint(total)}, nil
+}
@@ -0,0 +1,277 @@
+package suirpctest
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/go-xlan/sui-go-guide/suirpc"
+)
+
+// dynamicField represents dynamic field hung under parent object
+// DynamicField kind keeps value inside 0x2::dynamic_field::Field<K, V> object
+// DynamicObject kind points at standalone value object
+//
+// dynamicField 表示挂在父对象下的动态字段
+// DynamicField 类型将值保存在 0x2::dynamic_field::Field<K, V> 对象中
+// DynamicObject 类型指向独立的值对象
+type dynamicField struct {
+	parentId   string          // Parent object ID // 父对象 ID
+	kind       string          // DynamicField or DynamicObject // DynamicField 或 DynamicObject
+	nameType   string          // Move type of the name // 名称的 Move 类型
+	name       json.RawMessage // Name value in JSON // JSON 形式的名称值
+	objectType string          // Move type of field value // 字段值的 Move 类型
+	value      json.RawMessage // Field value, or fields of value object // 字段值，或值对象的字段
+	objectId   string          // Field object ID, or value object ID // 字段对象 ID，或值对象 ID
+	ownerId    string          // Owner of the object above // 上述对象的所有者
+	digest     string          // Object digest // 对象摘要
+}
+
+// AddDynamicField hangs dynamic field under parent object, like table::add or bag::add
+// Name and value are given in fullnode JSON form, such as "42" of u64 or {"type":..,"fields":..} of struct
+// Returns object ID of the 0x2::dynamic_field::Field holding the value
+//
+// AddDynamicField 在父对象下挂载动态字段，类似 table::add 或 bag::add
+// 名称和值以全节点 JSON 形式给出，例如 u64 的 "42" 或结构体的 {"type":..,"fields":..}
+// 返回保存该值的 0x2::dynamic_field::Field 的对象 ID
+func (s *Server) AddDynamicField(parentId string, nameType string, name any, valueType string, value any) string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	objectId := s.ledger.newObjectId()
+	s.addDynamicField(&dynamicField{
+		parentId:   parentId,
+		kind:       "DynamicField",
+		nameType:   nameType,
+		name:       mustJSON(name),
+		objectType: valueType,
+		value:      mustJSON(value),
+		objectId:   objectId,
+		ownerId:    parentId,
+		digest:     s.ledger.newDigest(),
+	})
+	return objectId
+}
+
+// AddDynamicObjectField hangs object under parent object, like object_table::add or object_bag::add
+// Fields are given in fullnode JSON form, without the id field which the simulator fills in
+// Returns object ID of the value object
+//
+// AddDynamicObjectField 在父对象下挂载对象，类似 object_table::add 或 object_bag::add
+// 字段以全节点 JSON 形式给出，不含由模拟器填充的 id 字段
+// 返回值对象的对象 ID
+func (s *Server) AddDynamicObjectField(parentId string, nameType string, name any, objectType string, fields map[string]any) string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	objectId := s.ledger.newObjectId()
+	value := map[string]any{"id": map[string]any{"id": objectId}}
+	for key, item := range fields {
+		value[key] = item
+	}
+	s.addDynamicField(&dynamicField{
+		parentId:   parentId,
+		kind:       "DynamicObject",
+		nameType:   nameType,
+		name:       mustJSON(name),
+		objectType: objectType,
+		value:      mustJSON(value),
+		objectId:   objectId,
+		ownerId:    s.ledger.newObjectId(),
+		digest:     s.ledger.newDigest(),
+	})
+	return objectId
+}
+
+// addDynamicField stores field ordered by object ID, caller holds the mutex
+//
+// addDynamicField 按对象 ID 顺序保存字段，调用方需持有互斥锁
+func (s *Server) addDynamicField(field *dynamicField) {
+	fields := append(s.dynamicFields[field.parentId], field)
+	slices.SortFunc(fields, func(a, b *dynamicField) int {
+		return compareString(a.objectId, b.objectId)
+	})
+	s.dynamicFields[field.parentId] = fields
+	s.fieldObjects[field.objectId] = field
+}
+
+// getDynamicFields serves suix_getDynamicFields, paged by object ID
+//
+// getDynamicFields 提供 suix_getDynamicFields，按对象 ID 分页
+func (s *Server) getDynamicFields(params params) (any, *suirpc.RpcError) {
+	parentId, err := params.string(0)
+	if err != nil {
+		return nil, invalidParams(err)
+	}
+	cursor, err := params.optionalString(1)
+	if err != nil {
+		return nil, invalidParams(err)
+	}
+	limit, err := params.optionalUint(2)
+	if err != nil {
+		return nil, invalidParams(err)
+	}
+	if limit == 0 {
+		limit = defaultPageLimit
+	}
+
+	fields := s.dynamicFields[parentId]
+	if cursor != "" {
+		first := slices.IndexFunc(fields, func(field *dynamicField) bool { return field.objectId > cursor })
+		if first < 0 {
+			first = len(fields)
+		}
+		fields = fields[first:]
+	}
+	hasNextPage := uint64(len(fields)) > limit
+	if hasNextPage {
+		fields = fields[:limit]
+	}
+	data := make([]map[string]any, 0, len(fields))
+	for _, field := range fields {
+		data = append(data, map[string]any{
+			"name":       field.nameJSON(),
+			"bcsName":    encodeBase58(field.name),
+			"type":       field.kind,
+			"objectType": field.objectType,
+			"objectId":   field.objectId,
+			"version":    1,
+			"digest":     field.digest,
+		})
+	}
+	var nextCursor any
+	if len(fields) > 0 {
+		nextCursor = fields[len(fields)-1].objectId
+	}
+	return map[string]any{"data": data, "hasNextPage": hasNextPage, "nextCursor": nextCursor}, nil
+}
+
+// getDynamicFieldObject serves suix_getDynamicFieldObject, matching name type and JSON value
+//
+// getDynamicFieldObject 提供 suix_getDynamicFieldObject，按名称类型和 JSON 值匹配
+func (s *Server) getDynamicFieldObject(params params) (any, *suirpc.RpcError) {
+	parentId, err := params.string(0)
+	if err != nil {
+		return nil, invalidParams(err)
+	}
+	if !params.present(1) {
+		return nil, invalidParams(fmt.Errorf("missing param 1"))
+	}
+	var name struct {
+		Type  string          `json:"type"`
+		Value json.RawMessage `json:"value"`
+	}
+	if err := json.Unmarshal(params[1], &name); err != nil {
+		return nil, invalidParams(fmt.Errorf("param 1: %w", err))
+	}
+	for _, field := range s.dynamicFields[parentId] {
+		if field.matches(name.Type, name.Value) {
+			return map[string]any{"data": field.objectJSON(fullObjectOptions)}, nil
+		}
+	}
+	return map[string]any{"error": map[string]any{"code": "dynamicFieldNotFound", "parent_object_id": parentId}}, nil
+}
+
+// fullObjectOptions shows each object section, suix_getDynamicFieldObject answers with each of them
+//
+// fullObjectOptions 展示对象的每个部分，suix_getDynamicFieldObject 会返回全部部分
+var fullObjectOptions = map[string]bool{
+	"showType":                true,
+	"showOwner":               true,
+	"showPreviousTransaction": true,
+	"showStorageRebate":       true,
+	"showContent":             true,
+}
+
+// matches checks if field name equals given name, addresses compare regardless of padding and case
+//
+// matches 检查字段名是否等于给定名称，地址比较时忽略补零和大小写差异
+func (field *dynamicField) matches(nameType string, name json.RawMessage) bool {
+	if normalizeAddresses(nameType) != normalizeAddresses(field.nameType) {
+		return false
+	}
+	var want, have any
+	if json.Unmarshal([]byte(normalizeAddresses(string(name))), &want) != nil {
+		return false
+	}
+	if json.Unmarshal([]byte(normalizeAddresses(string(field.name))), &have) != nil {
+		return false
+	}
+	return reflect.DeepEqual(want, have)
+}
+
+// nameJSON returns field name in fullnode form
+//
+// nameJSON 返回全节点形式的字段名
+func (field *dynamicField) nameJSON() map[string]any {
+	return map[string]any{"type": field.nameType, "value": field.name}
+}
+
+// objectJSON returns field object, or value object of DynamicObject kind, with sections asked for in options
+//
+// objectJSON 返回字段对象或 DynamicObject 类型的值对象，并带有选项所请求的部分
+func (field *dynamicField) objectJSON(options map[string]bool) map[string]any {
+	data := map[string]any{
+		"objectId": field.objectId,
+		"version":  "1",
+		"digest":   field.digest,
+	}
+	objectType := field.objectType
+	fields := field.value
+	if field.kind == "DynamicField" {
+		objectType = "0x2::dynamic_field::Field<" + field.nameType + ", " + field.objectType + ">"
+		fields = mustJSON(map[string]any{
+			"id":    map[string]any{"id": field.objectId},
+			"name":  field.name,
+			"value": field.value,
+		})
+	}
+	if options["showType"] {
+		data["type"] = objectType
+	}
+	if options["showOwner"] {
+		data["owner"] = map[string]any{"ObjectOwner": field.ownerId}
+	}
+	if options["showPreviousTransaction"] {
+		data["previousTransaction"] = field.digest
+	}
+	if options["showStorageRebate"] {
+		data["storageRebate"] = "988000"
+	}
+	if options["showContent"] {
+		data["content"] = map[string]any{
+			"dataType":          "moveObject",
+			"type":              objectType,
+			"hasPublicTransfer": field.kind == "DynamicObject",
+			"fields":            fields,
+		}
+	}
+	return data
+}
+
+// addressPattern matches hex addresses inside type tags and JSON text
+//
+// addressPattern 匹配类型标签和 JSON 文本中的十六进制地址
+var addressPattern = regexp.MustCompile(`0x[0-9a-fA-F]{1,64}`)
+
+// normalizeAddresses pads each hex address to 32 bytes in lowercase
+//
+// normalizeAddresses 将每个十六进制地址补齐到 32 字节并转为小写
+func normalizeAddresses(text string) string {
+	return addressPattern.ReplaceAllStringFunc(text, func(address string) string {
+		digits := strings.ToLower(address[2:])
+		return "0x" + strings.Repeat("0", 64-len(digits)) + digits
+	})
+}
+
+// mustJSON encodes value given by test code, panics on values JSON cannot hold
+//
+// mustJSON 编码测试代码给出的值，遇到 JSON 无法表示的值时 panic
+func mustJSON(value any) json.RawMessage {
+	data, err := json.Marshal(value)
+	if err != nil {
+		panic(fmt.Errorf("encode %T: %w", value, err))
+	}
+	return data
+}
@@ -0,0 +1,232 @@
+package suirpctest
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/go-xlan/sui-go-guide/suirpc"
+)
+
+// Event represents Move event emitted through EmitEvents
+//
+// Event 表示通过 EmitEvents 发出的 Move 事件
+type Event struct {
+	Type       string // Move event type such as 0xabc::math::Added // Move 事件类型，例如 0xabc::math::Added
+	ParsedJson any    // Event fields in fullnode JSON form // 全节点 JSON 形式的事件字段
+}
+
+// EmitEvents records transaction of sender calling Move function that emits given events
+// Package and transaction module come from the first event type, the function name stays unknown
+// The transaction lands in its own checkpoint and returns its digest
+//
+// EmitEvents 记录发送方调用发出给定事件的 Move 函数的交易
+// 包和交易模块取自第一个事件类型，函数名保持未知
+// 该交易位于独立的检查点中，返回其摘要
+func (s *Server) EmitEvents(sender string, events ...Event) string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	digest := s.ledger.newDigest()
+	var packageId, module string
+	if len(events) > 0 {
+		packageId, module, _ = strings.Cut(events[0].Type, "::")
+		module, _, _ = strings.Cut(module, "::")
+	}
+	items := make([]any, 0, len(events))
+	for idx, event := range events {
+		items = append(items, map[string]any{
+			"id":                map[string]any{"txDigest": digest, "eventSeq": strconv.Itoa(idx)},
+			"packageId":         normalizeAddresses(packageId),
+			"transactionModule": module,
+			"sender":            sender,
+			"type":              event.Type,
+			"parsedJson":        mustJSON(event.ParsedJson),
+		})
+	}
+	response := map[string]any{
+		"digest": digest,
+		"effects": map[string]any{
+			"messageVersion":    "v1",
+			"status":            map[string]any{"status": "success"},
+			"executedEpoch":     "0",
+			"gasUsed":           map[string]any{"computationCost": "0", "storageCost": "0", "storageRebate": "0", "nonRefundableStorageFee": "0"},
+			"transactionDigest": digest,
+			"created":           []any{},
+			"mutated":           []any{},
+			"deleted":           []any{},
+		},
+		"events":         items,
+		"objectChanges":  []any{},
+		"balanceChanges": []any{},
+	}
+	s.transactions[digest] = response
+	s.indexes[digest] = &txIndex{sender: sender, moveFunction: [3]string{packageId, module, ""}, kind: "ProgrammableTransaction"}
+	s.appendCheckpoint(response, 0)
+	for _, item := range items {
+		item.(map[string]any)["timestampMs"] = response["timestampMs"]
+	}
+	return digest
+}
+
+// queryEvents serves suix_queryEvents with cursor pagination in both orders
+// Params: filter, cursor, limit, descending
+//
+// queryEvents 提供按两种顺序游标分页的 suix_queryEvents
+// 参数：过滤条件、游标、数量上限、是否降序
+func (s *Server) queryEvents(params params) (any, *suirpc.RpcError) {
+	if !params.present(0) {
+		return nil, invalidParams(fmt.Errorf("missing param 0"))
+	}
+	var cursor *eventId
+	if params.present(1) {
+		if err := json.Unmarshal(params[1], &cursor); err != nil {
+			return nil, invalidParams(fmt.Errorf("param 1: %w", err))
+		}
+	}
+	limit, err := params.optionalUint(2)
+	if err != nil {
+		return nil, invalidParams(err)
+	}
+	if limit == 0 {
+		limit = defaultPageLimit
+	}
+	descending := params.present(3) && strings.TrimSpace(string(params[3])) == "true"
+
+	var events []map[string]any
+	for _, checkpoint := range s.checkpoints {
+		for _, digest := range checkpoint["transactions"].([]string) {
+			items, _ := s.transactions[digest]["events"].([]any)
+			for _, item := range items {
+				event := item.(map[string]any)
+				matched, err := matchEvent(params[0], event)
+				if err != nil {
+					return nil, invalidParams(fmt.Errorf("param 0: %w", err))
+				}
+				if matched {
+					events = append(events, event)
+				}
+			}
+		}
+	}
+	if descending {
+		slices.Reverse(events)
+	}
+	if cursor != nil {
+		first := slices.IndexFunc(events, func(event map[string]any) bool {
+			id := event["id"].(map[string]any)
+			return id["txDigest"] == cursor.TxDigest && id["eventSeq"] == cursor.EventSeq
+		})
+		events = events[first+1:]
+	}
+	hasNextPage := uint64(len(events)) > limit
+	if hasNextPage {
+		events = events[:limit]
+	}
+	var nextCursor any
+	if len(events) > 0 {
+		nextCursor = events[len(events)-1]["id"]
+	}
+	return map[string]any{"data": append([]map[string]any{}, events...), "hasNextPage": hasNextPage, "nextCursor": nextCursor}, nil
+}
+
+// eventId represents event cursor
+//
+// eventId 表示事件游标
+type eventId struct {
+	TxDigest string `json:"txDigest"` // Transaction digest // 交易摘要
+	EventSeq string `json:"eventSeq"` // Event index in transaction // 事件在交易中的序号
+}
+
+// matchEvent checks if event matches filter in fullnode JSON form
+//
+// matchEvent 检查事件是否匹配全节点 JSON 形式的过滤条件
+func matchEvent(filter json.RawMessage, event map[string]any) (bool, error) {
+	var variants map[string]json.RawMessage
+	if err := json.Unmarshal(filter, &variants); err != nil {
+		return false, err
+	}
+	if len(variants) != 1 {
+		return false, fmt.Errorf("filter needs exactly one variant, got %d", len(variants))
+	}
+	for name, value := range variants {
+		switch name {
+		case "Sender", "Transaction", "Package", "MoveEventType":
+			var text string
+			if err := json.Unmarshal(value, &text); err != nil {
+				return false, fmt.Errorf("%s: %w", name, err)
+			}
+			var have string
+			switch name {
+			case "Sender":
+				have = event["sender"].(string)
+			case "Transaction":
+				have = event["id"].(map[string]any)["txDigest"].(string)
+			case "Package":
+				have = event["packageId"].(string)
+			default:
+				have = event["type"].(string)
+			}
+			return normalizeAddresses(have) == normalizeAddresses(text), nil
+		case "MoveModule", "MoveEventModule":
+			var module struct {
+				Package string `json:"package"`
+				Module  string `json:"module"`
+			}
+			if err := json.Unmarshal(value, &module); err != nil {
+				return false, fmt.Errorf("%s: %w", name, err)
+			}
+			packageId, moduleName := event["packageId"].(string), event["transactionModule"].(string)
+			if name == "MoveEventModule" {
+				parts := strings.SplitN(event["type"].(string), "::", 3)
+				packageId, moduleName = parts[0], parts[1]
+			}
+			return normalizeAddresses(packageId) == normalizeAddresses(module.Package) && moduleName == module.Module, nil
+		case "TimeRange":
+			var timeRange struct {
+				StartTime json.RawMessage `json:"startTime"`
+				EndTime   json.RawMessage `json:"endTime"`
+			}
+			if err := json.Unmarshal(value, &timeRange); err != nil {
+				return false, fmt.Errorf("%s: %w", name, err)
+			}
+			startTime, err := parseUint(timeRange.StartTime)
+			if err != nil {
+				return false, fmt.Errorf("%s: %w", name, err)
+			}
+			endTime, err := parseUint(timeRange.EndTime)
+			if err != nil {
+				return false, fmt.Errorf("%s: %w", name, err)
+			}
+			timestampMs, _ := strconv.ParseUint(event["timestampMs"].(string), 10, 64)
+			return startTime <= timestampMs && timestampMs < endTime, nil
+		case "All", "Any", "And", "Or":
+			var filters []json.RawMessage
+			if err := json.Unmarshal(value, &filters); err != nil {
+				return false, fmt.Errorf("%s: %w", name, err)
+			}
+			if (name == "And" || name == "Or") && len(filters) != 2 {
+				return false, fmt.Errorf("%s takes two filters, got %d", name, len(filters))
+			}
+			every := name == "All" || name == "And"
+			for _, item := range filters {
+				matched, err := matchEvent(item, event)
+				if err != nil {
+					return false, err
+				}
+				if matched != every {
+					return matched, nil
+				}
+			}
+			return every, nil
+		case "Not":
+			matched, err := matchEvent(value, event)
+			return !matched, err
+		default:
+			return false, fmt.Errorf("unknown event filter %s", name)
+		}
+	}
+	return false, nil
+}
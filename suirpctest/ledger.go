@@ -0,0 +1,373 @@
+package suirpctest
+
+import (
+	"encoding/binary"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+// SuiCoinType is the coin type of native SUI coins
+//
+// SuiCoinType 是原生 SUI 代币的代币类型
+const SuiCoinType = "0x2::sui::SUI"
+
+// Coin represents coin object kept in the simulated ledger
+//
+// Coin 表示模拟账本中保存的代币对象
+type Coin struct {
+	CoinObjectId        string // Object ID // 对象 ID
+	CoinType            string // Coin type // 代币类型
+	Owner               string // Owner address // 所有者地址
+	Balance             uint64 // Balance in smallest unit // 最小单位余额
+	Version             uint64 // Object version // 对象版本
+	Digest              string // Object digest // 对象摘要
+	PreviousTransaction string // Digest of last transaction touching the coin // 最后一次修改该代币的交易摘要
+}
+
+// objectRef represents object reference carried in transaction data
+//
+// objectRef 表示交易数据中携带的对象引用
+type objectRef struct {
+	ObjectId string `json:"objectId"` // Object ID // 对象 ID
+	Version  uint64 `json:"version"`  // Object version at build time // 构建时的对象版本
+	Digest   string `json:"digest"`   // Object digest at build time // 构建时的对象摘要
+}
+
+// txData represents transaction built by unsafe_* methods
+// Encoded as JSON inside txBytes, signed like real BCS transaction bytes
+//
+// txData 表示 unsafe_* 方法构建的交易
+// 以 JSON 形式编码在 txBytes 中，签名方式与真实 BCS 交易字节相同
+type txData struct {
+	Kind       string      `json:"kind"`                 // Transaction kind // 交易类型
+	Sender     string      `json:"sender"`               // Sender address // 发送方地址
+	Inputs     []objectRef `json:"inputs"`               // Input objects with versions // 带版本的输入对象
+	GasCoin    string      `json:"gasCoin"`              // Gas coin object ID // Gas 代币对象 ID
+	GasBudget  uint64      `json:"gasBudget"`            // Gas budget // Gas 预算
+	Coins      []string    `json:"coins,omitempty"`      // Coins operated by the transaction // 交易操作的代币
+	Recipients []string    `json:"recipients,omitempty"` // Recipient addresses // 接收方地址
+	Amounts    []uint64    `json:"amounts,omitempty"`    // Amounts to pay or split // 支付或拆分的金额
+	Nonce      uint64      `json:"nonce"`                // Keeps equal transactions apart // 区分相同交易
+}
+
+// outcome represents result of running transaction against the ledger
+//
+// outcome 表示针对账本运行交易的结果
+type outcome struct {
+	failure string           // Failure text, empty on success // 失败描述，成功时为空
+	before  map[string]*Coin // Coins touched, before the run // 运行前涉及的代币
+	after   map[string]*Coin // Coins touched, after the run, nil when deleted // 运行后涉及的代币，删除时为 nil
+}
+
+// ledger represents coin objects by object ID, caller holds server mutex
+//
+// ledger 表示按对象 ID 索引的代币对象，调用方需持有服务器互斥锁
+type ledger struct {
+	coins    map[string]*Coin     // Live coins // 存活的代币
+	history  map[string][]Coin    // Each version of each coin, oldest first // 每个代币的每个版本，最旧的在前
+	deleted  map[string]objectRef // Deleted coins with version of deletion // 已删除的代币及删除时的版本
+	sequence uint64               // Object ID and digest counter // 对象 ID 和摘要计数器
+}
+
+// deletedDigest is the digest fullnodes report of deleted objects
+//
+// deletedDigest 是全节点为已删除对象报告的摘要
+const deletedDigest = "7gyGAp71YXQRoxmFBaHxofQXAipvgHyBKPyxmdSJxyvz"
+
+// newLedger creates empty ledger
+//
+// newLedger 创建空账本
+func newLedger() *ledger {
+	return &ledger{coins: map[string]*Coin{}, history: map[string][]Coin{}, deleted: map[string]objectRef{}}
+}
+
+// store puts coin version into live coins and history
+//
+// store 将代币版本放入存活代币和历史中
+func (l *ledger) store(coin *Coin) {
+	l.coins[coin.CoinObjectId] = coin
+	l.history[coin.CoinObjectId] = append(l.history[coin.CoinObjectId], *coin)
+}
+
+// newObjectId returns next deterministic object ID
+//
+// newObjectId 返回下一个确定性的对象 ID
+func (l *ledger) newObjectId() string {
+	return fmt.Sprintf("0x%x", l.hash("object"))
+}
+
+// newDigest returns next deterministic base58 digest
+//
+// newDigest 返回下一个确定性的 base58 摘要
+func (l *ledger) newDigest() string {
+	hash := l.hash("digest")
+	return encodeBase58(hash[:])
+}
+
+// hash returns blake2b hash of label and next sequence number
+//
+// hash 返回标签与下一个序号的 blake2b 哈希
+func (l *ledger) hash(label string) [32]byte {
+	l.sequence++
+	return blake2b.Sum256(binary.BigEndian.AppendUint64([]byte(label), l.sequence))
+}
+
+// ownedCoins returns coins of owner with given type ordered by object ID
+//
+// ownedCoins 返回所有者给定类型的代币，按对象 ID 排序
+func (l *ledger) ownedCoins(owner string, coinType string) []*Coin {
+	var coins []*Coin
+	for _, coin := range l.coins {
+		if coin.Owner == owner && (coinType == "" || coin.CoinType == coinType) {
+			coins = append(coins, coin)
+		}
+	}
+	slices.SortFunc(coins, func(a, b *Coin) int {
+		return compareString(a.CoinObjectId, b.CoinObjectId)
+	})
+	return coins
+}
+
+// pickGasCoin returns richest SUI coin of owner outside excluded coins
+//
+// pickGasCoin 返回所有者在排除代币之外余额最多的 SUI 代币
+func (l *ledger) pickGasCoin(owner string, excluded []string) *Coin {
+	var gasCoin *Coin
+	for _, coin := range l.ownedCoins(owner, SuiCoinType) {
+		if slices.Contains(excluded, coin.CoinObjectId) {
+			continue
+		}
+		if gasCoin == nil || coin.Balance > gasCoin.Balance {
+			gasCoin = coin
+		}
+	}
+	return gasCoin
+}
+
+// run executes transaction on copies of touched coins and returns outcome
+// Ledger stays unchanged, commit applies the outcome
+// Charges gas first, on failure only gas charge stays
+//
+// run 在涉及代币的副本上执行交易并返回结果
+// 账本保持不变，由 commit 应用结果
+// 先扣除 gas，失败时仅保留 gas 扣费
+func (l *ledger) run(tx *txData, gasCost uint64) (*outcome, error) {
+	// Check inputs are live, owned by sender and unchanged since build
+	// 检查输入对象存活、归发送方所有且自构建后未变化
+	before := map[string]*Coin{}
+	for _, input := range tx.Inputs {
+		coin, ok := l.coins[input.ObjectId]
+		if !ok {
+			return nil, fmt.Errorf("object %s not found", input.ObjectId)
+		}
+		if coin.Version != input.Version {
+			return nil, fmt.Errorf("object %s version %d is unavailable, current version %d", input.ObjectId, input.Version, coin.Version)
+		}
+		if coin.Owner != tx.Sender {
+			return nil, fmt.Errorf("object %s is not owned by %s", input.ObjectId, tx.Sender)
+		}
+		clone := *coin
+		before[coin.CoinObjectId] = &clone
+	}
+	if tx.GasBudget < gasCost {
+		return nil, fmt.Errorf("gas budget %d is below gas cost %d", tx.GasBudget, gasCost)
+	}
+
+	charged := cloneCoins(before)
+	gasCoin := charged[tx.GasCoin]
+	if gasCoin == nil || gasCoin.CoinType != SuiCoinType {
+		return nil, fmt.Errorf("gas coin %s is not SUI coin of the transaction", tx.GasCoin)
+	}
+	if gasCoin.Balance < gasCost {
+		return nil, fmt.Errorf("balance of gas object %s is %d, lower than gas cost %d", tx.GasCoin, gasCoin.Balance, gasCost)
+	}
+	gasCoin.Balance -= gasCost
+
+	after := cloneCoins(charged)
+	if failure := l.apply(tx, after); failure != "" {
+		return &outcome{failure: failure + " in command 0", before: before, after: charged}, nil
+	}
+	return &outcome{before: before, after: after}, nil
+}
+
+// apply runs transaction kind on coins, returns failure text or empty on success
+//
+// apply 在代币上运行交易类型，返回失败描述，成功时为空
+func (l *ledger) apply(tx *txData, coins map[string]*Coin) string {
+	total := func(amounts []uint64) uint64 {
+		var sum uint64
+		for _, amount := range amounts {
+			sum += amount
+		}
+		return sum
+	}
+	mergeInto := func(primary *Coin, others []string) {
+		for _, objectId := range others {
+			primary.Balance += coins[objectId].Balance
+			coins[objectId] = nil
+		}
+	}
+	split := func(source *Coin, owners []string, amounts []uint64) string {
+		if source.Balance < total(amounts) {
+			return "InsufficientCoinBalance"
+		}
+		for idx, amount := range amounts {
+			source.Balance -= amount
+			objectId := l.newObjectId()
+			coins[objectId] = &Coin{CoinObjectId: objectId, CoinType: source.CoinType, Owner: owners[idx], Balance: amount}
+		}
+		return ""
+	}
+
+	switch tx.Kind {
+	case "pay":
+		primary := coins[tx.Coins[0]]
+		for _, objectId := range tx.Coins[1:] {
+			if coins[objectId].CoinType != primary.CoinType {
+				return "TypeMismatch"
+			}
+		}
+		mergeInto(primary, tx.Coins[1:])
+		return split(primary, tx.Recipients, tx.Amounts)
+	case "paySui":
+		primary := coins[tx.Coins[0]]
+		mergeInto(primary, tx.Coins[1:])
+		return split(primary, tx.Recipients, tx.Amounts)
+	case "payAllSui":
+		primary := coins[tx.Coins[0]]
+		mergeInto(primary, tx.Coins[1:])
+		primary.Owner = tx.Recipients[0]
+		return ""
+	case "transferSui":
+		coin := coins[tx.Coins[0]]
+		if len(tx.Amounts) == 0 {
+			coin.Owner = tx.Recipients[0]
+			return ""
+		}
+		return split(coin, tx.Recipients, tx.Amounts)
+	case "splitCoin":
+		owners := make([]string, len(tx.Amounts))
+		for idx := range owners {
+			owners[idx] = tx.Sender
+		}
+		return split(coins[tx.Coins[0]], owners, tx.Amounts)
+	case "mergeCoins":
+		primary, other := coins[tx.Coins[0]], coins[tx.Coins[1]]
+		if primary.CoinType != other.CoinType {
+			return "TypeMismatch"
+		}
+		mergeInto(primary, tx.Coins[1:])
+		return ""
+	case "transferObject":
+		coins[tx.Coins[0]].Owner = tx.Recipients[0]
+		return ""
+	default:
+		return "UnsupportedTransaction"
+	}
+}
+
+// commit writes outcome into the ledger with new versions and digests
+// Every touched object gets lamport version above all input versions
+//
+// commit 将结果写入账本并分配新版本和摘要
+// 每个涉及的对象获得高于所有输入版本的 lamport 版本
+func (l *ledger) commit(result *outcome, txDigest string) {
+	var version uint64
+	for _, coin := range result.before {
+		version = max(version, coin.Version)
+	}
+	version++
+
+	objectIds := make([]string, 0, len(result.after))
+	for objectId := range result.after {
+		objectIds = append(objectIds, objectId)
+	}
+	slices.Sort(objectIds)
+	for _, objectId := range objectIds {
+		coin := result.after[objectId]
+		if coin == nil {
+			delete(l.coins, objectId)
+			l.deleted[objectId] = objectRef{ObjectId: objectId, Version: version, Digest: deletedDigest}
+			continue
+		}
+		coin.Version = version
+		coin.Digest = l.newDigest()
+		coin.PreviousTransaction = txDigest
+		l.store(coin)
+	}
+}
+
+// cloneCoins returns deep copy of coin map
+//
+// cloneCoins 返回代币映射的深拷贝
+func cloneCoins(coins map[string]*Coin) map[string]*Coin {
+	results := make(map[string]*Coin, len(coins))
+	for objectId, coin := range coins {
+		if coin == nil {
+			results[objectId] = nil
+			continue
+		}
+		clone := *coin
+		results[objectId] = &clone
+	}
+	return results
+}
+
+// compareString compares strings for sorting
+//
+// compareString 比较字符串以便排序
+func compareString(a, b string) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// formatUint formats amount as decimal string like fullnode JSON
+//
+// formatUint 将金额格式化为与全节点 JSON 一致的十进制字符串
+func formatUint(value uint64) string {
+	return strconv.FormatUint(value, 10)
+}
+
+// base58Alphabet is the Bitcoin alphabet used by Sui digests
+//
+// base58Alphabet 是 Sui 摘要使用的比特币字母表
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// encodeBase58 encodes bytes with base58 alphabet
+//
+// encodeBase58 使用 base58 字母表编码字节
+func encodeBase58(data []byte) string {
+	digits := []byte{0}
+	for _, value := range data {
+		carry := int(value)
+		for idx := range digits {
+			carry += int(digits[idx]) << 8
+			digits[idx] = byte(carry % 58)
+			carry /= 58
+		}
+		for carry > 0 {
+			digits = append(digits, byte(carry%58))
+			carry /= 58
+		}
+	}
+	var result []byte
+	for _, value := range data {
+		if value != 0 {
+			break
+		}
+		result = append(result, base58Alphabet[0])
+	}
+	for idx := len(digits) - 1; idx >= 0; idx-- {
+		result = append(result, base58Alphabet[digits[idx]])
+	}
+	return string(result)
+}
@@ -0,0 +1,232 @@
+package suirpctest
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/go-xlan/sui-go-guide/suirpc"
+)
+
+// getObject serves sui_getObject, error entry when object is deleted or unknown
+//
+// getObject 提供 sui_getObject，对象已删除或未知时返回错误条目
+func (s *Server) getObject(params params) (any, *suirpc.RpcError) {
+	objectId, err := params.string(0)
+	if err != nil {
+		return nil, invalidParams(err)
+	}
+	options, err := params.options(1)
+	if err != nil {
+		return nil, invalidParams(err)
+	}
+	return s.objectResponse(objectId, options), nil
+}
+
+// multiGetObjects serves sui_multiGetObjects, one entry per object ID in given order
+//
+// multiGetObjects 提供 sui_multiGetObjects，按给定顺序每个对象 ID 一个条目
+func (s *Server) multiGetObjects(params params) (any, *suirpc.RpcError) {
+	objectIds, err := params.strings(0)
+	if err != nil {
+		return nil, invalidParams(err)
+	}
+	options, err := params.options(1)
+	if err != nil {
+		return nil, invalidParams(err)
+	}
+	results := make([]map[string]any, 0, len(objectIds))
+	for _, objectId := range objectIds {
+		results = append(results, s.objectResponse(objectId, options))
+	}
+	return results, nil
+}
+
+// tryGetPastObject serves sui_tryGetPastObject from coin history
+//
+// tryGetPastObject 从代币历史中提供 sui_tryGetPastObject
+func (s *Server) tryGetPastObject(params params) (any, *suirpc.RpcError) {
+	objectId, err := params.string(0)
+	if err != nil {
+		return nil, invalidParams(err)
+	}
+	version, err := params.uint(1)
+	if err != nil {
+		return nil, invalidParams(err)
+	}
+	options, err := params.options(2)
+	if err != nil {
+		return nil, invalidParams(err)
+	}
+	return s.pastObjectResponse(objectId, version, options), nil
+}
+
+// tryMultiGetPastObjects serves sui_tryMultiGetPastObjects, versions come as decimal strings
+//
+// tryMultiGetPastObjects 提供 sui_tryMultiGetPastObjects，版本以十进制字符串给出
+func (s *Server) tryMultiGetPastObjects(params params) (any, *suirpc.RpcError) {
+	if !params.present(0) {
+		return nil, invalidParams(fmt.Errorf("missing param 0"))
+	}
+	var requests []struct {
+		ObjectId string          `json:"objectId"`
+		Version  json.RawMessage `json:"version"`
+	}
+	if err := json.Unmarshal(params[0], &requests); err != nil {
+		return nil, invalidParams(fmt.Errorf("param 0: %w", err))
+	}
+	options, err := params.options(1)
+	if err != nil {
+		return nil, invalidParams(err)
+	}
+	results := make([]map[string]any, 0, len(requests))
+	for _, request := range requests {
+		version, err := parseUint(request.Version)
+		if err != nil {
+			return nil, invalidParams(fmt.Errorf("param 0: %w", err))
+		}
+		results = append(results, s.pastObjectResponse(request.ObjectId, version, options))
+	}
+	return results, nil
+}
+
+// getOwnedObjects serves suix_getOwnedObjects over coins, filters by StructType or none
+//
+// getOwnedObjects 基于代币提供 suix_getOwnedObjects，支持按 StructType 过滤或不过滤
+func (s *Server) getOwnedObjects(params params) (any, *suirpc.RpcError) {
+	owner, err := params.string(0)
+	if err != nil {
+		return nil, invalidParams(err)
+	}
+	var query struct {
+		Filter  map[string]string `json:"filter"`
+		Options map[string]bool   `json:"options"`
+	}
+	if params.present(1) {
+		if err := json.Unmarshal(params[1], &query); err != nil {
+			return nil, invalidParams(fmt.Errorf("param 1: %w", err))
+		}
+	}
+	cursor, err := params.optionalString(2)
+	if err != nil {
+		return nil, invalidParams(err)
+	}
+	limit, err := params.optionalUint(3)
+	if err != nil {
+		return nil, invalidParams(err)
+	}
+	if limit == 0 {
+		limit = defaultPageLimit
+	}
+
+	structType, filtered := query.Filter["StructType"]
+	if len(query.Filter) > 0 && !filtered {
+		return nil, invalidParams(fmt.Errorf("simulator supports StructType filter only"))
+	}
+	var coins []*Coin
+	for _, coin := range s.ledger.ownedCoins(owner, "") {
+		if !filtered || structType == coinObjectType(coin.CoinType) {
+			coins = append(coins, coin)
+		}
+	}
+	return coinPage(coins, cursor, limit, func(coin *Coin) map[string]any {
+		return map[string]any{"data": objectJSON(coin, query.Options)}
+	}), nil
+}
+
+// objectResponse returns object response of live coin or dynamic field object, or error entry
+//
+// objectResponse 返回存活代币或动态字段对象的对象响应，或错误条目
+func (s *Server) objectResponse(objectId string, options map[string]bool) map[string]any {
+	if coin, ok := s.ledger.coins[objectId]; ok {
+		return map[string]any{"data": objectJSON(coin, options)}
+	}
+	if field, ok := s.fieldObjects[objectId]; ok {
+		return map[string]any{"data": field.objectJSON(options)}
+	}
+	if ref, ok := s.ledger.deleted[objectId]; ok {
+		return map[string]any{"error": map[string]any{"code": "deleted", "object_id": objectId, "version": formatUint(ref.Version), "digest": ref.Digest}}
+	}
+	return map[string]any{"error": map[string]any{"code": "notExists", "object_id": objectId}}
+}
+
+// pastObjectResponse returns past object response of coin at given version
+//
+// pastObjectResponse 返回代币在给定版本的历史对象响应
+func (s *Server) pastObjectResponse(objectId string, version uint64, options map[string]bool) map[string]any {
+	history, ok := s.ledger.history[objectId]
+	if !ok {
+		return map[string]any{"status": "ObjectNotExists", "details": objectId}
+	}
+	if ref, ok := s.ledger.deleted[objectId]; ok && ref.Version == version {
+		return map[string]any{"status": "ObjectDeleted", "details": refJSON(ref.ObjectId, ref.Version, ref.Digest)}
+	}
+	for idx := range history {
+		if history[idx].Version == version {
+			return map[string]any{"status": "VersionFound", "details": objectJSON(&history[idx], options)}
+		}
+	}
+	latest := history[len(history)-1].Version
+	if ref, ok := s.ledger.deleted[objectId]; ok {
+		latest = ref.Version
+	}
+	if version > latest {
+		return map[string]any{"status": "VersionTooHigh", "details": map[string]any{"object_id": objectId, "asked_version": version, "latest_version": latest}}
+	}
+	return map[string]any{"status": "VersionNotFound", "details": []any{objectId, version}}
+}
+
+// objectJSON returns coin as object data with sections asked for in options
+//
+// objectJSON 以对象数据形式返回代币，并带有选项所请求的部分
+func objectJSON(coin *Coin, options map[string]bool) map[string]any {
+	data := map[string]any{
+		"objectId": coin.CoinObjectId,
+		"version":  formatUint(coin.Version),
+		"digest":   coin.Digest,
+	}
+	objectType := coinObjectType(coin.CoinType)
+	if options["showType"] {
+		data["type"] = objectType
+	}
+	if options["showOwner"] {
+		data["owner"] = ownerJSON(coin.Owner)
+	}
+	if options["showPreviousTransaction"] {
+		data["previousTransaction"] = coin.PreviousTransaction
+	}
+	if options["showStorageRebate"] {
+		data["storageRebate"] = "988000"
+	}
+	if options["showDisplay"] {
+		data["display"] = map[string]any{"data": nil, "error": nil}
+	}
+	if options["showContent"] {
+		data["content"] = map[string]any{
+			"dataType":          "moveObject",
+			"type":              objectType,
+			"hasPublicTransfer": true,
+			"fields": map[string]any{
+				"balance": formatUint(coin.Balance),
+				"id":      map[string]any{"id": coin.CoinObjectId},
+			},
+		}
+	}
+	if options["showBcs"] {
+		// Coin layout: 32-byte UID followed by u64 balance in little endian
+		// Coin 布局：32 字节 UID 后接小端序 u64 余额
+		idBytes, _ := hex.DecodeString(strings.TrimPrefix(coin.CoinObjectId, "0x"))
+		bcsBytes := binary.LittleEndian.AppendUint64(idBytes, coin.Balance)
+		data["bcs"] = map[string]any{
+			"dataType":          "moveObject",
+			"type":              objectType,
+			"hasPublicTransfer": true,
+			"version":           coin.Version,
+			"bcsBytes":          base64.StdEncoding.EncodeToString(bcsBytes),
+		}
+	}
+	return data
+}
@@ -0,0 +1,128 @@
+package suirpctest
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// params represents positional JSON-RPC params kept raw until read
+//
+// params 表示保持原始形式直到读取的位置 JSON-RPC 参数
+type params []json.RawMessage
+
+// present checks if param at index is given and not null
+//
+// present 检查索引处的参数是否已给出且非 null
+func (p params) present(idx int) bool {
+	return idx < len(p) && string(p[idx]) != "null"
+}
+
+// string reads required string param
+//
+// string 读取必填的字符串参数
+func (p params) string(idx int) (string, error) {
+	if !p.present(idx) {
+		return "", fmt.Errorf("missing param %d", idx)
+	}
+	var value string
+	if err := json.Unmarshal(p[idx], &value); err != nil {
+		return "", fmt.Errorf("param %d: %w", idx, err)
+	}
+	return value, nil
+}
+
+// optionalString reads string param, empty when absent or null
+//
+// optionalString 读取字符串参数，缺失或为 null 时为空
+func (p params) optionalString(idx int) (string, error) {
+	if !p.present(idx) {
+		return "", nil
+	}
+	return p.string(idx)
+}
+
+// strings reads required string array param
+//
+// strings 读取必填的字符串数组参数
+func (p params) strings(idx int) ([]string, error) {
+	if !p.present(idx) {
+		return nil, fmt.Errorf("missing param %d", idx)
+	}
+	var values []string
+	if err := json.Unmarshal(p[idx], &values); err != nil {
+		return nil, fmt.Errorf("param %d: %w", idx, err)
+	}
+	return values, nil
+}
+
+// options reads response options object param, nil when absent or null
+//
+// options 读取响应选项对象参数，缺失或为 null 时为 nil
+func (p params) options(idx int) (map[string]bool, error) {
+	if !p.present(idx) {
+		return nil, nil
+	}
+	values := map[string]bool{}
+	if err := json.Unmarshal(p[idx], &values); err != nil {
+		return nil, fmt.Errorf("param %d: %w", idx, err)
+	}
+	return values, nil
+}
+
+// uint reads required unsigned param given as number or decimal string
+//
+// uint 读取以数字或十进制字符串给出的必填无符号参数
+func (p params) uint(idx int) (uint64, error) {
+	if !p.present(idx) {
+		return 0, fmt.Errorf("missing param %d", idx)
+	}
+	value, err := parseUint(p[idx])
+	if err != nil {
+		return 0, fmt.Errorf("param %d: %w", idx, err)
+	}
+	return value, nil
+}
+
+// optionalUint reads unsigned param, zero when absent or null
+//
+// optionalUint 读取无符号参数，缺失或为 null 时为零
+func (p params) optionalUint(idx int) (uint64, error) {
+	if !p.present(idx) {
+		return 0, nil
+	}
+	return p.uint(idx)
+}
+
+// uints reads required unsigned array param
+//
+// uints 读取必填的无符号数组参数
+func (p params) uints(idx int) ([]uint64, error) {
+	if !p.present(idx) {
+		return nil, fmt.Errorf("missing param %d", idx)
+	}
+	var items []json.RawMessage
+	if err := json.Unmarshal(p[idx], &items); err != nil {
+		return nil, fmt.Errorf("param %d: %w", idx, err)
+	}
+	values := make([]uint64, 0, len(items))
+	for _, item := range items {
+		value, err := parseUint(item)
+		if err != nil {
+			return nil, fmt.Errorf("param %d: %w", idx, err)
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+// parseUint parses JSON number or decimal string as uint64
+//
+// parseUint 将 JSON 数字或十进制字符串解析为 uint64
+func parseUint(data json.RawMessage) (uint64, error) {
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		text = string(data)
+	}
+	return strconv.ParseUint(text, 10, 64)
+}
@@ -0,0 +1,177 @@
+package suirpctest
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/go-xlan/sui-go-guide/suirpc"
+)
+
+// txIndex represents what suix_queryTransactionBlocks filters look at in transaction
+//
+// txIndex 表示 suix_queryTransactionBlocks 过滤条件在交易中查看的内容
+type txIndex struct {
+	sender         string    // Sender address // 发送方地址
+	recipients     []string  // Addresses owning objects created or changed by the transaction // 拥有交易创建或修改的对象的地址
+	inputObjects   []string  // Input object IDs // 输入对象 ID
+	changedObjects []string  // Object IDs created, changed or deleted // 创建、修改或删除的对象 ID
+	moveFunction   [3]string // Package, module and function called, empty when none or unknown // 调用的包、模块和函数，没有或未知时为空
+	kind           string    // Transaction kind // 交易种类
+}
+
+// newTxIndex returns index of transaction run against the ledger
+//
+// newTxIndex 返回针对账本运行的交易的索引
+func newTxIndex(tx *txData, result *outcome) *txIndex {
+	index := &txIndex{sender: tx.Sender, kind: "ProgrammableTransaction"}
+	for _, input := range tx.Inputs {
+		index.inputObjects = append(index.inputObjects, input.ObjectId)
+	}
+	for objectId, coin := range result.after {
+		index.changedObjects = append(index.changedObjects, objectId)
+		if coin != nil && !slices.Contains(index.recipients, coin.Owner) {
+			index.recipients = append(index.recipients, coin.Owner)
+		}
+	}
+	return index
+}
+
+// queryTransactionBlocks serves suix_queryTransactionBlocks with cursor pagination in both orders
+// Params: query with filter and options, cursor, limit, descending
+//
+// queryTransactionBlocks 提供按两种顺序游标分页的 suix_queryTransactionBlocks
+// 参数：带过滤条件和选项的查询、游标、数量上限、是否降序
+func (s *Server) queryTransactionBlocks(params params) (any, *suirpc.RpcError) {
+	var query struct {
+		Filter  json.RawMessage `json:"filter"`
+		Options map[string]bool `json:"options"`
+	}
+	if params.present(0) {
+		if err := json.Unmarshal(params[0], &query); err != nil {
+			return nil, invalidParams(fmt.Errorf("param 0: %w", err))
+		}
+	}
+	cursor, err := params.optionalString(1)
+	if err != nil {
+		return nil, invalidParams(err)
+	}
+	limit, err := params.optionalUint(2)
+	if err != nil {
+		return nil, invalidParams(err)
+	}
+	if limit == 0 {
+		limit = defaultPageLimit
+	}
+	descending := params.present(3) && strings.TrimSpace(string(params[3])) == "true"
+
+	var digests []string
+	for sequence, checkpoint := range s.checkpoints {
+		for _, digest := range checkpoint["transactions"].([]string) {
+			matched := true
+			if len(query.Filter) > 0 && string(query.Filter) != "null" {
+				if matched, err = matchTransaction(query.Filter, s.indexes[digest], uint64(sequence)); err != nil {
+					return nil, invalidParams(fmt.Errorf("param 0: %w", err))
+				}
+			}
+			if matched {
+				digests = append(digests, digest)
+			}
+		}
+	}
+	if descending {
+		slices.Reverse(digests)
+	}
+	if cursor != "" {
+		digests = digests[slices.Index(digests, cursor)+1:]
+	}
+	hasNextPage := uint64(len(digests)) > limit
+	if hasNextPage {
+		digests = digests[:limit]
+	}
+	data := make([]map[string]any, 0, len(digests))
+	for _, digest := range digests {
+		data = append(data, responseView(s.transactions[digest], query.Options))
+	}
+	var nextCursor any
+	if len(digests) > 0 {
+		nextCursor = digests[len(digests)-1]
+	}
+	return map[string]any{"data": data, "hasNextPage": hasNextPage, "nextCursor": nextCursor}, nil
+}
+
+// matchTransaction checks if transaction matches filter in fullnode JSON form
+//
+// matchTransaction 检查交易是否匹配全节点 JSON 形式的过滤条件
+func matchTransaction(filter json.RawMessage, index *txIndex, checkpoint uint64) (bool, error) {
+	var variants map[string]json.RawMessage
+	if err := json.Unmarshal(filter, &variants); err != nil {
+		return false, err
+	}
+	if len(variants) != 1 {
+		return false, fmt.Errorf("filter needs exactly one variant, got %d", len(variants))
+	}
+	for name, value := range variants {
+		switch name {
+		case "Checkpoint":
+			sequence, err := parseUint(value)
+			if err != nil {
+				return false, fmt.Errorf("%s: %w", name, err)
+			}
+			return sequence == checkpoint, nil
+		case "FromAddress", "ToAddress", "InputObject", "ChangedObject", "TransactionKind":
+			var text string
+			if err := json.Unmarshal(value, &text); err != nil {
+				return false, fmt.Errorf("%s: %w", name, err)
+			}
+			text = normalizeAddresses(text)
+			switch name {
+			case "FromAddress":
+				return normalizeAddresses(index.sender) == text, nil
+			case "ToAddress":
+				return containsAddress(index.recipients, text), nil
+			case "InputObject":
+				return containsAddress(index.inputObjects, text), nil
+			case "ChangedObject":
+				return containsAddress(index.changedObjects, text), nil
+			default:
+				return index.kind == text, nil
+			}
+		case "FromAndToAddress":
+			var addresses struct {
+				From string `json:"from"`
+				To   string `json:"to"`
+			}
+			if err := json.Unmarshal(value, &addresses); err != nil {
+				return false, fmt.Errorf("%s: %w", name, err)
+			}
+			return normalizeAddresses(index.sender) == normalizeAddresses(addresses.From) && containsAddress(index.recipients, normalizeAddresses(addresses.To)), nil
+		case "MoveFunction":
+			var function struct {
+				Package  string  `json:"package"`
+				Module   *string `json:"module"`
+				Function *string `json:"function"`
+			}
+			if err := json.Unmarshal(value, &function); err != nil {
+				return false, fmt.Errorf("%s: %w", name, err)
+			}
+			called := index.moveFunction
+			return called[0] != "" && normalizeAddresses(called[0]) == normalizeAddresses(function.Package) &&
+				(function.Module == nil || *function.Module == called[1]) &&
+				(function.Function == nil || *function.Function == called[2]), nil
+		default:
+			return false, fmt.Errorf("unknown transaction filter %s", name)
+		}
+	}
+	return false, nil
+}
+
+// containsAddress checks if addresses hold normalized address
+//
+// containsAddress 检查地址列表是否包含规范化后的地址
+func containsAddress(addresses []string, address string) bool {
+	return slices.ContainsFunc(addresses, func(item string) bool {
+		return normalizeAddresses(item) == address
+	})
+}
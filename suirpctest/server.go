@@ -0,0 +1,1063 @@
+// Package suirpctest: In-memory fullnode simulator to run SUI flows without network
+// Serves JSON-RPC over local HTTP server backed by small ledger of SUI coin objects
+// Builds transactions with unsafe_* methods, dry-runs and executes them with signature checks
+// Lets split, merge and pay flows run end-to-end in tests and CI
+//
+// suirpctest: 无需网络即可运行 SUI 流程的内存全节点模拟器
+// 通过本地 HTTP 服务器提供 JSON-RPC，后端是由 SUI 代币对象组成的小型账本
+// 使用 unsafe_* 方法构建交易，并带签名检查地模拟执行和执行交易
+// 使拆分、合并和支付流程可以在测试和 CI 中端到端运行
+package suirpctest
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/go-xlan/sui-go-guide/suirpc"
+	"golang.org/x/crypto/blake2b"
+)
+
+const (
+	DefaultGasCost         = 1_000_000  // Gas charged per transaction in MIST // 每笔交易收取的 gas，单位 MIST
+	DefaultChainIdentifier = "5u1c0de5" // Chain identifier reported by simulator // 模拟器报告的链标识符
+
+	codeTransactionError = -32002 // Fullnode code of rejected transactions // 全节点拒绝交易的错误码
+	defaultPageLimit     = 50     // Page size when caller gives no limit // 调用方未指定时的分页大小
+)
+
+// Server represents local JSON-RPC server simulating SUI fullnode ledger
+// Tracks addresses, SUI coin objects with versions and digests, and executed transactions
+// Verifies signatures with the same scheme suisigntx.SignTx produces
+//
+// Server 表示模拟 SUI 全节点账本的本地 JSON-RPC 服务器
+// 跟踪地址、带版本和摘要的 SUI 代币对象以及已执行的交易
+// 使用与 suisigntx.SignTx 相同的方案验证签名
+type Server struct {
+	server       *httptest.Server          // Underlying HTTP server // 底层 HTTP 服务器
+	mutex        sync.Mutex                // Guards fields below // 保护以下字段
+	ledger       *ledger                   // Coin objects // 代币对象
+	gasCost      uint64                    // Gas charged per transaction // 每笔交易收取的 gas
+	chainId      string                    // Chain identifier // 链标识符
+	nonce        uint64                    // Transaction build counter // 交易构建计数器
+	transactions map[string]map[string]any // Executed responses by digest // 按摘要索引的已执行响应
+	indexes      map[string]*txIndex       // Query index of executed transactions by digest // 按摘要索引的已执行交易查询索引
+	checkpoints  []map[string]any          // Checkpoints by sequence number, one per transaction // 按序号排列的检查点，每笔交易一个
+	metadata     map[string]map[string]any // Coin metadata by coin type // 按代币类型索引的代币元数据
+
+	dynamicFields map[string][]*dynamicField // Dynamic fields by parent object ID // 按父对象 ID 索引的动态字段
+	fieldObjects  map[string]*dynamicField   // Dynamic fields by object ID // 按对象 ID 索引的动态字段
+}
+
+// NewServer creates and starts simulator on local address
+// Caller closes it with Close when done
+//
+// NewServer 在本地地址上创建并启动模拟器
+// 调用方用完后通过 Close 关闭
+func NewServer() *Server {
+	s := &Server{
+		ledger:       newLedger(),
+		gasCost:      DefaultGasCost,
+		chainId:      DefaultChainIdentifier,
+		transactions: map[string]map[string]any{},
+		indexes:      map[string]*txIndex{},
+		metadata:     map[string]map[string]any{},
+
+		dynamicFields: map[string][]*dynamicField{},
+		fieldObjects:  map[string]*dynamicField{},
+	}
+	s.setCoinMetadata(SuiCoinType, 9, "SUI", "Sui")
+	s.appendCheckpoint(nil, 0)
+	s.server = httptest.NewServer(s)
+	return s
+}
+
+// URL returns server URL to pass as RPC endpoint
+//
+// URL 返回作为 RPC 端点使用的服务器 URL
+func (s *Server) URL() string {
+	return s.server.URL
+}
+
+// Client returns RPC client bound to this server
+//
+// Client 返回绑定到此服务器的 RPC 客户端
+func (s *Server) Client() *suirpc.Client {
+	return suirpc.NewClient(s.server.URL)
+}
+
+// Close shuts down the server
+//
+// Close 关闭服务器
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// SetGasCost sets gas charged per transaction
+//
+// SetGasCost 设置每笔交易收取的 gas
+func (s *Server) SetGasCost(gasCost uint64) *Server {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.gasCost = gasCost
+	return s
+}
+
+// SetChainIdentifier sets chain identifier returned by sui_getChainIdentifier
+//
+// SetChainIdentifier 设置 sui_getChainIdentifier 返回的链标识符
+func (s *Server) SetChainIdentifier(chainId string) *Server {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.chainId = chainId
+	return s
+}
+
+// Mint creates SUI coin with given balance owned by given address
+//
+// Mint 创建归给定地址所有的给定余额 SUI 代币
+func (s *Server) Mint(owner string, balance uint64) Coin {
+	return s.MintCoin(owner, SuiCoinType, balance)
+}
+
+// MintCoin creates coin of given type with given balance owned by given address
+//
+// MintCoin 创建归给定地址所有的给定类型和余额的代币
+func (s *Server) MintCoin(owner string, coinType string, balance uint64) Coin {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	coin := &Coin{
+		CoinObjectId: s.ledger.newObjectId(),
+		CoinType:     coinType,
+		Owner:        owner,
+		Balance:      balance,
+		Version:      1,
+		Digest:       s.ledger.newDigest(),
+	}
+	coin.PreviousTransaction = s.ledger.newDigest()
+	s.ledger.store(coin)
+	return *coin
+}
+
+// Coins returns copies of coins owned by address, ordered by object ID
+//
+// Coins 返回地址拥有的代币副本，按对象 ID 排序
+func (s *Server) Coins(owner string) []Coin {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var coins []Coin
+	for _, coin := range s.ledger.ownedCoins(owner, "") {
+		coins = append(coins, *coin)
+	}
+	return coins
+}
+
+// Balance returns total balance of given coin type owned by address
+//
+// Balance 返回地址拥有的给定代币类型的总余额
+func (s *Server) Balance(owner string, coinType string) uint64 {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var balance uint64
+	for _, coin := range s.ledger.ownedCoins(owner, coinType) {
+		balance += coin.Balance
+	}
+	return balance
+}
+
+// ServeHTTP handles single and batch JSON-RPC requests
+//
+// ServeHTTP 处理单个和批量 JSON-RPC 请求
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var response any
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		var requests []json.RawMessage
+		if err := json.Unmarshal(trimmed, &requests); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		responses := make([]map[string]any, 0, len(requests))
+		for _, request := range requests {
+			responses = append(responses, s.handle(request))
+		}
+		response = responses
+	} else {
+		response = s.handle(data)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// handle decodes one request and builds its response object
+//
+// handle 解码单个请求并构建其响应对象
+func (s *Server) handle(data []byte) map[string]any {
+	var request struct {
+		ID     json.RawMessage   `json:"id"`
+		Method string            `json:"method"`
+		Params []json.RawMessage `json:"params"`
+	}
+	if err := json.Unmarshal(data, &request); err != nil {
+		return map[string]any{"jsonrpc": "2.0", "id": nil, "error": &suirpc.RpcError{Code: suirpc.CodeInvalidRequest, Message: err.Error()}}
+	}
+
+	s.mutex.Lock()
+	result, rpcError := s.dispatch(request.Method, params(request.Params))
+	s.mutex.Unlock()
+
+	response := map[string]any{"jsonrpc": "2.0", "id": request.ID}
+	if rpcError != nil {
+		response["error"] = rpcError
+	} else {
+		response["result"] = result
+	}
+	return response
+}
+
+// dispatch routes method to its handler, caller holds the mutex
+//
+// dispatch 将方法路由到对应处理函数，调用方需持有互斥锁
+func (s *Server) dispatch(method string, params params) (any, *suirpc.RpcError) {
+	switch method {
+	case "sui_getChainIdentifier":
+		return s.chainId, nil
+	case "suix_getCoins":
+		return s.getCoins(params, true)
+	case "suix_getAllCoins":
+		return s.getCoins(params, false)
+	case "suix_getBalance":
+		return s.getBalance(params)
+	case "suix_getAllBalances":
+		return s.getAllBalances(params)
+	case "unsafe_pay":
+		return s.pay(params)
+	case "unsafe_paySui":
+		return s.paySui(params)
+	case "unsafe_payAllSui":
+		return s.payAllSui(params)
+	case "unsafe_transferSui":
+		return s.transferSui(params)
+	case "unsafe_splitCoin":
+		return s.splitCoin(params)
+	case "unsafe_splitCoinEqual":
+		return s.splitCoinEqual(params)
+	case "unsafe_mergeCoins":
+		return s.mergeCoins(params)
+	case "unsafe_transferObject":
+		return s.transferObject(params)
+	case "sui_dryRunTransactionBlock":
+		return s.dryRunTransactionBlock(params)
+	case "sui_executeTransactionBlock":
+		return s.executeTransactionBlock(params)
+	case "sui_getTransactionBlock":
+		return s.getTransactionBlock(params)
+	case "sui_multiGetTransactionBlocks":
+		return s.multiGetTransactionBlocks(params)
+	case "sui_getTotalTransactionBlocks":
+		return formatUint(uint64(len(s.transactions))), nil
+	case "sui_getLatestCheckpointSequenceNumber":
+		return formatUint(uint64(len(s.checkpoints) - 1)), nil
+	case "sui_getCheckpoint":
+		return s.getCheckpoint(params)
+	case "sui_getCheckpoints":
+		return s.getCheckpoints(params)
+	case "suix_getCoinMetadata":
+		return s.getCoinMetadata(params)
+	case "suix_getTotalSupply":
+		return s.getTotalSupply(params)
+	case "sui_getObject":
+		return s.getObject(params)
+	case "sui_multiGetObjects":
+		return s.multiGetObjects(params)
+	case "sui_tryGetPastObject":
+		return s.tryGetPastObject(params)
+	case "sui_tryMultiGetPastObjects":
+		return s.tryMultiGetPastObjects(params)
+	case "suix_getOwnedObjects":
+		return s.getOwnedObjects(params)
+	case "suix_getDynamicFields":
+		return s.getDynamicFields(params)
+	case "suix_getDynamicFieldObject":
+		return s.getDynamicFieldObject(params)
+	case "suix_queryEvents":
+		return s.queryEvents(params)
+	case "suix_queryTransactionBlocks":
+		return s.queryTransactionBlocks(params)
+	default:
+		return nil, &suirpc.RpcError{Code: suirpc.CodeMethodNotFound, Message: "Method not found: " + method}
+	}
+}
+
+// getCoins serves suix_getCoins and suix_getAllCoins with cursor pagination
+//
+// getCoins 提供带游标分页的 suix_getCoins 和 suix_getAllCoins
+func (s *Server) getCoins(params params, typed bool) (any, *suirpc.RpcError) {
+	owner, err := params.string(0)
+	if err != nil {
+		return nil, invalidParams(err)
+	}
+	coinType, next := SuiCoinType, 1
+	if typed {
+		if value, err := params.optionalString(1); err != nil {
+			return nil, invalidParams(err)
+		} else if value != "" {
+			coinType = value
+		}
+		next = 2
+	} else {
+		coinType = ""
+	}
+	cursor, err := params.optionalString(next)
+	if err != nil {
+		return nil, invalidParams(err)
+	}
+	limit, err := params.optionalUint(next + 1)
+	if err != nil {
+		return nil, invalidParams(err)
+	}
+	if limit == 0 {
+		limit = defaultPageLimit
+	}
+
+	return coinPage(s.ledger.ownedCoins(owner, coinType), cursor, limit, coinJSON), nil
+}
+
+// coinPage returns page of coins after cursor object ID, each coin rendered by render
+//
+// coinPage 返回游标对象 ID 之后的一页代币，每个代币由 render 渲染
+func coinPage(coins []*Coin, cursor string, limit uint64, render func(coin *Coin) map[string]any) map[string]any {
+	if cursor != "" {
+		first := slices.IndexFunc(coins, func(coin *Coin) bool { return coin.CoinObjectId > cursor })
+		if first < 0 {
+			first = len(coins)
+		}
+		coins = coins[first:]
+	}
+	hasNextPage := uint64(len(coins)) > limit
+	if hasNextPage {
+		coins = coins[:limit]
+	}
+
+	data := make([]map[string]any, 0, len(coins))
+	for _, coin := range coins {
+		data = append(data, render(coin))
+	}
+	var nextCursor any
+	if len(coins) > 0 {
+		nextCursor = coins[len(coins)-1].CoinObjectId
+	}
+	return map[string]any{"data": data, "hasNextPage": hasNextPage, "nextCursor": nextCursor}
+}
+
+// getBalance serves suix_getBalance
+//
+// getBalance 提供 suix_getBalance
+func (s *Server) getBalance(params params) (any, *suirpc.RpcError) {
+	owner, err := params.string(0)
+	if err != nil {
+		return nil, invalidParams(err)
+	}
+	coinType, err := params.optionalString(1)
+	if err != nil {
+		return nil, invalidParams(err)
+	}
+	if coinType == "" {
+		coinType = SuiCoinType
+	}
+	return balanceJSON(coinType, s.ledger.ownedCoins(owner, coinType)), nil
+}
+
+// getAllBalances serves suix_getAllBalances, one entry per coin type
+//
+// getAllBalances 提供 suix_getAllBalances，每种代币类型一项
+func (s *Server) getAllBalances(params params) (any, *suirpc.RpcError) {
+	owner, err := params.string(0)
+	if err != nil {
+		return nil, invalidParams(err)
+	}
+	groups := map[string][]*Coin{}
+	var coinTypes []string
+	for _, coin := range s.ledger.ownedCoins(owner, "") {
+		if _, ok := groups[coin.CoinType]; !ok {
+			coinTypes = append(coinTypes, coin.CoinType)
+		}
+		groups[coin.CoinType] = append(groups[coin.CoinType], coin)
+	}
+	slices.Sort(coinTypes)
+
+	results := make([]map[string]any, 0, len(coinTypes))
+	for _, coinType := range coinTypes {
+		results = append(results, balanceJSON(coinType, groups[coinType]))
+	}
+	return results, nil
+}
+
+// pay serves unsafe_pay, input coins of any one type go to recipients, gas comes apart
+// Params: signer, input coins, recipients, amounts, optional gas coin, gas budget
+//
+// pay 提供 unsafe_pay，任意同一类型的输入代币支付给接收方，gas 单独支付
+// 参数：签名者、输入代币、接收方、金额、可选 gas 代币、gas 预算
+func (s *Server) pay(params params) (any, *suirpc.RpcError) {
+	tx := &txData{Kind: "pay"}
+	var err error
+	if tx.Sender, err = params.string(0); err != nil {
+		return nil, invalidParams(err)
+	}
+	if tx.Coins, err = params.strings(1); err != nil {
+		return nil, invalidParams(err)
+	}
+	if tx.Recipients, err = params.strings(2); err != nil {
+		return nil, invalidParams(err)
+	}
+	if tx.Amounts, err = params.uints(3); err != nil {
+		return nil, invalidParams(err)
+	}
+	gasCoin, err := params.optionalString(4)
+	if err != nil {
+		return nil, invalidParams(err)
+	}
+	if tx.GasBudget, err = params.uint(5); err != nil {
+		return nil, invalidParams(err)
+	}
+	if len(tx.Coins) == 0 || len(tx.Recipients) != len(tx.Amounts) {
+		return nil, invalidParams(fmt.Errorf("need input coins and one amount per recipient"))
+	}
+	if slices.Contains(tx.Coins, gasCoin) {
+		return nil, invalidParams(fmt.Errorf("gas coin %s is also input coin", gasCoin))
+	}
+	return s.build(tx, gasCoin)
+}
+
+// paySui serves unsafe_paySui, first input coin pays gas
+// Params: signer, input coins, recipients, amounts, gas budget
+//
+// paySui 提供 unsafe_paySui，首个输入代币支付 gas
+// 参数：签名者、输入代币、接收方、金额、gas 预算
+func (s *Server) paySui(params params) (any, *suirpc.RpcError) {
+	tx := &txData{Kind: "paySui"}
+	var err error
+	if tx.Sender, err = params.string(0); err != nil {
+		return nil, invalidParams(err)
+	}
+	if tx.Coins, err = params.strings(1); err != nil {
+		return nil, invalidParams(err)
+	}
+	if tx.Recipients, err = params.strings(2); err != nil {
+		return nil, invalidParams(err)
+	}
+	if tx.Amounts, err = params.uints(3); err != nil {
+		return nil, invalidParams(err)
+	}
+	if tx.GasBudget, err = params.uint(4); err != nil {
+		return nil, invalidParams(err)
+	}
+	if len(tx.Coins) == 0 || len(tx.Recipients) != len(tx.Amounts) {
+		return nil, invalidParams(fmt.Errorf("need input coins and one amount per recipient"))
+	}
+	return s.build(tx, tx.Coins[0])
+}
+
+// payAllSui serves unsafe_payAllSui, merges input coins and sends all after gas
+// Params: signer, input coins, recipient, gas budget
+//
+// payAllSui 提供 unsafe_payAllSui，合并输入代币并发送扣除 gas 后的全部余额
+// 参数：签名者、输入代币、接收方、gas 预算
+func (s *Server) payAllSui(params params) (any, *suirpc.RpcError) {
+	tx := &txData{Kind: "payAllSui"}
+	var err error
+	if tx.Sender, err = params.string(0); err != nil {
+		return nil, invalidParams(err)
+	}
+	if tx.Coins, err = params.strings(1); err != nil {
+		return nil, invalidParams(err)
+	}
+	recipient, err := params.string(2)
+	if err != nil {
+		return nil, invalidParams(err)
+	}
+	tx.Recipients = []string{recipient}
+	if tx.GasBudget, err = params.uint(3); err != nil {
+		return nil, invalidParams(err)
+	}
+	if len(tx.Coins) == 0 {
+		return nil, invalidParams(fmt.Errorf("need input coins"))
+	}
+	return s.build(tx, tx.Coins[0])
+}
+
+// transferSui serves unsafe_transferSui, the coin pays gas and the amount
+// Params: signer, SUI coin, gas budget, recipient, optional amount
+//
+// transferSui 提供 unsafe_transferSui，由该代币支付 gas 和金额
+// 参数：签名者、SUI 代币、gas 预算、接收方、可选金额
+func (s *Server) transferSui(params params) (any, *suirpc.RpcError) {
+	tx := &txData{Kind: "transferSui"}
+	var err error
+	if tx.Sender, err = params.string(0); err != nil {
+		return nil, invalidParams(err)
+	}
+	coinId, err := params.string(1)
+	if err != nil {
+		return nil, invalidParams(err)
+	}
+	tx.Coins = []string{coinId}
+	if tx.GasBudget, err = params.uint(2); err != nil {
+		return nil, invalidParams(err)
+	}
+	recipient, err := params.string(3)
+	if err != nil {
+		return nil, invalidParams(err)
+	}
+	tx.Recipients = []string{recipient}
+	amount, err := params.optionalUint(4)
+	if err != nil {
+		return nil, invalidParams(err)
+	}
+	if amount > 0 {
+		tx.Amounts = []uint64{amount}
+	}
+	return s.build(tx, coinId)
+}
+
+// splitCoin serves unsafe_splitCoin
+// Params: signer, coin, split amounts, optional gas coin, gas budget
+//
+// splitCoin 提供 unsafe_splitCoin
+// 参数：签名者、代币、拆分金额、可选 gas 代币、gas 预算
+func (s *Server) splitCoin(params params) (any, *suirpc.RpcError) {
+	tx := &txData{Kind: "splitCoin"}
+	var err error
+	if tx.Sender, err = params.string(0); err != nil {
+		return nil, invalidParams(err)
+	}
+	coinId, err := params.string(1)
+	if err != nil {
+		return nil, invalidParams(err)
+	}
+	tx.Coins = []string{coinId}
+	if tx.Amounts, err = params.uints(2); err != nil {
+		return nil, invalidParams(err)
+	}
+	gasCoin, err := params.optionalString(3)
+	if err != nil {
+		return nil, invalidParams(err)
+	}
+	if tx.GasBudget, err = params.uint(4); err != nil {
+		return nil, invalidParams(err)
+	}
+	return s.build(tx, gasCoin)
+}
+
+// splitCoinEqual serves unsafe_splitCoinEqual, remainder stays in the coin
+// Params: signer, coin, split count, optional gas coin, gas budget
+//
+// splitCoinEqual 提供 unsafe_splitCoinEqual，余数留在原代币中
+// 参数：签名者、代币、拆分份数、可选 gas 代币、gas 预算
+func (s *Server) splitCoinEqual(params params) (any, *suirpc.RpcError) {
+	tx := &txData{Kind: "splitCoin"}
+	var err error
+	if tx.Sender, err = params.string(0); err != nil {
+		return nil, invalidParams(err)
+	}
+	coinId, err := params.string(1)
+	if err != nil {
+		return nil, invalidParams(err)
+	}
+	tx.Coins = []string{coinId}
+	count, err := params.uint(2)
+	if err != nil {
+		return nil, invalidParams(err)
+	}
+	coin, ok := s.ledger.coins[coinId]
+	if !ok || count == 0 {
+		return nil, invalidParams(fmt.Errorf("cannot split coin %s into %d parts", coinId, count))
+	}
+	for range count - 1 {
+		tx.Amounts = append(tx.Amounts, coin.Balance/count)
+	}
+	gasCoin, err := params.optionalString(3)
+	if err != nil {
+		return nil, invalidParams(err)
+	}
+	if tx.GasBudget, err = params.uint(4); err != nil {
+		return nil, invalidParams(err)
+	}
+	return s.build(tx, gasCoin)
+}
+
+// mergeCoins serves unsafe_mergeCoins
+// Params: signer, primary coin, coin to merge, optional gas coin, gas budget
+//
+// mergeCoins 提供 unsafe_mergeCoins
+// 参数：签名者、主代币、待合并代币、可选 gas 代币、gas 预算
+func (s *Server) mergeCoins(params params) (any, *suirpc.RpcError) {
+	tx := &txData{Kind: "mergeCoins"}
+	var err error
+	if tx.Sender, err = params.string(0); err != nil {
+		return nil, invalidParams(err)
+	}
+	primaryCoin, err := params.string(1)
+	if err != nil {
+		return nil, invalidParams(err)
+	}
+	coinToMerge, err := params.string(2)
+	if err != nil {
+		return nil, invalidParams(err)
+	}
+	if primaryCoin == coinToMerge {
+		return nil, invalidParams(fmt.Errorf("cannot merge coin %s into itself", primaryCoin))
+	}
+	tx.Coins = []string{primaryCoin, coinToMerge}
+	gasCoin, err := params.optionalString(3)
+	if err != nil {
+		return nil, invalidParams(err)
+	}
+	if tx.GasBudget, err = params.uint(4); err != nil {
+		return nil, invalidParams(err)
+	}
+	return s.build(tx, gasCoin)
+}
+
+// transferObject serves unsafe_transferObject
+// Params: signer, object, optional gas coin, gas budget, recipient
+//
+// transferObject 提供 unsafe_transferObject
+// 参数：签名者、对象、可选 gas 代币、gas 预算、接收方
+func (s *Server) transferObject(params params) (any, *suirpc.RpcError) {
+	tx := &txData{Kind: "transferObject"}
+	var err error
+	if tx.Sender, err = params.string(0); err != nil {
+		return nil, invalidParams(err)
+	}
+	objectId, err := params.string(1)
+	if err != nil {
+		return nil, invalidParams(err)
+	}
+	tx.Coins = []string{objectId}
+	gasCoin, err := params.optionalString(2)
+	if err != nil {
+		return nil, invalidParams(err)
+	}
+	if tx.GasBudget, err = params.uint(3); err != nil {
+		return nil, invalidParams(err)
+	}
+	recipient, err := params.string(4)
+	if err != nil {
+		return nil, invalidParams(err)
+	}
+	tx.Recipients = []string{recipient}
+	return s.build(tx, gasCoin)
+}
+
+// build checks operated coins, picks gas coin and encodes transaction bytes
+// Picks richest other SUI coin of sender when gas coin is empty
+//
+// build 检查操作的代币、选择 gas 代币并编码交易字节
+// gas 代币为空时选择发送方余额最多的其他 SUI 代币
+func (s *Server) build(tx *txData, gasCoin string) (any, *suirpc.RpcError) {
+	if gasCoin == "" {
+		coin := s.ledger.pickGasCoin(tx.Sender, tx.Coins)
+		if coin == nil {
+			return nil, transactionError(fmt.Errorf("no gas coin of %s outside transaction inputs", tx.Sender))
+		}
+		gasCoin = coin.CoinObjectId
+	}
+	tx.GasCoin = gasCoin
+
+	for _, objectId := range append(slices.Clone(tx.Coins), gasCoin) {
+		if slices.ContainsFunc(tx.Inputs, func(ref objectRef) bool { return ref.ObjectId == objectId }) {
+			continue
+		}
+		coin, ok := s.ledger.coins[objectId]
+		if !ok {
+			return nil, transactionError(fmt.Errorf("object %s not found", objectId))
+		}
+		if coin.Owner != tx.Sender {
+			return nil, transactionError(fmt.Errorf("object %s is not owned by %s", objectId, tx.Sender))
+		}
+		tx.Inputs = append(tx.Inputs, objectRef{ObjectId: coin.CoinObjectId, Version: coin.Version, Digest: coin.Digest})
+	}
+
+	s.nonce++
+	tx.Nonce = s.nonce
+	data, err := json.Marshal(tx)
+	if err != nil {
+		return nil, transactionError(err)
+	}
+	gasRef := tx.Inputs[slices.IndexFunc(tx.Inputs, func(ref objectRef) bool { return ref.ObjectId == gasCoin })]
+	return map[string]any{
+		"txBytes": base64.StdEncoding.EncodeToString(data),
+		"gas":     []map[string]any{refJSON(gasRef.ObjectId, gasRef.Version, gasRef.Digest)},
+	}, nil
+}
+
+// dryRunTransactionBlock serves sui_dryRunTransactionBlock without changing the ledger
+//
+// dryRunTransactionBlock 提供 sui_dryRunTransactionBlock，不改变账本
+func (s *Server) dryRunTransactionBlock(params params) (any, *suirpc.RpcError) {
+	txBytes, err := params.string(0)
+	if err != nil {
+		return nil, invalidParams(err)
+	}
+	tx, data, err := decodeTx(txBytes)
+	if err != nil {
+		return nil, invalidParams(err)
+	}
+	result, err := s.ledger.run(tx, s.gasCost)
+	if err != nil {
+		return nil, transactionError(err)
+	}
+	return s.newResponse(txDigest(data), tx, result), nil
+}
+
+// executeTransactionBlock serves sui_executeTransactionBlock
+// Verifies sender signature, then commits effects, failed ones still pay gas
+// Params: txBytes, signatures, options, request type
+//
+// executeTransactionBlock 提供 sui_executeTransactionBlock
+// 先验证发送方签名，再提交效果，失败的交易仍支付 gas
+// 参数：交易字节、签名列表、选项、请求类型
+func (s *Server) executeTransactionBlock(params params) (any, *suirpc.RpcError) {
+	txBytes, err := params.string(0)
+	if err != nil {
+		return nil, invalidParams(err)
+	}
+	tx, data, err := decodeTx(txBytes)
+	if err != nil {
+		return nil, invalidParams(err)
+	}
+	signatures, err := params.strings(1)
+	if err != nil {
+		return nil, invalidParams(err)
+	}
+	if len(signatures) == 0 {
+		return nil, invalidParams(fmt.Errorf("need at least one signature"))
+	}
+	if err := verifySignatures(tx.Sender, data, signatures); err != nil {
+		return nil, transactionError(err)
+	}
+	options, err := params.options(2)
+	if err != nil {
+		return nil, invalidParams(err)
+	}
+	requestType, err := params.optionalString(3)
+	if err != nil {
+		return nil, invalidParams(err)
+	}
+	// Like fullnodes, object and balance changes need local execution
+	// 与全节点一致，对象变更和余额变更需要本地执行
+	if requestType == "WaitForEffectsCert" && (options["showObjectChanges"] || options["showBalanceChanges"]) {
+		return nil, invalidParams(fmt.Errorf("request type WaitForEffectsCert can not show object or balance changes"))
+	}
+
+	result, err := s.ledger.run(tx, s.gasCost)
+	if err != nil {
+		return nil, transactionError(err)
+	}
+	digest := txDigest(data)
+	s.ledger.commit(result, digest)
+
+	response := s.newResponse(digest, tx, result)
+	s.transactions[digest] = response
+	s.indexes[digest] = newTxIndex(tx, result)
+	s.appendCheckpoint(response, s.gasCost)
+	view := responseView(response, options)
+	view["confirmedLocalExecution"] = requestType != "WaitForEffectsCert"
+	return view, nil
+}
+
+// responseSections maps response options to the sections they show
+//
+// responseSections 将响应选项映射到其展示的部分
+var responseSections = map[string]string{
+	"showEffects":        "effects",
+	"showEvents":         "events",
+	"showObjectChanges":  "objectChanges",
+	"showBalanceChanges": "balanceChanges",
+}
+
+// responseView returns copy of response holding sections asked for in options
+// Nil options keep each section, the simulator is lenient there unlike fullnodes
+//
+// responseView 返回仅包含选项所请求部分的响应副本
+// options 为 nil 时保留每个部分，模拟器在此比全节点宽松
+func responseView(response map[string]any, options map[string]bool) map[string]any {
+	view := make(map[string]any, len(response))
+	for key, value := range response {
+		view[key] = value
+	}
+	if options == nil {
+		return view
+	}
+	for option, section := range responseSections {
+		if !options[option] {
+			delete(view, section)
+		}
+	}
+	return view
+}
+
+// getTransactionBlock serves sui_getTransactionBlock from executed transactions
+//
+// getTransactionBlock 从已执行交易中提供 sui_getTransactionBlock
+func (s *Server) getTransactionBlock(params params) (any, *suirpc.RpcError) {
+	digest, err := params.string(0)
+	if err != nil {
+		return nil, invalidParams(err)
+	}
+	options, err := params.options(1)
+	if err != nil {
+		return nil, invalidParams(err)
+	}
+	response, ok := s.transactions[digest]
+	if !ok {
+		return nil, &suirpc.RpcError{Code: codeTransactionError, Message: fmt.Sprintf("Could not find the referenced transaction [TransactionDigest(%s)].", digest)}
+	}
+	return responseView(response, options), nil
+}
+
+// newResponse builds transaction block response with effects, object and balance changes
+//
+// newResponse 构建带有效果、对象变更和余额变更的交易区块响应
+func (s *Server) newResponse(digest string, tx *txData, result *outcome) map[string]any {
+	status := map[string]any{"status": "success"}
+	if result.failure != "" {
+		status = map[string]any{"status": "failure", "error": result.failure}
+	}
+
+	objectIds := make([]string, 0, len(result.after))
+	for objectId := range result.after {
+		objectIds = append(objectIds, objectId)
+	}
+	slices.Sort(objectIds)
+
+	created, mutated, deleted := []any{}, []any{}, []any{}
+	objectChanges := []any{}
+	deltas := map[[2]string]int64{}
+	var gasObject any
+	for _, objectId := range objectIds {
+		before, after := result.before[objectId], result.after[objectId]
+		if before != nil {
+			deltas[[2]string{before.Owner, before.CoinType}] -= int64(before.Balance)
+		}
+		switch {
+		case after == nil:
+			deleted = append(deleted, refJSON(objectId, before.Version, before.Digest))
+			objectChanges = append(objectChanges, map[string]any{
+				"type": "deleted", "sender": tx.Sender, "objectType": coinObjectType(before.CoinType),
+				"objectId": objectId, "version": formatUint(before.Version),
+			})
+			continue
+		case before == nil:
+			created = append(created, ownedRefJSON(after))
+			objectChanges = append(objectChanges, map[string]any{
+				"type": "created", "sender": tx.Sender, "owner": ownerJSON(after.Owner), "objectType": coinObjectType(after.CoinType),
+				"objectId": objectId, "version": formatUint(after.Version), "digest": after.Digest,
+			})
+		default:
+			mutated = append(mutated, ownedRefJSON(after))
+			objectChanges = append(objectChanges, map[string]any{
+				"type": "mutated", "sender": tx.Sender, "owner": ownerJSON(after.Owner), "objectType": coinObjectType(after.CoinType),
+				"objectId": objectId, "version": formatUint(after.Version), "previousVersion": formatUint(before.Version), "digest": after.Digest,
+			})
+		}
+		deltas[[2]string{after.Owner, after.CoinType}] += int64(after.Balance)
+		if objectId == tx.GasCoin {
+			gasObject = ownedRefJSON(after)
+		}
+	}
+
+	keys := make([][2]string, 0, len(deltas))
+	for key, amount := range deltas {
+		if amount != 0 {
+			keys = append(keys, key)
+		}
+	}
+	slices.SortFunc(keys, func(a, b [2]string) int {
+		if a[0] != b[0] {
+			return compareString(a[0], b[0])
+		}
+		return compareString(a[1], b[1])
+	})
+	balanceChanges := make([]any, 0, len(keys))
+	for _, key := range keys {
+		balanceChanges = append(balanceChanges, map[string]any{
+			"owner": ownerJSON(key[0]), "coinType": key[1], "amount": strconv.FormatInt(deltas[key], 10),
+		})
+	}
+
+	return map[string]any{
+		"digest": digest,
+		"effects": map[string]any{
+			"messageVersion":    "v1",
+			"status":            status,
+			"executedEpoch":     "0",
+			"gasUsed":           map[string]any{"computationCost": formatUint(s.gasCost), "storageCost": "0", "storageRebate": "0", "nonRefundableStorageFee": "0"},
+			"transactionDigest": digest,
+			"created":           created,
+			"mutated":           mutated,
+			"deleted":           deleted,
+			"gasObject":         gasObject,
+		},
+		"events":         []any{},
+		"objectChanges":  objectChanges,
+		"balanceChanges": balanceChanges,
+	}
+}
+
+// verifySignatures checks each signature and requires one of them from sender
+// Other signers stand for sponsors paying gas of the transaction
+//
+// verifySignatures 检查每个签名，并要求其中一个来自发送方
+// 其他签名者代表为交易支付 gas 的赞助方
+func verifySignatures(sender string, txBytes []byte, signatures []string) error {
+	signers := make([]string, 0, len(signatures))
+	for _, signature := range signatures {
+		signer, err := recoverSigner(txBytes, signature)
+		if err != nil {
+			return err
+		}
+		signers = append(signers, signer)
+	}
+	if !slices.Contains(signers, sender) {
+		return fmt.Errorf("invalid user signature: signers %s do not include sender %s", strings.Join(signers, ", "), sender)
+	}
+	return nil
+}
+
+// recoverSigner checks serialized Ed25519 signature over intent-prefixed transaction hash and returns signer address
+// Layout: flag 0x00, 64-byte signature, 32-byte public key, same as suisigntx.SignTx
+//
+// recoverSigner 检查针对带意图前缀的交易哈希的序列化 Ed25519 签名并返回签名者地址
+// 布局：标志 0x00、64 字节签名、32 字节公钥，与 suisigntx.SignTx 相同
+func recoverSigner(txBytes []byte, signature string) (string, error) {
+	data, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return "", fmt.Errorf("invalid user signature: %w", err)
+	}
+	if len(data) != 1+ed25519.SignatureSize+ed25519.PublicKeySize || data[0] != 0x00 {
+		return "", fmt.Errorf("invalid user signature: want ed25519 flag and %d bytes, got %d bytes", 1+ed25519.SignatureSize+ed25519.PublicKeySize, len(data))
+	}
+	publicKey := ed25519.PublicKey(data[1+ed25519.SignatureSize:])
+
+	txHash := blake2b.Sum256(append([]byte{0, 0, 0}, txBytes...))
+	if !ed25519.Verify(publicKey, txHash[:], data[1:1+ed25519.SignatureSize]) {
+		return "", fmt.Errorf("invalid user signature: signature does not match transaction")
+	}
+	authKey := blake2b.Sum256(append([]byte{0x00}, publicKey...))
+	return fmt.Sprintf("0x%x", authKey), nil
+}
+
+// decodeTx decodes base64 transaction bytes built by this simulator
+//
+// decodeTx 解码本模拟器构建的 base64 交易字节
+func decodeTx(txBytes string) (*txData, []byte, error) {
+	data, err := base64.StdEncoding.DecodeString(txBytes)
+	if err != nil {
+		return nil, nil, err
+	}
+	var tx txData
+	if err := json.Unmarshal(data, &tx); err != nil {
+		return nil, nil, fmt.Errorf("txBytes not built by simulator: %w", err)
+	}
+	return &tx, data, nil
+}
+
+// txDigest returns base58 digest of transaction bytes
+//
+// txDigest 返回交易字节的 base58 摘要
+func txDigest(data []byte) string {
+	hash := blake2b.Sum256(append([]byte("TransactionData::"), data...))
+	return encodeBase58(hash[:])
+}
+
+// invalidParams wraps error as JSON-RPC invalid params error
+//
+// invalidParams 将错误包装为 JSON-RPC 无效参数错误
+func invalidParams(err error) *suirpc.RpcError {
+	return &suirpc.RpcError{Code: suirpc.CodeInvalidParams, Message: "Invalid params: " + err.Error()}
+}
+
+// transactionError wraps error as rejected transaction error
+//
+// transactionError 将错误包装为交易被拒绝的错误
+func transactionError(err error) *suirpc.RpcError {
+	return &suirpc.RpcError{Code: codeTransactionError, Message: err.Error()}
+}
+
+// coinJSON returns coin in suix_getCoins shape
+//
+// coinJSON 返回 suix_getCoins 格式的代币
+func coinJSON(coin *Coin) map[string]any {
+	return map[string]any{
+		"coinType":            coin.CoinType,
+		"coinObjectId":        coin.CoinObjectId,
+		"version":             formatUint(coin.Version),
+		"digest":              coin.Digest,
+		"balance":             formatUint(coin.Balance),
+		"previousTransaction": coin.PreviousTransaction,
+	}
+}
+
+// balanceJSON returns balance of coins in suix_getBalance shape
+//
+// balanceJSON 返回 suix_getBalance 格式的代币余额
+func balanceJSON(coinType string, coins []*Coin) map[string]any {
+	var total uint64
+	for _, coin := range coins {
+		total += coin.Balance
+	}
+	return map[string]any{
+		"coinType":        coinType,
+		"coinObjectCount": len(coins),
+		"totalBalance":    formatUint(total),
+		"lockedBalance":   map[string]any{},
+	}
+}
+
+// refJSON returns object reference JSON
+//
+// refJSON 返回对象引用 JSON
+func refJSON(objectId string, version uint64, digest string) map[string]any {
+	return map[string]any{"objectId": objectId, "version": version, "digest": digest}
+}
+
+// ownedRefJSON returns owned object reference JSON used in effects
+//
+// ownedRefJSON 返回效果中使用的带所有者对象引用 JSON
+func ownedRefJSON(coin *Coin) map[string]any {
+	return map[string]any{"owner": ownerJSON(coin.Owner), "reference": refJSON(coin.CoinObjectId, coin.Version, coin.Digest)}
+}
+
+// ownerJSON returns address owner JSON
+//
+// ownerJSON 返回地址所有者 JSON
+func ownerJSON(address string) map[string]any {
+	return map[string]any{"AddressOwner": address}
+}
+
+// coinObjectType returns Move object type of coin with given coin type
+//
+// coinObjectType 返回给定代币类型的代币 Move 对象类型
+func coinObjectType(coinType string) string {
+	return "0x2::coin::Coin<" + coinType + ">"
+}
@@ -0,0 +1,184 @@
+package suirpctest_test
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/go-xlan/sui-go-guide/suiapi"
+	"github.com/go-xlan/sui-go-guide/suirpc"
+	"github.com/go-xlan/sui-go-guide/suirpctest"
+	"github.com/go-xlan/sui-go-guide/suisigntx"
+	"github.com/go-xlan/sui-go-guide/suiwallet"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	address       = "0x353a47f8fedca2d8cd1352222300f06b1f36789a55fffdecc6fe414ee1998969"
+	privateKeyHex = "0e51bb6e96264505b7c36c71d6a7f8053ed73b20f6f4476fb4f7877b8934ae6b"
+	recipient     = "0x207ed5c0ad36b96c730ed0f71e3c26a0ffb59bc20ab21d08067ca4c035d4d062"
+	gasBudget     = "10000000"
+)
+
+// newServer starts simulator closed at test end
+//
+// newServer 启动在测试结束时关闭的模拟器
+func newServer(t *testing.T) *suirpctest.Server {
+	server := suirpctest.NewServer()
+	t.Cleanup(server.Close)
+	return server
+}
+
+// execute builds transaction, dry-runs it, signs it and executes it like the demos do
+//
+// execute 像演示程序一样构建交易、模拟执行、签名并执行
+func execute(t *testing.T, client *suirpc.Client, method string, params ...any) *suiapi.DigestMessage {
+	ctx := context.Background()
+	built, err := suirpc.Call[suiapi.TxBytesMessage](ctx, client, &suirpc.RpcRequest{Jsonrpc: "2.0", Method: method, Params: params})
+	require.NoError(t, err)
+	txBytes := built.Result.TxBytes
+
+	dryRun, err := suiapi.DryRunTransactionBlock[suiapi.EffectsStatusStatusMessage](ctx, client, txBytes)
+	require.NoError(t, err)
+	require.Equal(t, "success", dryRun.Effects.Status.Status)
+
+	signatures, err := suisigntx.Sign(privateKeyHex, txBytes)
+	require.NoError(t, err)
+	res, err := suiapi.ExecuteTransactionBlock[suiapi.DigestMessage](ctx, client, txBytes, signatures)
+	require.NoError(t, err)
+	require.NotEmpty(t, res.Digest)
+	return res
+}
+
+// TestServer_SplitMergePay tests split, merge and pay flows end-to-end against the simulator
+//
+// TestServer_SplitMergePay 测试拆分、合并和支付流程针对模拟器端到端运行
+func TestServer_SplitMergePay(t *testing.T) {
+	server := newServer(t)
+	client := server.Client()
+	coin := server.Mint(address, 300_000_000)
+	gasCoin := server.Mint(address, 50_000_000)
+
+	suiCoins, err := suiapi.GetSuiCoinsInTopPage(context.Background(), client, address)
+	require.NoError(t, err)
+	require.Len(t, suiCoins, 2)
+
+	// Split picks the other coin to pay gas
+	// 拆分时选择另一个代币支付 gas
+	execute(t, client, "unsafe_splitCoin", address, coin.CoinObjectId, []string{"100000000", "50000000"}, nil, gasBudget)
+	require.Len(t, server.Coins(address), 4)
+	require.Equal(t, uint64(350_000_000-suirpctest.DefaultGasCost), server.Balance(address, suirpctest.SuiCoinType))
+
+	coins := server.Coins(address)
+	var parts []suirpctest.Coin
+	for _, item := range coins {
+		if item.CoinObjectId != coin.CoinObjectId && item.CoinObjectId != gasCoin.CoinObjectId {
+			parts = append(parts, item)
+		}
+	}
+	require.Len(t, parts, 2)
+	require.Equal(t, uint64(2), parts[0].Version)
+
+	execute(t, client, "unsafe_mergeCoins", address, parts[0].CoinObjectId, parts[1].CoinObjectId, nil, gasBudget)
+	require.Len(t, server.Coins(address), 3)
+
+	execute(t, client, "unsafe_paySui", address, []string{coin.CoinObjectId}, []string{recipient}, []string{"20000000"}, gasBudget)
+	require.Equal(t, uint64(20_000_000), server.Balance(recipient, suirpctest.SuiCoinType))
+	require.Equal(t, uint64(330_000_000-3*suirpctest.DefaultGasCost), server.Balance(address, suirpctest.SuiCoinType))
+
+	balance, err := suirpc.Call[map[string]any](context.Background(), client, &suirpc.RpcRequest{Jsonrpc: "2.0", Method: "suix_getBalance", Params: []any{recipient}})
+	require.NoError(t, err)
+	require.Equal(t, "20000000", balance.Result["totalBalance"])
+}
+
+// TestServer_Signature tests execution rejects foreign signatures and replayed transactions
+//
+// TestServer_Signature 测试执行拒绝他人的签名和重放的交易
+func TestServer_Signature(t *testing.T) {
+	server := newServer(t)
+	client := server.Client()
+	coin := server.Mint(address, 100_000_000)
+
+	ctx := context.Background()
+	built, err := suirpc.Call[suiapi.TxBytesMessage](ctx, client, &suirpc.RpcRequest{Jsonrpc: "2.0", Method: "unsafe_transferSui", Params: []any{address, coin.CoinObjectId, gasBudget, recipient, "1000"}})
+	require.NoError(t, err)
+	txBytes := built.Result.TxBytes
+
+	other, err := suiwallet.NewWallet(make([]byte, 32))
+	require.NoError(t, err)
+	signatures, err := suisigntx.Sign(hex.EncodeToString(make([]byte, 32)), txBytes)
+	require.NoError(t, err)
+	_, err = suiapi.ExecuteTransactionBlock[suiapi.DigestMessage](ctx, client, txBytes, signatures)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), other.Address())
+
+	signatures, err = suisigntx.Sign(privateKeyHex, txBytes)
+	require.NoError(t, err)
+	_, err = suiapi.ExecuteTransactionBlock[suiapi.DigestMessage](ctx, client, txBytes, signatures)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1000), server.Balance(recipient, suirpctest.SuiCoinType))
+
+	// Same bytes again refer to stale object versions
+	// 再次提交相同字节会引用过期的对象版本
+	_, err = suiapi.ExecuteTransactionBlock[suiapi.DigestMessage](ctx, client, txBytes, signatures)
+	var rpcError *suirpc.RpcError
+	require.True(t, errors.As(err, &rpcError))
+	require.Contains(t, rpcError.Message, "unavailable")
+}
+
+// TestServer_DryRunFailure tests failing transaction reports failure status and still pays gas
+//
+// TestServer_DryRunFailure 测试失败的交易报告失败状态且仍支付 gas
+func TestServer_DryRunFailure(t *testing.T) {
+	server := newServer(t)
+	client := server.Client()
+	coin := server.Mint(address, 100_000_000)
+	server.Mint(address, 50_000_000)
+
+	ctx := context.Background()
+	built, err := suirpc.Call[suiapi.TxBytesMessage](ctx, client, &suirpc.RpcRequest{Jsonrpc: "2.0", Method: "unsafe_splitCoin", Params: []any{address, coin.CoinObjectId, []string{"200000000"}, nil, gasBudget}})
+	require.NoError(t, err)
+	txBytes := built.Result.TxBytes
+
+	_, err = suiapi.DryRunTransactionBlock[suiapi.EffectsStatusStatusMessage](ctx, client, txBytes)
+	require.ErrorIs(t, err, suirpc.ErrExecutionFailed)
+	require.Equal(t, uint64(150_000_000), server.Balance(address, suirpctest.SuiCoinType))
+
+	signatures, err := suisigntx.Sign(privateKeyHex, txBytes)
+	require.NoError(t, err)
+	_, err = suiapi.ExecuteTransactionBlock[suiapi.DigestMessage](ctx, client, txBytes, signatures)
+	var executionError *suirpc.ExecutionError
+	require.True(t, errors.As(err, &executionError))
+	require.Contains(t, executionError.Message, "InsufficientCoinBalance")
+	require.Equal(t, uint64(150_000_000-suirpctest.DefaultGasCost), server.Balance(address, suirpctest.SuiCoinType))
+}
+
+// TestServer_GetCoins tests coin pages follow cursor until the last page
+//
+// TestServer_GetCoins 测试代币分页按游标翻页直到最后一页
+func TestServer_GetCoins(t *testing.T) {
+	server := newServer(t)
+	for idx := range 5 {
+		server.Mint(address, uint64(idx+1)*1000)
+	}
+
+	type Page struct {
+		Data        []*suiapi.CoinType `json:"data"`
+		HasNextPage bool               `json:"hasNextPage"`
+		NextCursor  *string            `json:"nextCursor"`
+	}
+	var coins []*suiapi.CoinType
+	var cursor *string
+	for {
+		page, err := suirpc.Call[Page](context.Background(), server.Client(), &suirpc.RpcRequest{Jsonrpc: "2.0", Method: "suix_getCoins", Params: []any{address, nil, cursor, 2}})
+		require.NoError(t, err)
+		coins = append(coins, page.Result.Data...)
+		if !page.Result.HasNextPage {
+			break
+		}
+		cursor = page.Result.NextCursor
+	}
+	require.Len(t, coins, 5)
+	require.Equal(t, server.Coins(address)[4].CoinObjectId, coins[4].CoinObjectId)
+}